@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_aliases" "logs" {
+  name = "logs-*"
+}
+
+output "logs_write_indices" {
+  value = { for a in data.elasticstack_elasticsearch_aliases.logs.aliases : a.name => a.index if a.is_write_index }
+}
@@ -0,0 +1,13 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_cluster_health" "health" {
+  wait_for_status = "green"
+  wait_for_nodes  = ">=3"
+  timeout         = "2m"
+}
+
+output "cluster_status" {
+  value = data.elasticstack_elasticsearch_cluster_health.health.status
+}
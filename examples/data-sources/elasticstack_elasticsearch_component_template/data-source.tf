@@ -0,0 +1,24 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_component_template" "logs" {
+  name = "logs-*"
+}
+
+locals {
+  components = ["logs-mappings", "logs-settings"]
+}
+
+resource "elasticstack_elasticsearch_index_template" "logs" {
+  name           = "logs"
+  index_patterns = ["logs-app-*"]
+  composed_of    = local.components
+
+  lifecycle {
+    precondition {
+      condition     = alltrue([for c in local.components : contains(data.elasticstack_elasticsearch_component_template.logs.component_templates[*].name, c)])
+      error_message = "The component templates of the logs must exist."
+    }
+  }
+}
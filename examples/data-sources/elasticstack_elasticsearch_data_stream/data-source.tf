@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_data_stream" "logs" {
+  name = "logs-app-default"
+}
+
+output "write_index" {
+  value = element(data.elasticstack_elasticsearch_data_stream.logs.indices, length(data.elasticstack_elasticsearch_data_stream.logs.indices) - 1).index_name
+}
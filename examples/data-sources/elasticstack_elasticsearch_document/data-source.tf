@@ -0,0 +1,13 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_document" "country" {
+  index           = "countries"
+  document_id     = "fr"
+  source_includes = ["name", "capital"]
+}
+
+output "capital" {
+  value = jsondecode(data.elasticstack_elasticsearch_document.country.source).capital
+}
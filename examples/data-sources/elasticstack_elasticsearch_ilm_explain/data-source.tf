@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_ilm_explain" "failed" {
+  index       = "logs-*"
+  only_errors = true
+}
+
+output "failed_indices" {
+  value = { for i in data.elasticstack_elasticsearch_ilm_explain.failed.indices : i.index => i.error }
+}
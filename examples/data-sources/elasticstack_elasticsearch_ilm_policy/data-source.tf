@@ -0,0 +1,15 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_ilm_policy" "logs" {
+  name = "logs"
+}
+
+output "logs_policy_indices" {
+  value = data.elasticstack_elasticsearch_ilm_policy.logs.in_use_by_indices
+}
+
+output "logs_policy_templates" {
+  value = data.elasticstack_elasticsearch_ilm_policy.logs.in_use_by_composable_templates
+}
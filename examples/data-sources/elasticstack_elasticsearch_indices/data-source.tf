@@ -0,0 +1,13 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_indices" "logs" {
+  target         = "logs-*"
+  include_hidden = true
+  include_closed = true
+}
+
+output "closed_logs_indices" {
+  value = [for i in data.elasticstack_elasticsearch_indices.logs.indices : i.name if i.closed]
+}
@@ -0,0 +1,17 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_nodes" "hot" {
+  role = "data_hot"
+}
+
+// one primary shard per hot node
+resource "elasticstack_elasticsearch_index" "logs" {
+  name             = "logs"
+  number_of_shards = length(data.elasticstack_elasticsearch_nodes.hot.nodes)
+}
+
+output "hot_nodes" {
+  value = [for n in data.elasticstack_elasticsearch_nodes.hot.nodes : n.name]
+}
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_script" "my_script" {
+  script_id = "my_script"
+}
+
+output "script_lang" {
+  value = data.elasticstack_elasticsearch_script.my_script.lang
+}
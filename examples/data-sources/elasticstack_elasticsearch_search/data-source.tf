@@ -0,0 +1,20 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_search" "errors" {
+  index = "logs-*"
+  query = jsonencode({
+    term = { "log.level" = "error" }
+  })
+  size            = 5
+  source_includes = ["@timestamp", "message"]
+}
+
+output "error_count" {
+  value = data.elasticstack_elasticsearch_search.errors.total
+}
+
+output "sample_errors" {
+  value = [for hit in data.elasticstack_elasticsearch_search.errors.hits : jsondecode(hit.source).message]
+}
@@ -0,0 +1,26 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_privileges" "available" {}
+
+variable "index_privileges" {
+  type    = list(string)
+  default = ["read", "view_index_metadata"]
+}
+
+resource "elasticstack_elasticsearch_security_role" "reader" {
+  name = "reader"
+
+  indices {
+    names      = ["logs-*"]
+    privileges = var.index_privileges
+  }
+
+  lifecycle {
+    precondition {
+      condition     = alltrue([for p in var.index_privileges : contains(data.elasticstack_elasticsearch_security_privileges.available.index, p)])
+      error_message = "Unknown index privilege."
+    }
+  }
+}
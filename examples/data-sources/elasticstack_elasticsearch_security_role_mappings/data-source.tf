@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_role_mappings" "all" {}
+
+output "superuser_mappings" {
+  value = [
+    for m in data.elasticstack_elasticsearch_security_role_mappings.all.role_mappings : m.name
+    if contains(m.roles, "superuser")
+  ]
+}
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_roles" "custom" {
+  include_reserved = false
+}
+
+output "roles_with_cluster_privileges" {
+  value = [for r in data.elasticstack_elasticsearch_security_roles.custom.roles : r.name if length(r.cluster) > 0]
+}
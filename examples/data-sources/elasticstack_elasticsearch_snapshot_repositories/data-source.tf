@@ -0,0 +1,9 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_snapshot_repositories" "all" {}
+
+output "repository_names" {
+  value = data.elasticstack_elasticsearch_snapshot_repositories.all.repositories[*].name
+}
@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_sql_query" "services" {
+  query    = "SELECT service.name, COUNT(*) AS docs FROM \"logs-*\" GROUP BY service.name"
+  max_rows = 100
+}
+
+output "services" {
+  value = { for row in jsondecode(data.elasticstack_elasticsearch_sql_query.services.rows_json) : row[0] => row[1] }
+}
@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_tasks" "reindex" {
+  actions  = ["*reindex"]
+  detailed = true
+}
+
+output "running_reindex" {
+  value = { for t in data.elasticstack_elasticsearch_tasks.reindex.tasks : t.task_id => t.running_time }
+}
@@ -0,0 +1,22 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_transform_preview" "orders_by_customer" {
+  source_indices = ["orders-*"]
+  source_query = jsonencode({
+    range = { "@timestamp" = { gte = "now-7d" } }
+  })
+  pivot = jsonencode({
+    group_by = {
+      customer = { terms = { field = "customer.id" } }
+    }
+    aggregations = {
+      total_amount = { sum = { field = "amount" } }
+    }
+  })
+}
+
+output "sample_totals" {
+  value = [for doc in data.elasticstack_elasticsearch_transform_preview.orders_by_customer.preview : jsondecode(doc).total_amount]
+}
@@ -0,0 +1,15 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_watch" "test" {
+  watch_id       = "my_watch"
+  triggered_time = "2022-01-01T00:00:00Z"
+  alternative_input = jsonencode({
+    hits = { total = 10 }
+  })
+}
+
+output "condition_met" {
+  value = data.elasticstack_elasticsearch_watch.test.condition_met
+}
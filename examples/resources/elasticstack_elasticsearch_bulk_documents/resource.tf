@@ -0,0 +1,17 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "countries" {
+  name = "countries"
+}
+
+resource "elasticstack_elasticsearch_bulk_documents" "countries" {
+  index   = elasticstack_elasticsearch_index.countries.name
+  refresh = "wait_for"
+
+  documents = {
+    for code, name in { fr = "France", de = "Germany", jp = "Japan" } :
+    code => jsonencode({ code = code, name = name })
+  }
+}
@@ -1 +1,3 @@
 terraform import elasticstack_elasticsearch_component_template.my_template <cluster_uuid>/<component_name>
+# or by the template name, using the cluster of the provider connection
+terraform import elasticstack_elasticsearch_component_template.my_template <component_name>
@@ -0,0 +1,22 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_delete_by_query" "purge" {
+  index = "logs-*"
+  query = jsonencode({
+    range = { "@timestamp" = { lt = "now-90d" } }
+  })
+
+  conflicts           = "proceed"
+  wait_for_completion = false
+}
+
+// wait for the purge in a separate resource, e.g. with a longer timeout
+resource "elasticstack_elasticsearch_task_wait" "purge" {
+  task_id = elasticstack_elasticsearch_delete_by_query.purge.task_id
+
+  timeouts {
+    create = "3h"
+  }
+}
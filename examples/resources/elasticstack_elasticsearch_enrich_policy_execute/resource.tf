@@ -0,0 +1,16 @@
+variable "users_version" {
+  type = string
+}
+
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_enrich_policy_execute" "users" {
+  name = "users-policy"
+
+  # execute the policy again when the users are reloaded
+  trigger = {
+    users_version = var.users_version
+  }
+}
@@ -34,3 +34,39 @@ resource "elasticstack_elasticsearch_index" "my_index" {
   search_idle_after     = "20s"
   total_shards_per_node = 200
 }
+
+resource "elasticstack_elasticsearch_index" "my_analyzed_index" {
+  name = "my-analyzed-index"
+
+  analysis {
+    analyzer {
+      name      = "autocomplete"
+      tokenizer = "autocomplete_tokenizer"
+      filter    = ["lowercase", "english_stop"]
+    }
+
+    tokenizer {
+      name = "autocomplete_tokenizer"
+      type = "edge_ngram"
+      settings = jsonencode({
+        min_gram    = 2
+        max_gram    = 10
+        token_chars = ["letter", "digit"]
+      })
+    }
+
+    filter {
+      name = "english_stop"
+      type = "stop"
+      settings = jsonencode({
+        stopwords = "_english_"
+      })
+    }
+  }
+
+  mappings = jsonencode({
+    properties = {
+      title = { type = "text", analyzer = "autocomplete" }
+    }
+  })
+}
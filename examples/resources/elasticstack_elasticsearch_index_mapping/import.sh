@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_index_mapping.my_mapping <cluster_uuid>/<index_name>
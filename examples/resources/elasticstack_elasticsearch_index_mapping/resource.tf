@@ -0,0 +1,25 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "my_index" {
+  name = "my-index"
+
+  // the mappings are managed by the dedicated resource
+  lifecycle {
+    ignore_changes = [mappings]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_mapping" "my_mapping" {
+  index = elasticstack_elasticsearch_index.my_index.name
+  properties = jsonencode({
+    user_id = { type = "keyword" }
+    message = { type = "text" }
+    address = {
+      properties = {
+        city = { type = "keyword" }
+      }
+    }
+  })
+}
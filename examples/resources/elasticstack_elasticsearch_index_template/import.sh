@@ -1 +1,3 @@
 terraform import elasticstack_elasticsearch_index_template.my_template <cluster_uuid>/<template_name>
+# or by the template name, using the cluster of the provider connection
+terraform import elasticstack_elasticsearch_index_template.my_template <template_name>
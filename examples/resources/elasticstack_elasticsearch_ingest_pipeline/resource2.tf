@@ -8,11 +8,28 @@ data "elasticstack_elasticsearch_ingest_processor_json" "parse_string_source" {
   target_field = "json_target"
 }
 
+data "elasticstack_elasticsearch_ingest_processor_geoip" "geoip" {
+  field        = "source.ip"
+  target_field = "source.geo"
+}
+
+data "elasticstack_elasticsearch_ingest_processor_user_agent" "user_agent" {
+  field = "user_agent.original"
+}
+
 resource "elasticstack_elasticsearch_ingest_pipeline" "ingest" {
   name = "set-parse"
 
   processors = [
     data.elasticstack_elasticsearch_ingest_processor_set.set_count.json,
-    data.elasticstack_elasticsearch_ingest_processor_json.parse_string_source.json
+    data.elasticstack_elasticsearch_ingest_processor_json.parse_string_source.json,
+    data.elasticstack_elasticsearch_ingest_processor_geoip.geoip.json,
+    data.elasticstack_elasticsearch_ingest_processor_user_agent.user_agent.json,
+    // the typed and the JSON processors can be mixed, the order is kept
+    jsonencode({
+      lowercase = {
+        field = "event.action"
+      }
+    }),
   ]
 }
@@ -0,0 +1,22 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_snapshot_repository" "my_repository" {
+  name = "my_repository"
+
+  fs {
+    location = "/tmp/snapshots"
+  }
+}
+
+resource "elasticstack_elasticsearch_snapshot" "before_upgrade" {
+  repository           = elasticstack_elasticsearch_snapshot_repository.my_repository.name
+  snapshot             = "before-upgrade"
+  indices              = ["logs-*", "my-index"]
+  include_global_state = false
+}
+
+output "snapshot_state" {
+  value = elasticstack_elasticsearch_snapshot.before_upgrade.state
+}
@@ -0,0 +1,17 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_tasks" "reindex" {
+  actions = ["indices:data/write/reindex"]
+}
+
+// wait for the reindex operations started outside of Terraform
+resource "elasticstack_elasticsearch_task_wait" "reindex" {
+  for_each = toset([for t in data.elasticstack_elasticsearch_tasks.reindex.tasks : t.task_id])
+  task_id  = each.value
+
+  timeouts {
+    create = "2h"
+  }
+}
@@ -0,0 +1,21 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_update_by_query" "rename_status" {
+  index = "orders"
+  query = jsonencode({
+    exists = { field = "state" }
+  })
+
+  script {
+    source = "ctx._source.status = ctx._source.remove('state')"
+  }
+
+  conflicts = "proceed"
+  slices    = "auto"
+}
+
+output "migrated_orders" {
+  value = elasticstack_elasticsearch_update_by_query.rename_status.updated
+}
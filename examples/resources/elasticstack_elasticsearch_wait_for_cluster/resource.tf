@@ -0,0 +1,26 @@
+provider "elasticstack" {
+  elasticsearch {}
+  // the cluster may still be starting when Terraform runs
+  verify_connection = false
+}
+
+resource "elasticstack_elasticsearch_wait_for_cluster" "ready" {
+  wait_for_status = "green"
+  wait_for_nodes  = ">=3"
+
+  timeouts {
+    create = "20m"
+  }
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "my_ilm" {
+  name = "my_ilm_policy"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  depends_on = [elasticstack_elasticsearch_wait_for_cluster.ready]
+}
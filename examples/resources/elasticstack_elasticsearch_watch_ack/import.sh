@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_watch_ack.my_watch_ack <cluster_uuid>/<watch_id>
@@ -0,0 +1,8 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watch_ack" "my_watch_ack" {
+  watch_id   = "my_watch"
+  action_ids = ["email_admin"]
+}
@@ -3,20 +3,29 @@ package clients
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/pkcs12"
 )
 
 type CompositeId struct {
@@ -58,12 +67,83 @@ type ApiClient struct {
 	es                       *elasticsearch.Client
 	elasticsearchClusterInfo *models.ClusterInfo
 	version                  string
+	// connectionSettings holds the resolved connection configuration the client has been created with.
+	connectionSettings map[string]interface{}
+	// resourceNamePrefix is prepended to the names of the objects created by the resources.
+	resourceNamePrefix string
+	// pipelineReferencesValidation is the mode of the plan time check of the pipelines referenced by the templates.
+	pipelineReferencesValidation string
+	// ignoreVersionCheck disables the detection of the server version and the checks of the version-dependent features.
+	ignoreVersionCheck bool
+	// variant is the distribution of the cluster, `elasticsearch` or `opensearch`.
+	variant string
+	// reconcileOnConflict retries the create and update of the objects conflicting with a concurrent change.
+	reconcileOnConflict bool
+	// plannedPipelines holds the names of the ingest pipelines planned by the resources of the current run.
+	plannedPipelines *sync.Map
 }
 
 func NewApiClientFunc(version string) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		return newEsApiClient(d, "elasticsearch", version, true)
+		client, diags := newEsApiClient(d, "elasticsearch", version, nil)
+		if diags.HasError() {
+			return nil, diags
+		}
+		client.resourceNamePrefix, _ = d.Get("resource_name_prefix").(string)
+		client.pipelineReferencesValidation, _ = d.Get("validate_pipeline_references").(string)
+		client.ignoreVersionCheck, _ = d.Get("ignore_version_check").(bool)
+		client.reconcileOnConflict, _ = d.Get("reconcile_on_conflict").(bool)
+		if variant, _ := d.Get("variant").(string); variant != "" {
+			client.setVariant(variant)
+		}
+		if d.Get("verify_connection").(bool) {
+			if diags := client.verifyConnection(ctx); diags.HasError() {
+				return nil, diags
+			}
+		}
+		return client, diags
+	}
+}
+
+// verifyConnection pings the cluster, reporting whether the connection failed on the network, TLS or authentication level.
+func (a *ApiClient) verifyConnection(ctx context.Context) diag.Diagnostics {
+	res, err := a.es.Info(a.es.Info.WithContext(ctx))
+	if err != nil {
+		var unknownAuthorityErr x509.UnknownAuthorityError
+		var hostnameErr x509.HostnameError
+		var certificateInvalidErr x509.CertificateInvalidError
+		var recordHeaderErr tls.RecordHeaderError
+		if errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &certificateInvalidErr) || errors.As(err, &recordHeaderErr) || strings.Contains(err.Error(), "tls: ") {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Unable to establish a TLS connection to Elasticsearch",
+				Detail:   fmt.Sprintf("%s. Check the endpoints scheme, `ca_file`/`ca_data`, `tls_server_name` or `insecure` of the connection. Set `verify_connection = false` to skip this check.", err),
+			}}
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to connect to Elasticsearch",
+			Detail:   fmt.Sprintf("%s. Check the endpoints of the connection and that Elasticsearch is reachable. Set `verify_connection = false` to skip this check.", err),
+		}}
 	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to authenticate to Elasticsearch",
+			Detail:   fmt.Sprintf("Elasticsearch rejected the credentials with %s. Check the `username`/`password` or `api_key` of the connection. Set `verify_connection = false` to skip this check.", res.Status()),
+		}}
+	}
+	if diags := utils.CheckError(res, "Unable to connect to the Elasticsearch cluster"); diags.HasError() {
+		return diags
+	}
+
+	info := models.ClusterInfo{}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return diag.FromErr(err)
+	}
+	a.elasticsearchClusterInfo = &info
+	return nil
 }
 
 func NewAcceptanceTestingClient() (*ApiClient, error) {
@@ -90,7 +170,7 @@ func NewAcceptanceTestingClient() (*ApiClient, error) {
 		return nil, err
 	}
 
-	return &ApiClient{es, nil, "acceptance-testing"}, nil
+	return &ApiClient{es, nil, "acceptance-testing", nil, "", "", false, "", false, &sync.Map{}}, nil
 }
 
 const esConnectionKey string = "elasticsearch_connection"
@@ -98,16 +178,48 @@ const esConnectionKey string = "elasticsearch_connection"
 func NewApiClient(d *schema.ResourceData, meta interface{}) (*ApiClient, diag.Diagnostics) {
 	defaultClient := meta.(*ApiClient)
 
+	client := defaultClient
+	var diags diag.Diagnostics
 	if _, ok := d.GetOk(esConnectionKey); ok {
-		return newEsApiClient(d, esConnectionKey, defaultClient.version, false)
+		client, diags = newEsApiClient(d, esConnectionKey, defaultClient.version, defaultClient)
+		if diags.HasError() {
+			return client, diags
+		}
 	}
 
-	return defaultClient, nil
+	return client.withResourceTimeouts(d), diags
+}
+
+// withResourceTimeouts returns a copy of the client using the `master_timeout` and `timeout` set on the resource
+// instead of the ones of the connection, the client itself if none is set. The configuration is not known on read and
+// delete, in which case the values of the state are used.
+func (a *ApiClient) withResourceTimeouts(d *schema.ResourceData) *ApiClient {
+	var settings map[string]interface{}
+	for _, key := range []string{"master_timeout", "timeout"} {
+		v, _ := d.Get(key).(string)
+		if v == "" {
+			continue
+		}
+		if settings == nil {
+			settings = make(map[string]interface{}, len(a.connectionSettings)+2)
+			for k, s := range a.connectionSettings {
+				settings[k] = s
+			}
+		}
+		settings[key] = v
+	}
+	if settings == nil {
+		return a
+	}
+	client := *a
+	client.connectionSettings = settings
+	return &client
 }
 
 func ensureTLSClientConfig(config *elasticsearch.Config) *tls.Config {
 	if config.Transport == nil {
-		config.Transport = http.DefaultTransport.(*http.Transport)
+		// clone the default transport, so TLS options do not leak between the clients
+		config.Transport = http.DefaultTransport.(*http.Transport).Clone()
 	}
 	if config.Transport.(*http.Transport).TLSClientConfig == nil {
 		config.Transport.(*http.Transport).TLSClientConfig = &tls.Config{}
@@ -115,10 +227,162 @@ func ensureTLSClientConfig(config *elasticsearch.Config) *tls.Config {
 	return config.Transport.(*http.Transport).TLSClientConfig
 }
 
+// configureTLSVersions restricts the TLS versions and cipher suites of the connection, the Go defaults apply to the ones not configured.
+func configureTLSVersions(config *elasticsearch.Config, esConfig map[string]interface{}) diag.Diagnostics {
+	var minVersion, maxVersion uint16
+	for key, version := range map[string]*uint16{"tls_min_version": &minVersion, "tls_max_version": &maxVersion} {
+		v, ok := esConfig[key].(string)
+		if !ok || v == "" {
+			continue
+		}
+		tlsVersion, ok := providerSchema.TLSVersions[v]
+		if !ok {
+			return diag.Errorf(`Unknown TLS version "%s" in "%s", expected one of %s`, v, key, strings.Join(providerSchema.TLSVersionNames(), ", "))
+		}
+		*version = tlsVersion
+	}
+	if minVersion != 0 && maxVersion != 0 && minVersion > maxVersion {
+		return diag.Errorf(`"tls_min_version" must not be greater than "tls_max_version"`)
+	}
+
+	var cipherSuites []uint16
+	if v, ok := esConfig["cipher_suites"].([]interface{}); ok {
+		suites := make(map[string]uint16)
+		for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+			suites[suite.Name] = suite.ID
+		}
+		for _, name := range v {
+			id, ok := suites[name.(string)]
+			if !ok {
+				return diag.Errorf(`Unknown cipher suite "%s" in "cipher_suites"`, name)
+			}
+			cipherSuites = append(cipherSuites, id)
+		}
+	}
+
+	if minVersion == 0 && maxVersion == 0 && len(cipherSuites) == 0 {
+		return nil
+	}
+	tlsClientConfig := ensureTLSClientConfig(config)
+	tlsClientConfig.MinVersion = minVersion
+	tlsClientConfig.MaxVersion = maxVersion
+	tlsClientConfig.CipherSuites = cipherSuites
+	return nil
+}
+
 func (a *ApiClient) GetESClient() *elasticsearch.Client {
 	return a.es
 }
 
+// ResourceName returns the name of the object in the cluster, i.e. the configured name prefixed with the `resource_name_prefix` of the provider.
+func (a *ApiClient) ResourceName(name string) string {
+	return a.resourceNamePrefix + name
+}
+
+// ConfiguredName returns the configured name of the object in the cluster, i.e. without the `resource_name_prefix` of the provider.
+// The names without the prefix, e.g. of the objects created with another prefix, are kept as they are.
+func (a *ApiClient) ConfiguredName(name string) string {
+	return strings.TrimPrefix(name, a.resourceNamePrefix)
+}
+
+// TargetName returns the name in the cluster of the object managed by the resource. Once created it's the name of the
+// ID, so that changing the `resource_name_prefix` of the provider does not retarget the existing object.
+func (a *ApiClient) TargetName(d *schema.ResourceData) (string, diag.Diagnostics) {
+	if d.Id() == "" {
+		return a.ResourceName(d.Get("name").(string)), nil
+	}
+	return ResourceIDFromStr(d.Id())
+}
+
+// CheckResourceNamePrefix returns an error when the name of the imported object does not start with the
+// `resource_name_prefix` of the provider, as the object would be managed under another name.
+func (a *ApiClient) CheckResourceNamePrefix(name string) diag.Diagnostics {
+	if strings.HasPrefix(name, a.resourceNamePrefix) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf(`"%s" can not be imported`, name),
+		Detail:   fmt.Sprintf(`The name does not start with the resource_name_prefix "%s" of the provider, only the objects named with the prefix can be managed.`, a.resourceNamePrefix),
+	}}
+}
+
+// ImportStatePrefixedPassthroughContext imports the object by its ID, the same as schema.ImportStatePassthroughContext,
+// rejecting the objects whose name does not start with the `resource_name_prefix` of the provider.
+func ImportStatePrefixedPassthroughContext(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, utils.DiagsAsError(diags)
+	}
+	name, diags := ResourceIDFromStr(d.Id())
+	if diags.HasError() {
+		return nil, utils.DiagsAsError(diags)
+	}
+	if diags := client.CheckResourceNamePrefix(name); diags.HasError() {
+		return nil, utils.DiagsAsError(diags)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// PipelineReferencesValidation returns how the referenced ingest pipelines are checked: `off` by default when they
+// are not checked, `warn` or `error`.
+func (a *ApiClient) PipelineReferencesValidation() string {
+	if a.pipelineReferencesValidation == "" {
+		return "off"
+	}
+	return a.pipelineReferencesValidation
+}
+
+// AddPlannedPipeline records the ingest pipeline planned by a resource, the resources depending on the pipeline resource
+// being planned afterwards.
+func (a *ApiClient) AddPlannedPipeline(name string) {
+	if a.plannedPipelines != nil {
+		a.plannedPipelines.Store(name, true)
+	}
+}
+
+// IsPlannedPipeline reports whether the ingest pipeline is planned by a resource of the current run, i.e. it may not
+// exist yet when the plan is made.
+func (a *ApiClient) IsPlannedPipeline(name string) bool {
+	if a.plannedPipelines == nil {
+		return false
+	}
+	_, ok := a.plannedPipelines.Load(name)
+	return ok
+}
+
+// ReconcileOnConflict reports whether the objects conflicting with a concurrent change, e.g. of another run against the
+// same cluster, are reconciled instead of failing.
+func (a *ApiClient) ReconcileOnConflict() bool {
+	return a.reconcileOnConflict
+}
+
+// MasterTimeout returns the configured period to wait for the master node, zero if the Elasticsearch default applies.
+func (a *ApiClient) MasterTimeout() time.Duration {
+	return a.durationSetting("master_timeout")
+}
+
+// Timeout returns the configured period to wait for the response, zero if the Elasticsearch default applies.
+func (a *ApiClient) Timeout() time.Duration {
+	return a.durationSetting("timeout")
+}
+
+// MinimizeResponses reports whether the reads should fetch only the fields of the responses used by the provider.
+func (a *ApiClient) MinimizeResponses() bool {
+	minimize, ok := a.connectionSettings["minimize_responses"].(bool)
+	return ok && minimize
+}
+
+func (a *ApiClient) durationSetting(key string) time.Duration {
+	v, ok := a.connectionSettings[key].(string)
+	if !ok {
+		return 0
+	}
+	// the value is validated by the schema
+	d, _ := time.ParseDuration(v)
+	return d
+}
+
 func (a *ApiClient) ID(ctx context.Context, resourceId string) (*CompositeId, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	clusterId, diags := a.ClusterID(ctx)
@@ -153,7 +417,12 @@ func (a *ApiClient) serverInfo(ctx context.Context) (*models.ClusterInfo, diag.D
 	return &info, diags
 }
 
+// ServerVersion returns the version of the cluster, nil when the version check is disabled with the `ignore_version_check`
+// of the provider or with the `opensearch` variant, in which case the version-dependent features are sent to the cluster as is.
 func (a *ApiClient) ServerVersion(ctx context.Context) (*version.Version, diag.Diagnostics) {
+	if a.ignoreVersionCheck || a.IsOpenSearch() {
+		return nil, nil
+	}
 	info, diags := a.serverInfo(ctx)
 	if diags.HasError() {
 		return nil, diags
@@ -188,110 +457,149 @@ func (a *ApiClient) ClusterID(ctx context.Context) (*string, diag.Diagnostics) {
 	return nil, diags
 }
 
-func newEsApiClient(d *schema.ResourceData, key string, version string, useEnvAsDefault bool) (*ApiClient, diag.Diagnostics) {
-	var diags diag.Diagnostics
-	config := elasticsearch.Config{}
-	config.Header = http.Header{"User-Agent": []string{fmt.Sprintf("elasticstack-terraform-provider/%s", version)}}
+// connectionSettingGroups lists the connection settings which are only meaningful together.
+// When a higher precedence source sets any member of a group, the whole group is taken from that source,
+// e.g. a resource level `api_key` replaces the `username` and `password` configured on the provider.
+var connectionSettingGroups = [][]string{
+	{"username", "password", "api_key", "oauth2"},
+	{"ca_file", "ca_data"},
+	{"cert_file", "key_file", "cert_data", "key_data", "keystore_path", "keystore_password"},
+}
 
-	if esConn, ok := d.GetOk(key); ok {
-		// if defined, then we only have a single entry
-		if es := esConn.([]interface{})[0]; es != nil {
-			esConfig := es.(map[string]interface{})
+// envConnectionSettings returns the connection settings defined with the ELASTICSEARCH_* environment variables.
+func envConnectionSettings() (map[string]interface{}, diag.Diagnostics) {
+	settings := make(map[string]interface{})
 
-			if username, ok := esConfig["username"]; ok {
-				config.Username = username.(string)
-			}
-			if password, ok := esConfig["password"]; ok {
-				config.Password = password.(string)
-			}
-			if apikey, ok := esConfig["api_key"]; ok {
-				config.APIKey = apikey.(string)
-			}
+	if endpoints := os.Getenv("ELASTICSEARCH_ENDPOINTS"); endpoints != "" {
+		var addrs []interface{}
+		for _, e := range strings.Split(endpoints, ",") {
+			addrs = append(addrs, strings.TrimSpace(e))
+		}
+		settings["endpoints"] = addrs
+	}
+	for key, env := range map[string]string{
+		"username": "ELASTICSEARCH_USERNAME",
+		"password": "ELASTICSEARCH_PASSWORD",
+		"api_key":  "ELASTICSEARCH_API_KEY",
+	} {
+		if v := os.Getenv(env); v != "" {
+			settings[key] = v
+		}
+	}
+	if insecure := os.Getenv("ELASTICSEARCH_INSECURE"); insecure != "" {
+		v, err := strconv.ParseBool(insecure)
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Invalid value of ELASTICSEARCH_INSECURE environment variable",
+				Detail:   err.Error(),
+			}}
+		}
+		settings["insecure"] = v
+	}
 
-			if useEnvAsDefault {
-				if endpoints := os.Getenv("ELASTICSEARCH_ENDPOINTS"); endpoints != "" {
-					var addrs []string
-					for _, e := range strings.Split(endpoints, ",") {
-						addrs = append(addrs, strings.TrimSpace(e))
-					}
-					config.Addresses = addrs
-				}
-			}
+	return settings, nil
+}
 
-			if endpoints, ok := esConfig["endpoints"]; ok && len(endpoints.([]interface{})) > 0 {
-				var addrs []string
-				for _, e := range endpoints.([]interface{}) {
-					addrs = append(addrs, e.(string))
-				}
-				config.Addresses = addrs
-			}
+// blockConnectionSettings returns the connection settings explicitly configured in the connection block under the given key.
+// Empty values are treated as not set, to let the lower precedence sources provide them, as well as the disabled flags
+// unless they're explicitly set to false in the configuration, e.g. to override the `insecure` of the provider.
+func blockConnectionSettings(d *schema.ResourceData, key string) map[string]interface{} {
+	settings := make(map[string]interface{})
 
-			if insecure, ok := esConfig["insecure"]; ok && insecure.(bool) {
-				tlsClientConfig := ensureTLSClientConfig(&config)
-				tlsClientConfig.InsecureSkipVerify = true
+	esConn, ok := d.GetOk(key)
+	if !ok {
+		return settings
+	}
+	// if defined, then we only have a single entry
+	es := esConn.([]interface{})[0]
+	if es == nil {
+		return settings
+	}
+	block := configuredBlock(d, key)
+	for k, v := range es.(map[string]interface{}) {
+		if b, ok := v.(bool); ok && !b {
+			if !isSetToFalse(block, k) {
+				continue
 			}
+		} else if utils.IsEmpty(v) {
+			continue
+		}
+		settings[k] = v
+	}
+	return settings
+}
 
-			if caFile, ok := esConfig["ca_file"]; ok && caFile.(string) != "" {
-				caCert, err := os.ReadFile(caFile.(string))
-				if err != nil {
-					diags = append(diags, diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Unable to read CA File",
-						Detail:   err.Error(),
-					})
-					return nil, diags
-				}
-				config.CACert = caCert
-			}
-			if caData, ok := esConfig["ca_data"]; ok && caData.(string) != "" {
-				config.CACert = []byte(caData.(string))
-			}
+// configuredBlock returns the connection block under the given key as set in the configuration, or in the state on
+// refresh when the configuration is not known, a null value if it's not known either.
+func configuredBlock(d *schema.ResourceData, key string) cty.Value {
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		raw = d.GetRawState()
+	}
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(key) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	list := raw.GetAttr(key)
+	if list.IsNull() || !list.IsKnown() || list.LengthInt() == 0 {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return list.Index(cty.NumberIntVal(0))
+}
 
-			if certFile, ok := esConfig["cert_file"]; ok && certFile.(string) != "" {
-				if keyFile, ok := esConfig["key_file"]; ok && keyFile.(string) != "" {
-					cert, err := tls.LoadX509KeyPair(certFile.(string), keyFile.(string))
-					if err != nil {
-						diags = append(diags, diag.Diagnostic{
-							Severity: diag.Error,
-							Summary:  "Unable to read certificate or key file",
-							Detail:   err.Error(),
-						})
-						return nil, diags
-					}
-					tlsClientConfig := ensureTLSClientConfig(&config)
-					tlsClientConfig.Certificates = []tls.Certificate{cert}
-				} else {
-					diags = append(diags, diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Unable to read key file",
-						Detail:   "Path to key file has not been configured or is empty",
-					})
-					return nil, diags
+// isSetToFalse reports whether the flag of the connection block is explicitly set to false.
+func isSetToFalse(block cty.Value, name string) bool {
+	if block.IsNull() || !block.IsKnown() || !block.Type().IsObjectType() || !block.Type().HasAttribute(name) {
+		return false
+	}
+	v := block.GetAttr(name)
+	return v.IsKnown() && !v.IsNull() && v.Type() == cty.Bool && v.False()
+}
+
+// mergeConnectionSettings merges the given connection settings, each of them taking precedence over the previous ones.
+func mergeConnectionSettings(layers ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, layer := range layers {
+		for _, group := range connectionSettingGroups {
+			overridden := false
+			for _, k := range group {
+				if _, ok := layer[k]; ok {
+					overridden = true
 				}
 			}
-			if certData, ok := esConfig["cert_data"]; ok && certData.(string) != "" {
-				if keyData, ok := esConfig["key_data"]; ok && keyData.(string) != "" {
-					cert, err := tls.X509KeyPair([]byte(certData.(string)), []byte(keyData.(string)))
-					if err != nil {
-						diags = append(diags, diag.Diagnostic{
-							Severity: diag.Error,
-							Summary:  "Unable to parse certificate or key",
-							Detail:   err.Error(),
-						})
-						return nil, diags
-					}
-					tlsClientConfig := ensureTLSClientConfig(&config)
-					tlsClientConfig.Certificates = []tls.Certificate{cert}
-				} else {
-					diags = append(diags, diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Unable to parse key",
-						Detail:   "Key data has not been configured or is empty",
-					})
-					return nil, diags
+			if overridden {
+				for _, k := range group {
+					delete(merged, k)
 				}
 			}
 		}
+		for k, v := range layer {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// newEsApiClient creates the client for the given connection block.
+//
+// The connection settings are resolved in the following order of precedence:
+// the resource level `elasticsearch_connection` block, the provider `elasticsearch` block,
+// the ELASTICSEARCH_* environment variables, and finally the built-in defaults.
+func newEsApiClient(d *schema.ResourceData, key string, version string, defaultClient *ApiClient) (*ApiClient, diag.Diagnostics) {
+	var settings map[string]interface{}
+	if defaultClient != nil {
+		settings = mergeConnectionSettings(defaultClient.connectionSettings, blockConnectionSettings(d, key))
+	} else {
+		envSettings, diags := envConnectionSettings()
+		if diags.HasError() {
+			return nil, diags
+		}
+		settings = mergeConnectionSettings(envSettings, blockConnectionSettings(d, key))
+	}
+
+	config, diags := buildEsConfig(settings, version)
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	es, err := elasticsearch.NewClient(config)
@@ -303,9 +611,165 @@ func newEsApiClient(d *schema.ResourceData, key string, version string, useEnvAs
 		})
 		return nil, diags
 	}
+	if source := connectionTokenSource(settings, config.Transport); source != nil {
+		es.Transport = newOAuth2Transport(es.Transport, source)
+	}
+	if limiter := connectionLimiter(settings); limiter != nil {
+		es.Transport = newLimitedTransport(es.Transport, limiter)
+	}
 	if logging.IsDebugOrHigher() {
 		es.Transport = newDebugTransport("elasticsearch", es.Transport)
 	}
 
-	return &ApiClient{es, nil, version}, diags
+	client := &ApiClient{es, nil, version, settings, "", "", false, "", false, &sync.Map{}}
+	if defaultClient != nil {
+		client.resourceNamePrefix = defaultClient.resourceNamePrefix
+		client.pipelineReferencesValidation = defaultClient.pipelineReferencesValidation
+		client.ignoreVersionCheck = defaultClient.ignoreVersionCheck
+		client.setVariant(defaultClient.variant)
+		client.reconcileOnConflict = defaultClient.reconcileOnConflict
+		client.plannedPipelines = defaultClient.plannedPipelines
+	}
+	return client, diags
+}
+
+// loadKeystore reads the client certificate, its chain and the private key from the PKCS#12 keystore.
+func loadKeystore(path string, password string) (tls.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("%s: %w", path, err)
+	}
+	var certPEM, keyPEM []byte
+	for _, block := range blocks {
+		if block.Type == "PRIVATE KEY" {
+			keyPEM = append(keyPEM, pem.EncodeToMemory(block)...)
+		} else {
+			certPEM = append(certPEM, pem.EncodeToMemory(block)...)
+		}
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+func buildEsConfig(esConfig map[string]interface{}, version string) (elasticsearch.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := elasticsearch.Config{}
+	config.Header = http.Header{"User-Agent": []string{fmt.Sprintf("elasticstack-terraform-provider/%s", version)}}
+
+	if username, ok := esConfig["username"]; ok {
+		config.Username = username.(string)
+	}
+	if password, ok := esConfig["password"]; ok {
+		config.Password = password.(string)
+	}
+	if apikey, ok := esConfig["api_key"]; ok {
+		config.APIKey = apikey.(string)
+	}
+
+	if endpoints, ok := esConfig["endpoints"]; ok && len(endpoints.([]interface{})) > 0 {
+		var addrs []string
+		for _, e := range endpoints.([]interface{}) {
+			addrs = append(addrs, e.(string))
+		}
+		config.Addresses = addrs
+	}
+
+	if compression, ok := esConfig["compression"]; ok && compression.(bool) {
+		config.CompressRequestBody = true
+	}
+
+	if insecure, ok := esConfig["insecure"]; ok && insecure.(bool) {
+		tlsClientConfig := ensureTLSClientConfig(&config)
+		tlsClientConfig.InsecureSkipVerify = true
+	}
+
+	if diags := configureTLSVersions(&config, esConfig); diags.HasError() {
+		return config, diags
+	}
+
+	if serverName, ok := esConfig["tls_server_name"]; ok && serverName.(string) != "" {
+		tlsClientConfig := ensureTLSClientConfig(&config)
+		tlsClientConfig.ServerName = serverName.(string)
+	}
+
+	if caFile, ok := esConfig["ca_file"]; ok && caFile.(string) != "" {
+		caCert, err := os.ReadFile(caFile.(string))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read CA File",
+				Detail:   err.Error(),
+			})
+			return config, diags
+		}
+		config.CACert = caCert
+	}
+	if caData, ok := esConfig["ca_data"]; ok && caData.(string) != "" {
+		config.CACert = []byte(caData.(string))
+	}
+
+	if certFile, ok := esConfig["cert_file"]; ok && certFile.(string) != "" {
+		if keyFile, ok := esConfig["key_file"]; ok && keyFile.(string) != "" {
+			cert, err := tls.LoadX509KeyPair(certFile.(string), keyFile.(string))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to read certificate or key file",
+					Detail:   err.Error(),
+				})
+				return config, diags
+			}
+			tlsClientConfig := ensureTLSClientConfig(&config)
+			tlsClientConfig.Certificates = []tls.Certificate{cert}
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read key file",
+				Detail:   "Path to key file has not been configured or is empty",
+			})
+			return config, diags
+		}
+	}
+	if certData, ok := esConfig["cert_data"]; ok && certData.(string) != "" {
+		if keyData, ok := esConfig["key_data"]; ok && keyData.(string) != "" {
+			cert, err := tls.X509KeyPair([]byte(certData.(string)), []byte(keyData.(string)))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to parse certificate or key",
+					Detail:   err.Error(),
+				})
+				return config, diags
+			}
+			tlsClientConfig := ensureTLSClientConfig(&config)
+			tlsClientConfig.Certificates = []tls.Certificate{cert}
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to parse key",
+				Detail:   "Key data has not been configured or is empty",
+			})
+			return config, diags
+		}
+	}
+
+	if keystorePath, ok := esConfig["keystore_path"]; ok && keystorePath.(string) != "" {
+		password, _ := esConfig["keystore_password"].(string)
+		cert, err := loadKeystore(keystorePath.(string), password)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read the PKCS#12 keystore",
+				Detail:   err.Error(),
+			})
+			return config, diags
+		}
+		tlsClientConfig := ensureTLSClientConfig(&config)
+		tlsClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, diags
 }
@@ -0,0 +1,619 @@
+package clients
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConnectionSettingsPrecedence(t *testing.T) {
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch": providerSchema.GetConnectionSchema("elasticsearch", true),
+	}
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+
+	tests := []struct {
+		name             string
+		env              map[string]string
+		providerConfig   map[string]interface{}
+		resourceConfig   map[string]interface{}
+		expectedAddrs    []string
+		expectedUsername string
+		expectedPassword string
+		expectedApiKey   string
+		expectedInsecure bool
+	}{
+		{
+			name: "built-in defaults are used when nothing is configured",
+		},
+		{
+			name: "env vars are used without any connection block",
+			env: map[string]string{
+				"ELASTICSEARCH_ENDPOINTS": "http://env-1:9200, http://env-2:9200",
+				"ELASTICSEARCH_USERNAME":  "env-user",
+				"ELASTICSEARCH_PASSWORD":  "env-pass",
+				"ELASTICSEARCH_INSECURE":  "true",
+			},
+			expectedAddrs:    []string{"http://env-1:9200", "http://env-2:9200"},
+			expectedUsername: "env-user",
+			expectedPassword: "env-pass",
+			expectedInsecure: true,
+		},
+		{
+			name: "provider block takes precedence over env vars",
+			env: map[string]string{
+				"ELASTICSEARCH_ENDPOINTS": "http://env:9200",
+				"ELASTICSEARCH_USERNAME":  "env-user",
+				"ELASTICSEARCH_PASSWORD":  "env-pass",
+			},
+			providerConfig: map[string]interface{}{
+				"endpoints": []interface{}{"http://provider:9200"},
+				"username":  "provider-user",
+				"password":  "provider-pass",
+			},
+			expectedAddrs:    []string{"http://provider:9200"},
+			expectedUsername: "provider-user",
+			expectedPassword: "provider-pass",
+		},
+		{
+			name: "env vars fill the settings missing in the provider block",
+			env: map[string]string{
+				"ELASTICSEARCH_ENDPOINTS": "http://env:9200",
+				"ELASTICSEARCH_API_KEY":   "env-api-key",
+			},
+			providerConfig: map[string]interface{}{
+				"insecure": true,
+			},
+			expectedAddrs:    []string{"http://env:9200"},
+			expectedApiKey:   "env-api-key",
+			expectedInsecure: true,
+		},
+		{
+			name: "resource block takes precedence over provider block",
+			providerConfig: map[string]interface{}{
+				"endpoints": []interface{}{"http://provider:9200"},
+				"username":  "provider-user",
+				"password":  "provider-pass",
+			},
+			resourceConfig: map[string]interface{}{
+				"endpoints": []interface{}{"http://resource:9200"},
+				"username":  "resource-user",
+				"password":  "resource-pass",
+			},
+			expectedAddrs:    []string{"http://resource:9200"},
+			expectedUsername: "resource-user",
+			expectedPassword: "resource-pass",
+		},
+		{
+			name: "resource block inherits provider and env settings it does not define",
+			env: map[string]string{
+				"ELASTICSEARCH_ENDPOINTS": "http://env:9200",
+			},
+			providerConfig: map[string]interface{}{
+				"username": "provider-user",
+				"password": "provider-pass",
+			},
+			resourceConfig: map[string]interface{}{
+				"insecure": true,
+			},
+			expectedAddrs:    []string{"http://env:9200"},
+			expectedUsername: "provider-user",
+			expectedPassword: "provider-pass",
+			expectedInsecure: true,
+		},
+		{
+			name: "resource api key replaces provider basic auth",
+			providerConfig: map[string]interface{}{
+				"endpoints": []interface{}{"http://provider:9200"},
+				"username":  "provider-user",
+				"password":  "provider-pass",
+			},
+			resourceConfig: map[string]interface{}{
+				"api_key": "resource-api-key",
+			},
+			expectedAddrs:  []string{"http://provider:9200"},
+			expectedApiKey: "resource-api-key",
+		},
+		{
+			name: "resource basic auth replaces env api key",
+			env: map[string]string{
+				"ELASTICSEARCH_API_KEY": "env-api-key",
+			},
+			resourceConfig: map[string]interface{}{
+				"username": "resource-user",
+				"password": "resource-pass",
+			},
+			expectedUsername: "resource-user",
+			expectedPassword: "resource-pass",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, env := range []string{"ELASTICSEARCH_ENDPOINTS", "ELASTICSEARCH_USERNAME", "ELASTICSEARCH_PASSWORD", "ELASTICSEARCH_API_KEY", "ELASTICSEARCH_INSECURE"} {
+				t.Setenv(env, tc.env[env])
+			}
+
+			providerRaw := map[string]interface{}{}
+			if tc.providerConfig != nil {
+				providerRaw["elasticsearch"] = []interface{}{tc.providerConfig}
+			}
+			client, diags := newEsApiClient(schema.TestResourceDataRaw(t, providerSchemaMap, providerRaw), "elasticsearch", "test", nil)
+			if diags.HasError() {
+				t.Fatalf("unexpected error creating provider client: %v", diags)
+			}
+
+			settings := client.connectionSettings
+			if tc.resourceConfig != nil {
+				resourceRaw := map[string]interface{}{
+					esConnectionKey: []interface{}{tc.resourceConfig},
+				}
+				resourceClient, diags := NewApiClient(schema.TestResourceDataRaw(t, resourceSchemaMap, resourceRaw), client)
+				if diags.HasError() {
+					t.Fatalf("unexpected error creating resource client: %v", diags)
+				}
+				settings = resourceClient.connectionSettings
+			}
+
+			config, diags := buildEsConfig(settings, "test")
+			if diags.HasError() {
+				t.Fatalf("unexpected error building config: %v", diags)
+			}
+
+			if len(config.Addresses) != len(tc.expectedAddrs) {
+				t.Fatalf("expected addresses %v, got %v", tc.expectedAddrs, config.Addresses)
+			}
+			for i := range tc.expectedAddrs {
+				if config.Addresses[i] != tc.expectedAddrs[i] {
+					t.Errorf("expected addresses %v, got %v", tc.expectedAddrs, config.Addresses)
+				}
+			}
+			if config.Username != tc.expectedUsername {
+				t.Errorf("expected username %q, got %q", tc.expectedUsername, config.Username)
+			}
+			if config.Password != tc.expectedPassword {
+				t.Errorf("expected password %q, got %q", tc.expectedPassword, config.Password)
+			}
+			if config.APIKey != tc.expectedApiKey {
+				t.Errorf("expected api key %q, got %q", tc.expectedApiKey, config.APIKey)
+			}
+			insecure := config.Transport != nil && ensureTLSClientConfig(&config).InsecureSkipVerify
+			if insecure != tc.expectedInsecure {
+				t.Errorf("expected insecure %t, got %t", tc.expectedInsecure, insecure)
+			}
+		})
+	}
+}
+
+func TestResourceConnectionDisabledFlags(t *testing.T) {
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch": providerSchema.GetConnectionSchema("elasticsearch", true),
+	}
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	client, diags := newEsApiClient(schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{
+		"elasticsearch": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{"http://provider:9200"},
+			"insecure":  true,
+		}},
+	}), "elasticsearch", "test", nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating provider client: %v", diags)
+	}
+
+	for _, tc := range []struct {
+		name             string
+		rawConfig        string
+		expectedInsecure bool
+	}{
+		{
+			name:             "the unset flag keeps the provider setting",
+			rawConfig:        `{"elasticsearch_connection": [{"endpoints": ["http://resource:9200"]}]}`,
+			expectedInsecure: true,
+		},
+		{
+			name:      "the flag set to false overrides the provider setting",
+			rawConfig: `{"elasticsearch_connection": [{"endpoints": ["http://resource:9200"], "insecure": false}]}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource := &schema.Resource{Schema: resourceSchemaMap}
+			rawConfig, err := ctyjson.Unmarshal([]byte(tc.rawConfig), resource.CoreConfigSchema().ImpliedType())
+			if err != nil {
+				t.Fatalf("unexpected error building the raw config: %v", err)
+			}
+			d := resource.Data(&terraform.InstanceState{
+				ID: "test",
+				Attributes: map[string]string{
+					"elasticsearch_connection.#":             "1",
+					"elasticsearch_connection.0.endpoints.#": "1",
+					"elasticsearch_connection.0.endpoints.0": "http://resource:9200",
+					"elasticsearch_connection.0.insecure":    "false",
+				},
+				RawConfig: rawConfig,
+			})
+
+			resourceClient, diags := NewApiClient(d, client)
+			if diags.HasError() {
+				t.Fatalf("unexpected error creating resource client: %v", diags)
+			}
+			config, diags := buildEsConfig(resourceClient.connectionSettings, "test")
+			if diags.HasError() {
+				t.Fatalf("unexpected error building config: %v", diags)
+			}
+			insecure := config.Transport != nil && ensureTLSClientConfig(&config).InsecureSkipVerify
+			if insecure != tc.expectedInsecure {
+				t.Errorf("expected insecure %t, got %t", tc.expectedInsecure, insecure)
+			}
+		})
+	}
+}
+
+func TestCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+			return
+		}
+
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("expected gzip compressed request body, got Content-Encoding %q", r.Header.Get("Content-Encoding"))
+		}
+		body, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("unable to decompress request body: %v", err)
+			return
+		}
+		reqBody, err := io.ReadAll(body)
+		if err != nil {
+			t.Errorf("unable to read request body: %v", err)
+			return
+		}
+		if string(reqBody) != `{"persistent":{}}` {
+			t.Errorf("unexpected request body: %s", reqBody)
+		}
+
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("expected gzip to be accepted, got Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		fmt.Fprint(gz, `{"acknowledged": true}`)
+	}))
+	defer server.Close()
+
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+		esConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints":   []interface{}{server.URL},
+			"compression": true,
+		}},
+	})
+	client, diags := NewApiClient(d, &ApiClient{version: "test"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	es := client.GetESClient()
+	res, err := es.Cluster.PutSettings(strings.NewReader(`{"persistent":{}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	var resBody struct {
+		Acknowledged bool `json:"acknowledged"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resBody); err != nil {
+		t.Fatalf("unable to decode the response: %v", err)
+	}
+	if !resBody.Acknowledged {
+		t.Errorf("expected the gzip compressed response to be decoded")
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch": providerSchema.GetConnectionSchema("elasticsearch", true),
+	}
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+
+	defaultClient, diags := newEsApiClient(schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{}), "elasticsearch", "test", nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating provider client: %v", diags)
+	}
+	if defaultClient.MasterTimeout() != 0 || defaultClient.Timeout() != 0 {
+		t.Errorf("expected the Elasticsearch defaults to apply, got master timeout %s and timeout %s", defaultClient.MasterTimeout(), defaultClient.Timeout())
+	}
+
+	providerClient, diags := newEsApiClient(schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{
+		"elasticsearch": []interface{}{map[string]interface{}{
+			"master_timeout": "1m",
+			"timeout":        "90s",
+		}},
+	}), "elasticsearch", "test", nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating provider client: %v", diags)
+	}
+	if providerClient.MasterTimeout() != time.Minute || providerClient.Timeout() != 90*time.Second {
+		t.Errorf("expected the provider timeouts, got master timeout %s and timeout %s", providerClient.MasterTimeout(), providerClient.Timeout())
+	}
+
+	resourceClient, diags := NewApiClient(schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+		esConnectionKey: []interface{}{map[string]interface{}{
+			"master_timeout": "5m",
+		}},
+	}), providerClient)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating resource client: %v", diags)
+	}
+	if resourceClient.MasterTimeout() != 5*time.Minute || resourceClient.Timeout() != 90*time.Second {
+		t.Errorf("expected the resource to override the master timeout only, got master timeout %s and timeout %s", resourceClient.MasterTimeout(), resourceClient.Timeout())
+	}
+}
+
+func TestTLSVersions(t *testing.T) {
+	config, diags := buildEsConfig(map[string]interface{}{}, "test")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if config.Transport != nil {
+		t.Errorf("expected the default transport to be used when TLS is not configured")
+	}
+
+	config, diags = buildEsConfig(map[string]interface{}{
+		"tls_min_version": "1.2",
+		"tls_max_version": "1.3",
+		"cipher_suites":   []interface{}{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+	}, "test")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	tlsConfig := config.Transport.(*http.Transport).TLSClientConfig
+	if tlsConfig.MinVersion != tls.VersionTLS12 || tlsConfig.MaxVersion != tls.VersionTLS13 {
+		t.Errorf("unexpected TLS versions: min %x, max %x", tlsConfig.MinVersion, tlsConfig.MaxVersion)
+	}
+	if len(tlsConfig.CipherSuites) != 2 || tlsConfig.CipherSuites[0] != tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 || tlsConfig.CipherSuites[1] != tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384 {
+		t.Errorf("unexpected cipher suites: %v", tlsConfig.CipherSuites)
+	}
+
+	for _, esConfig := range []map[string]interface{}{
+		{"tls_min_version": "1.4"},
+		{"tls_min_version": "1.3", "tls_max_version": "1.2"},
+		{"cipher_suites": []interface{}{"TLS_UNKNOWN"}},
+	} {
+		if _, diags := buildEsConfig(esConfig, "test"); !diags.HasError() {
+			t.Errorf("expected an error for %v", esConfig)
+		}
+	}
+}
+
+func TestKeystore(t *testing.T) {
+	config, diags := buildEsConfig(map[string]interface{}{
+		"keystore_path":     "testdata/client.p12",
+		"keystore_password": "changeme",
+	}, "test")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	certificates := config.Transport.(*http.Transport).TLSClientConfig.Certificates
+	if len(certificates) != 1 || certificates[0].PrivateKey == nil {
+		t.Fatalf("expected the client certificate and key to be loaded from the keystore, got %v", certificates)
+	}
+	cert, err := x509.ParseCertificate(certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatalf("unexpected error parsing the certificate: %v", err)
+	}
+	if cert.Subject.CommonName != "client" {
+		t.Errorf("unexpected certificate subject: %s", cert.Subject)
+	}
+
+	for _, esConfig := range []map[string]interface{}{
+		{"keystore_path": "testdata/client.p12", "keystore_password": "wrong"},
+		{"keystore_path": "testdata/missing.p12"},
+	} {
+		if _, diags := buildEsConfig(esConfig, "test"); !diags.HasError() {
+			t.Errorf("expected an error for %v", esConfig)
+		}
+	}
+}
+
+func TestTLSServerName(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+	}))
+	defer server.Close()
+	caData := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	// the certificate of the test server is issued for example.com and the loopback addresses only
+	endpoint := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	for serverName, expectError := range map[string]bool{
+		"":            true,
+		"example.com": false,
+	} {
+		d := schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+			esConnectionKey: []interface{}{map[string]interface{}{
+				"endpoints":       []interface{}{endpoint},
+				"ca_data":         caData,
+				"tls_server_name": serverName,
+			}},
+		})
+		client, diags := NewApiClient(d, &ApiClient{version: "test"})
+		if diags.HasError() {
+			t.Fatalf("unexpected error creating client: %v", diags)
+		}
+		res, err := client.GetESClient().Info()
+		if err == nil {
+			res.Body.Close()
+		}
+		if expectError && err == nil {
+			t.Errorf("expected the certificate verification to fail without the server name")
+		}
+		if !expectError && err != nil {
+			t.Errorf("unexpected error with the server name %q: %v", serverName, err)
+		}
+	}
+}
+
+func TestVerifyConnection(t *testing.T) {
+	unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"type": "security_exception", "reason": "unable to authenticate user"}, "status": 401}`)
+	}))
+	defer unauthorized.Close()
+	selfSigned := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer selfSigned.Close()
+	stopped := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	stopped.Close()
+
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch":     providerSchema.GetConnectionSchema("elasticsearch", true),
+		"verify_connection": {Type: schema.TypeBool, Optional: true, Default: true},
+	}
+	for _, tc := range []struct {
+		endpoint        string
+		expectedSummary string
+	}{
+		{unauthorized.URL, "Unable to authenticate to Elasticsearch"},
+		{selfSigned.URL, "Unable to establish a TLS connection to Elasticsearch"},
+		{stopped.URL, "Unable to connect to Elasticsearch"},
+	} {
+		d := schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{
+			"elasticsearch": []interface{}{map[string]interface{}{
+				"endpoints": []interface{}{tc.endpoint},
+			}},
+		})
+		_, diags := NewApiClientFunc("test")(context.Background(), d)
+		if !diags.HasError() || diags[0].Summary != tc.expectedSummary {
+			t.Errorf("expected %q for %s, got %v", tc.expectedSummary, tc.endpoint, diags)
+		}
+
+		if err := d.Set("verify_connection", false); err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := NewApiClientFunc("test")(context.Background(), d); diags.HasError() {
+			t.Errorf("expected the connection not to be verified, got %v", diags)
+		}
+	}
+}
+
+func TestResourceNamePrefix(t *testing.T) {
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+		esConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{"http://localhost:9200"},
+		}},
+	})
+	// the resource level connection keeps the prefix of the provider
+	client, diags := NewApiClient(d, &ApiClient{version: "test", resourceNamePrefix: "team-a-"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	if name := client.ResourceName("logs"); name != "team-a-logs" {
+		t.Errorf("expected the prefixed name, got %s", name)
+	}
+	if name := client.ConfiguredName("team-a-logs"); name != "logs" {
+		t.Errorf("expected the name without the prefix, got %s", name)
+	}
+	if name := client.ConfiguredName("logs"); name != "logs" {
+		t.Errorf("expected the name without the prefix to be kept, got %s", name)
+	}
+	if name := (&ApiClient{}).ResourceName("logs"); name != "logs" {
+		t.Errorf("expected the name to be kept without a prefix, got %s", name)
+	}
+
+	if diags := client.CheckResourceNamePrefix("team-a-logs"); diags.HasError() {
+		t.Errorf("expected the prefixed name to be imported, got %v", diags)
+	}
+	if diags := client.CheckResourceNamePrefix("logs"); !diags.HasError() {
+		t.Error("expected the name without the prefix not to be imported")
+	}
+
+	// the existing object keeps the name of its ID
+	resourceSchemaMap["name"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	d = schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{"name": "logs"})
+	if name, _ := client.TargetName(d); name != "team-a-logs" {
+		t.Errorf("expected the prefixed name to be created, got %s", name)
+	}
+	d.SetId("cluster-uuid/team-b-logs")
+	if name, _ := client.TargetName(d); name != "team-b-logs" {
+		t.Errorf("expected the name of the ID, got %s", name)
+	}
+}
+
+func TestIgnoreVersionCheck(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"cluster_uuid": "test", "version": {"number": "7.17.7"}}`)
+	}))
+	defer server.Close()
+
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+		esConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}},
+	})
+	// the resource level connection keeps the option of the provider
+	client, diags := NewApiClient(d, &ApiClient{version: "test", ignoreVersionCheck: true})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	serverVersion, diags := client.ServerVersion(context.Background())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if serverVersion != nil || requests != 0 {
+		t.Errorf("expected the version not to be requested, got %v after %d requests", serverVersion, requests)
+	}
+
+	client.ignoreVersionCheck = false
+	serverVersion, diags = client.ServerVersion(context.Background())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if serverVersion == nil || serverVersion.String() != "7.17.7" {
+		t.Errorf("expected the version of the server, got %v", serverVersion)
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func PutSnapshotRepository(ctx context.Context, apiClient *clients.ApiClient, repository *models.SnapshotRepository) diag.Diagnostics {
@@ -19,12 +24,9 @@ func PutSnapshotRepository(ctx context.Context, apiClient *clients.ApiClient, re
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := apiClient.GetESClient().Snapshot.CreateRepository(repository.Name, bytes.NewReader(snapRepoBytes), apiClient.GetESClient().Snapshot.CreateRepository.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to create or update the snapshot repository"); diags.HasError() {
+	if diags := retryOnConcurrentSnapshot(ctx, "Unable to create or update the snapshot repository", func() (*esapi.Response, error) {
+		return apiClient.GetESClient().Snapshot.CreateRepository(repository.Name, bytes.NewReader(snapRepoBytes), apiClient.GetESClient().Snapshot.CreateRepository.WithContext(ctx))
+	}); diags.HasError() {
 		return diags
 	}
 
@@ -65,14 +67,109 @@ func GetSnapshotRepository(ctx context.Context, apiClient *clients.ApiClient, na
 	return nil, diags
 }
 
+// GetSnapshotRepositories returns all the registered snapshot repositories by their name.
+func GetSnapshotRepositories(ctx context.Context, apiClient *clients.ApiClient) (map[string]models.SnapshotRepository, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Snapshot.GetRepository(apiClient.GetESClient().Snapshot.GetRepository.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the snapshot repositories"); diags.HasError() {
+		return nil, diags
+	}
+	repositories := make(map[string]models.SnapshotRepository)
+	if err := json.NewDecoder(res.Body).Decode(&repositories); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for name, repository := range repositories {
+		repository.Name = name
+		repositories[name] = repository
+	}
+	return repositories, nil
+}
+
 func DeleteSnapshotRepository(ctx context.Context, apiClient *clients.ApiClient, name string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := apiClient.GetESClient().Snapshot.DeleteRepository([]string{name}, apiClient.GetESClient().Snapshot.DeleteRepository.WithContext(ctx))
+	if diags := retryOnConcurrentSnapshot(ctx, fmt.Sprintf("Unable to delete snapshot repository: %s", name), func() (*esapi.Response, error) {
+		return apiClient.GetESClient().Snapshot.DeleteRepository([]string{name}, apiClient.GetESClient().Snapshot.DeleteRepository.WithContext(ctx))
+	}); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func CreateSnapshot(ctx context.Context, apiClient *clients.ApiClient, repository, name string, snapshot *models.Snapshot, waitForCompletion bool) (*models.SnapshotInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	snapshotBytes, err := json.Marshal(snapshot)
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, diag.FromErr(err)
+	}
+	var response struct {
+		Snapshot *models.SnapshotInfo `json:"snapshot"`
+	}
+	if diags := retryOnConcurrentSnapshot(ctx, fmt.Sprintf("Unable to create the snapshot: %s", name), func() (*esapi.Response, error) {
+		opts := []func(*esapi.SnapshotCreateRequest){
+			apiClient.GetESClient().Snapshot.Create.WithContext(ctx),
+			apiClient.GetESClient().Snapshot.Create.WithBody(bytes.NewReader(snapshotBytes)),
+			apiClient.GetESClient().Snapshot.Create.WithWaitForCompletion(waitForCompletion),
+		}
+		if t := apiClient.MasterTimeout(); t > 0 {
+			opts = append(opts, apiClient.GetESClient().Snapshot.Create.WithMasterTimeout(t))
+		}
+		res, err := apiClient.GetESClient().Snapshot.Create(repository, name, opts...)
+		if err != nil || res.IsError() {
+			return res, err
+		}
+		// the body is consumed here, the response is successful
+		defer res.Body.Close()
+		if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+			return nil, err
+		}
+		res.Body = io.NopCloser(bytes.NewReader(nil))
+		return res, nil
+	}); diags.HasError() {
+		return nil, diags
+	}
+	return response.Snapshot, diags
+}
+
+func GetSnapshot(ctx context.Context, apiClient *clients.ApiClient, repository, name string) (*models.SnapshotInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	res, err := apiClient.GetESClient().Snapshot.Get(repository, []string{name}, apiClient.GetESClient().Snapshot.Get.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete snapshot repository: %s", name)); diags.HasError() {
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the snapshot: %s", name)); diags.HasError() {
+		return nil, diags
+	}
+	var response struct {
+		Snapshots []models.SnapshotInfo `json:"snapshots"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if len(response.Snapshots) == 0 {
+		return nil, nil
+	}
+	return &response.Snapshots[0], diags
+}
+
+func DeleteSnapshot(ctx context.Context, apiClient *clients.ApiClient, repository, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if diags := retryOnConcurrentSnapshot(ctx, fmt.Sprintf("Unable to delete the snapshot: %s", name), func() (*esapi.Response, error) {
+		res, err := apiClient.GetESClient().Snapshot.Delete(repository, name, apiClient.GetESClient().Snapshot.Delete.WithContext(ctx))
+		if err == nil && res.StatusCode == http.StatusNotFound {
+			// already deleted
+			res.Body.Close()
+			res.StatusCode = http.StatusOK
+			res.Body = io.NopCloser(bytes.NewReader(nil))
+		}
+		return res, err
+	}); diags.HasError() {
 		return diags
 	}
 	return diags
@@ -85,13 +182,10 @@ func PutSlm(ctx context.Context, apiClient *clients.ApiClient, slm *models.Snaps
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	req := apiClient.GetESClient().SlmPutLifecycle.WithBody(bytes.NewReader(slmBytes))
-	res, err := apiClient.GetESClient().SlmPutLifecycle(slm.Id, req, apiClient.GetESClient().SlmPutLifecycle.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to create or update the SLM"); diags.HasError() {
+	if diags := retryOnConcurrentSnapshot(ctx, "Unable to create or update the SLM", func() (*esapi.Response, error) {
+		req := apiClient.GetESClient().SlmPutLifecycle.WithBody(bytes.NewReader(slmBytes))
+		return apiClient.GetESClient().SlmPutLifecycle(slm.Id, req, apiClient.GetESClient().SlmPutLifecycle.WithContext(ctx))
+	}); diags.HasError() {
 		return diags
 	}
 
@@ -100,8 +194,15 @@ func PutSlm(ctx context.Context, apiClient *clients.ApiClient, slm *models.Snaps
 
 func GetSlm(ctx context.Context, apiClient *clients.ApiClient, slmName string) (*models.SnapshotPolicy, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	req := apiClient.GetESClient().SlmGetLifecycle.WithPolicyID(slmName)
-	res, err := apiClient.GetESClient().SlmGetLifecycle(req, apiClient.GetESClient().SlmGetLifecycle.WithContext(ctx))
+	opts := []func(*esapi.SlmGetLifecycleRequest){
+		apiClient.GetESClient().SlmGetLifecycle.WithPolicyID(slmName),
+		apiClient.GetESClient().SlmGetLifecycle.WithContext(ctx),
+	}
+	if apiClient.MinimizeResponses() {
+		// leave out the stats and the last executions
+		opts = append(opts, apiClient.GetESClient().SlmGetLifecycle.WithFilterPath("*.policy"))
+	}
+	res, err := apiClient.GetESClient().SlmGetLifecycle(opts...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -132,25 +233,93 @@ func GetSlm(ctx context.Context, apiClient *clients.ApiClient, slmName string) (
 
 func DeleteSlm(ctx context.Context, apiClient *clients.ApiClient, slmName string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := apiClient.GetESClient().SlmDeleteLifecycle(slmName, apiClient.GetESClient().SlmDeleteLifecycle.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer res.Body.Close()
-	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete SLM policy: %s", slmName)); diags.HasError() {
+	if diags := retryOnConcurrentSnapshot(ctx, fmt.Sprintf("Unable to delete SLM policy: %s", slmName), func() (*esapi.Response, error) {
+		return apiClient.GetESClient().SlmDeleteLifecycle(slmName, apiClient.GetESClient().SlmDeleteLifecycle.WithContext(ctx))
+	}); diags.HasError() {
 		return diags
 	}
 
 	return diags
 }
 
+// defaultConcurrentSnapshotTimeout is used to wait for the running snapshot when the context has no deadline.
+const defaultConcurrentSnapshotTimeout = 20 * time.Minute
+
+// retryOnConcurrentSnapshot sends the request until it doesn't fail with concurrent_snapshot_execution_exception,
+// backing off exponentially until the running snapshot completes or the context deadline is reached.
+func retryOnConcurrentSnapshot(ctx context.Context, errMsg string, do func() (*esapi.Response, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+	timeout := defaultConcurrentSnapshotTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		res, err := do()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		defer res.Body.Close()
+		if !res.IsError() {
+			return nil
+		}
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if isConcurrentSnapshotError(body) {
+			tflog.Debug(ctx, fmt.Sprintf("Another snapshot is running, retrying: %s", body))
+			return resource.RetryableError(fmt.Errorf("%s: %s", errMsg, body))
+		}
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		diags = utils.CheckError(res, errMsg)
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// isConcurrentSnapshotError checks if the error response is caused by the snapshot operation running concurrently.
+func isConcurrentSnapshotError(body []byte) bool {
+	var errResponse struct {
+		Error struct {
+			Type      string `json:"type"`
+			RootCause []struct {
+				Type string `json:"type"`
+			} `json:"root_cause"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errResponse); err != nil {
+		return false
+	}
+	if errResponse.Error.Type == "concurrent_snapshot_execution_exception" {
+		return true
+	}
+	for _, cause := range errResponse.Error.RootCause {
+		if cause.Type == "concurrent_snapshot_execution_exception" {
+			return true
+		}
+	}
+	return false
+}
+
 func PutSettings(ctx context.Context, apiClient *clients.ApiClient, settings map[string]interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	settingsBytes, err := json.Marshal(settings)
 	if err != nil {
 		diag.FromErr(err)
 	}
-	res, err := apiClient.GetESClient().Cluster.PutSettings(bytes.NewReader(settingsBytes), apiClient.GetESClient().Cluster.PutSettings.WithContext(ctx))
+	opts := []func(*esapi.ClusterPutSettingsRequest){
+		apiClient.GetESClient().Cluster.PutSettings.WithContext(ctx),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.PutSettings.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.PutSettings.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Cluster.PutSettings(bytes.NewReader(settingsBytes), opts...)
 	if err != nil {
 		diag.FromErr(err)
 	}
@@ -180,6 +349,77 @@ func GetSettings(ctx context.Context, apiClient *clients.ApiClient) (map[string]
 	return clusterSettings, diags
 }
 
+func GetClusterHealth(ctx context.Context, apiClient *clients.ApiClient, params *models.ClusterHealthParams) (*models.ClusterHealth, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.ClusterHealthRequest){
+		apiClient.GetESClient().Cluster.Health.WithContext(ctx),
+	}
+	if len(params.Indices) > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.Health.WithIndex(params.Indices...))
+	}
+	if params.WaitForStatus != "" {
+		opts = append(opts, apiClient.GetESClient().Cluster.Health.WithWaitForStatus(params.WaitForStatus))
+	}
+	if params.WaitForNodes != "" {
+		opts = append(opts, apiClient.GetESClient().Cluster.Health.WithWaitForNodes(params.WaitForNodes))
+	}
+	if params.WaitForActiveShards != "" {
+		opts = append(opts, apiClient.GetESClient().Cluster.Health.WithWaitForActiveShards(params.WaitForActiveShards))
+	}
+	if params.Timeout > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.Health.WithTimeout(params.Timeout))
+	}
+	res, err := apiClient.GetESClient().Cluster.Health(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	// the API responds with 408 when the wait conditions are not met within the timeout
+	if res.StatusCode != http.StatusRequestTimeout {
+		if diags := utils.CheckError(res, "Unable to get the cluster health."); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	var health models.ClusterHealth
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if health.TimedOut {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Timed out waiting for the cluster health",
+			Detail: fmt.Sprintf(`The wait conditions (status: "%s", nodes: "%s", active shards: "%s") were not met within %s. Current status: "%s", number of nodes: %d, unassigned shards: %d.`,
+				params.WaitForStatus, params.WaitForNodes, params.WaitForActiveShards, params.Timeout, health.Status, health.NumberOfNodes, health.UnassignedShards),
+		})
+		return &health, diags
+	}
+	return &health, diags
+}
+
+func GetNodes(ctx context.Context, apiClient *clients.ApiClient) ([]models.CatNode, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Cat.Nodes(
+		apiClient.GetESClient().Cat.Nodes.WithContext(ctx),
+		apiClient.GetESClient().Cat.Nodes.WithFormat("json"),
+		apiClient.GetESClient().Cat.Nodes.WithFullID(true),
+		apiClient.GetESClient().Cat.Nodes.WithBytes("b"),
+		apiClient.GetESClient().Cat.Nodes.WithH("id", "name", "ip", "node.role", "master", "version", "heap.current", "heap.max", "heap.percent", "disk.used", "disk.total", "disk.used_percent"),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the nodes of the cluster."); diags.HasError() {
+		return nil, diags
+	}
+
+	var nodes []models.CatNode
+	if err := json.NewDecoder(res.Body).Decode(&nodes); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return nodes, nil
+}
+
 func GetScript(ctx context.Context, apiClient *clients.ApiClient, id string) (*models.Script, diag.Diagnostics) {
 	res, err := apiClient.GetESClient().GetScript(id, apiClient.GetESClient().GetScript.WithContext(ctx))
 	if err != nil {
@@ -234,3 +474,84 @@ func DeleteScript(ctx context.Context, apiClient *clients.ApiClient, id string)
 	}
 	return nil
 }
+
+func GetTasks(ctx context.Context, apiClient *clients.ApiClient, actions, nodes []string, detailed bool) ([]models.TaskInfo, diag.Diagnostics) {
+	opts := []func(*esapi.TasksListRequest){
+		apiClient.GetESClient().Tasks.List.WithContext(ctx),
+		apiClient.GetESClient().Tasks.List.WithGroupBy("none"),
+		apiClient.GetESClient().Tasks.List.WithDetailed(detailed),
+	}
+	if len(actions) > 0 {
+		opts = append(opts, apiClient.GetESClient().Tasks.List.WithActions(actions...))
+	}
+	if len(nodes) > 0 {
+		opts = append(opts, apiClient.GetESClient().Tasks.List.WithNodes(nodes...))
+	}
+	res, err := apiClient.GetESClient().Tasks.List(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to list the tasks."); diags.HasError() {
+		return nil, diags
+	}
+
+	var tasks struct {
+		Tasks []models.TaskInfo `json:"tasks"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&tasks); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return tasks.Tasks, nil
+}
+
+func GetTask(ctx context.Context, apiClient *clients.ApiClient, taskId string) (*models.Task, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Tasks.Get(taskId, apiClient.GetESClient().Tasks.Get.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the task: %s", taskId)); diags.HasError() {
+		return nil, diags
+	}
+
+	var task models.Task
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &task, nil
+}
+
+// defaultTaskTimeout is used to wait for the task when the context has no deadline.
+const defaultTaskTimeout = time.Hour
+
+// WaitForTask polls the task until it's completed or the context deadline is reached.
+func WaitForTask(ctx context.Context, apiClient *clients.ApiClient, taskId string) (*models.Task, diag.Diagnostics) {
+	var task *models.Task
+	timeout := defaultTaskTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		t, diags := GetTask(ctx, apiClient, taskId)
+		if diags.HasError() {
+			return resource.NonRetryableError(utils.DiagsAsError(diags))
+		}
+		if t == nil {
+			return resource.NonRetryableError(fmt.Errorf(`task "%s" not found`, taskId))
+		}
+		if !t.Completed {
+			tflog.Debug(ctx, fmt.Sprintf(`Task "%s" is still running`, taskId))
+			return resource.RetryableError(fmt.Errorf(`task "%s" is not completed`, taskId))
+		}
+		task = t
+		return nil
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return task, nil
+}
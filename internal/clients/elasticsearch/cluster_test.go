@@ -0,0 +1,39 @@
+package elasticsearch
+
+import "testing"
+
+func TestIsConcurrentSnapshotError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{
+			name: "concurrent snapshot execution",
+			body: `{"error":{"root_cause":[{"type":"concurrent_snapshot_execution_exception","reason":"[repo:snap] a snapshot is already running"}],"type":"concurrent_snapshot_execution_exception","reason":"[repo:snap] a snapshot is already running"},"status":503}`,
+			want: true,
+		},
+		{
+			name: "concurrent snapshot execution as the root cause",
+			body: `{"error":{"root_cause":[{"type":"concurrent_snapshot_execution_exception","reason":"a snapshot is already running"}],"type":"transport_exception","reason":"failed"},"status":503}`,
+			want: true,
+		},
+		{
+			name: "other error",
+			body: `{"error":{"root_cause":[{"type":"repository_missing_exception","reason":"[repo] missing"}],"type":"repository_missing_exception","reason":"[repo] missing"},"status":404}`,
+			want: false,
+		},
+		{
+			name: "not a JSON body",
+			body: `Service Unavailable`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConcurrentSnapshotError([]byte(tt.body)); got != tt.want {
+				t.Errorf("isConcurrentSnapshotError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
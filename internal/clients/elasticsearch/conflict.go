@@ -0,0 +1,228 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// conflictAttempts is the number of times the conflicting request is sent with the `reconcile_on_conflict` of the provider.
+const conflictAttempts = 3
+
+// conflictBackoff is the wait before sending the conflicting request again, increased with each attempt.
+var conflictBackoff = time.Second
+
+// versionConflictError is the error returned when a concurrent change updated the same document, e.g. of the security index.
+const versionConflictError = "version_conflict_engine_exception"
+
+// alreadyExistsError is the error returned when a concurrent change created the same index or data stream.
+const alreadyExistsError = "resource_already_exists_exception"
+
+// errorType returns the type of the error of the failed response, the body is left readable for utils.CheckError.
+func errorType(res *esapi.Response) string {
+	if !res.IsError() {
+		return ""
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return ""
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var errRes struct {
+		Error struct {
+			Type      string `json:"type"`
+			RootCause []struct {
+				Type string `json:"type"`
+			} `json:"root_cause"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errRes); err != nil {
+		return ""
+	}
+	for _, cause := range errRes.Error.RootCause {
+		if cause.Type == versionConflictError || cause.Type == alreadyExistsError {
+			return cause.Type
+		}
+	}
+	return errRes.Error.Type
+}
+
+// isConflict reports whether the request failed because of a concurrent change of the same object.
+func isConflict(res *esapi.Response) bool {
+	return res.StatusCode == http.StatusConflict || errorType(res) == versionConflictError
+}
+
+// isAlreadyExists reports whether the object has been created by a concurrent change in the meantime, in which case it
+// is reconciled with the configured one instead of being replaced.
+func isAlreadyExists(apiClient *clients.ApiClient, res *esapi.Response) bool {
+	return apiClient.ReconcileOnConflict() && errorType(res) == alreadyExistsError
+}
+
+// doReconcilingConflicts sends the create or update request again when it conflicts with a concurrent change and the
+// `reconcile_on_conflict` of the provider is enabled, the create and update APIs replacing the object with the configured one.
+func doReconcilingConflicts(ctx context.Context, apiClient *clients.ApiClient, name string, do func() (*esapi.Response, error)) (*esapi.Response, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := do()
+		if err != nil || attempt == conflictAttempts || !apiClient.ReconcileOnConflict() || !isConflict(res) {
+			return res, err
+		}
+		res.Body.Close()
+		tflog.Warn(ctx, fmt.Sprintf(`"%s" conflicted with a concurrent change, retrying (attempt %d of %d)`, name, attempt, conflictAttempts))
+
+		timer := time.NewTimer(time.Duration(attempt) * conflictBackoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reconcileIndex reconciles the index created by a concurrent change with the configured one: the differing dynamic
+// settings, the mappings and the aliases are applied, while the index is left untouched and an error is returned when
+// its static settings or the existing fields of its mappings differ, as they can only be changed by replacing it.
+func reconcileIndex(ctx context.Context, apiClient *clients.ApiClient, index *models.Index, params *models.PutIndexParams) diag.Diagnostics {
+	indicesSettings, diags := GetIndicesSettings(ctx, apiClient, []string{index.Name}, "all", true)
+	if diags.HasError() {
+		return diags
+	}
+	existingSettings, ok := indicesSettings[index.Name]
+	if !ok {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`Unable to create index "%s"`, index.Name),
+			Detail:   fmt.Sprintf(`"%s" has been created by a concurrent change, but it's not an index, e.g. it's a data stream or an alias.`, index.Name),
+		}}
+	}
+	existingMappings, diags := GetIndexMappings(ctx, apiClient, index.Name)
+	if diags.HasError() {
+		return diags
+	}
+
+	current := utils.NormalizeIndexSettings(utils.FlattenMap(existingSettings.Defaults))
+	for k, v := range utils.NormalizeIndexSettings(utils.FlattenMap(existingSettings.Settings)) {
+		current[k] = v
+	}
+	var staticConflicts []string
+	dynamicSettings := make(map[string]interface{})
+	configured := utils.FlattenMap(index.Settings)
+	normalized := utils.NormalizeIndexSettings(configured)
+	for k, v := range configured {
+		if !strings.HasPrefix(k, "index.") {
+			k = "index." + k
+		}
+		if existing, ok := current[k]; ok && existing == normalized[k] {
+			continue
+		}
+		if params.IsStaticSetting != nil && params.IsStaticSetting(k) {
+			staticConflicts = append(staticConflicts, k)
+			continue
+		}
+		dynamicSettings[k] = v
+	}
+	mappingConflicts := conflictingMappings(existingMappings, index.Mappings, "")
+	if len(staticConflicts) > 0 || len(mappingConflicts) > 0 {
+		sort.Strings(staticConflicts)
+		sort.Strings(mappingConflicts)
+		var details []string
+		if len(staticConflicts) > 0 {
+			details = append(details, fmt.Sprintf("the static settings %s", strings.Join(staticConflicts, ", ")))
+		}
+		if len(mappingConflicts) > 0 {
+			details = append(details, fmt.Sprintf("the mappings of the fields %s", strings.Join(mappingConflicts, ", ")))
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`Index "%s" has been created by a concurrent change with a different configuration`, index.Name),
+			Detail: fmt.Sprintf(`The existing index differs from the configured one in %s, which can only be set on the index creation. `+
+				`Align the configurations of the concurrent runs, or delete the index and apply again.`, strings.Join(details, " and ")),
+		}}
+	}
+
+	if len(dynamicSettings) > 0 {
+		if diags := UpdateIndexSettings(ctx, apiClient, index.Name, dynamicSettings); diags.HasError() {
+			return diags
+		}
+	}
+	if len(index.Mappings) > 0 {
+		mappings, err := json.Marshal(index.Mappings)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if diags := UpdateIndexMappings(ctx, apiClient, index.Name, string(mappings)); diags.HasError() {
+			return diags
+		}
+	}
+	for name, alias := range index.Aliases {
+		alias.Name = name
+		if diags := UpdateIndexAlias(ctx, apiClient, index.Name, &alias); diags.HasError() {
+			return diags
+		}
+	}
+	return nil
+}
+
+// conflictingMappings returns the paths of the fields of the configured mappings, which are defined differently in the
+// existing mappings. The fields missing from the existing mappings are not conflicting, as they are added to the index.
+func conflictingMappings(existing, configured map[string]interface{}, path string) []string {
+	existingProperties, _ := existing["properties"].(map[string]interface{})
+	configuredProperties, _ := configured["properties"].(map[string]interface{})
+	var conflicts []string
+	for name, c := range configuredProperties {
+		e, ok := existingProperties[name]
+		if !ok {
+			continue
+		}
+		existingField, _ := e.(map[string]interface{})
+		configuredField, _ := c.(map[string]interface{})
+		if !reflect.DeepEqual(withoutProperties(existingField), withoutProperties(configuredField)) {
+			conflicts = append(conflicts, path+name)
+			continue
+		}
+		conflicts = append(conflicts, conflictingMappings(existingField, configuredField, path+name+".")...)
+	}
+	return conflicts
+}
+
+func withoutProperties(field map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(field))
+	for k, v := range field {
+		if k != "properties" {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// reconcileDataStream checks that the object created by a concurrent change is a data stream, its backing indices being
+// configured by the matching index template.
+func reconcileDataStream(ctx context.Context, apiClient *clients.ApiClient, dataStreamName string) diag.Diagnostics {
+	ds, diags := GetDataStream(ctx, apiClient, dataStreamName)
+	if diags.HasError() {
+		return diags
+	}
+	if ds == nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`Unable to create DataStream: %s`, dataStreamName),
+			Detail:   fmt.Sprintf(`"%s" has been created by a concurrent change, but it's not a data stream, e.g. it's an index or an alias.`, dataStreamName),
+		}}
+	}
+	return nil
+}
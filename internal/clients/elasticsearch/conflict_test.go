@@ -0,0 +1,183 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newReconcilingTestClient(t *testing.T, endpoint string, reconcile bool) *clients.ApiClient {
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch":         providerSchema.GetConnectionSchema("elasticsearch", true),
+		"verify_connection":     {Type: schema.TypeBool, Optional: true},
+		"reconcile_on_conflict": {Type: schema.TypeBool, Optional: true},
+	}
+	d := schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{
+		"elasticsearch": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{endpoint},
+		}},
+		"reconcile_on_conflict": reconcile,
+	})
+	client, diags := clients.NewApiClientFunc("test")(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+	return client.(*clients.ApiClient)
+}
+
+func TestPutRoleReconcilesConflicts(t *testing.T) {
+	conflictBackoff = time.Millisecond
+	defer func() { conflictBackoff = time.Second }()
+
+	for _, reconcile := range []bool{false, true} {
+		t.Run(fmt.Sprintf("reconcile_on_conflict=%t", reconcile), func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/" {
+					fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+					return
+				}
+				requests++
+				if requests == 1 {
+					w.WriteHeader(http.StatusConflict)
+					fmt.Fprint(w, `{"error": {"root_cause": [{"type": "version_conflict_engine_exception"}], "type": "version_conflict_engine_exception", "reason": "[role-test]: version conflict"}, "status": 409}`)
+					return
+				}
+				fmt.Fprint(w, `{"role": {"created": false}}`)
+			}))
+			defer server.Close()
+
+			diags := PutRole(context.Background(), newReconcilingTestClient(t, server.URL, reconcile), &models.Role{Name: "test"})
+			if diags.HasError() == reconcile {
+				t.Fatalf("expected error: %t, got %v", !reconcile, diags)
+			}
+			if expected := map[bool]int{false: 1, true: 2}[reconcile]; requests != expected {
+				t.Errorf("expected %d requests, got %d", expected, requests)
+			}
+		})
+	}
+}
+
+func TestPutIndexReconcilesConcurrentlyCreated(t *testing.T) {
+	index := &models.Index{
+		Name:     "test",
+		Aliases:  map[string]models.IndexAlias{"test-alias": {}},
+		Mappings: map[string]interface{}{"properties": map[string]interface{}{"field": map[string]interface{}{"type": "keyword"}, "added": map[string]interface{}{"type": "long"}}},
+		Settings: map[string]interface{}{"number_of_shards": 1, "number_of_replicas": 2},
+	}
+	params := &models.PutIndexParams{
+		IsStaticSetting: func(key string) bool { return key == "index.number_of_shards" },
+	}
+
+	for _, tc := range []struct {
+		name        string
+		reconcile   bool
+		existing    string
+		expectError bool
+		updates     []string
+	}{
+		{
+			name:        "not reconciling",
+			existing:    `{"test": {"settings": {"index.number_of_shards": "1"}, "mappings": {}}}`,
+			expectError: true,
+		},
+		{
+			name:      "matching static settings and mappings",
+			reconcile: true,
+			existing:  `{"test": {"settings": {"index.number_of_shards": "1", "index.number_of_replicas": "1"}, "mappings": {"properties": {"field": {"type": "keyword"}}}}}`,
+			updates:   []string{"/test/_settings", "/test/_mapping", "/test/_aliases/test-alias"},
+		},
+		{
+			name:        "different static settings",
+			reconcile:   true,
+			existing:    `{"test": {"settings": {"index.number_of_shards": "3"}, "mappings": {}}}`,
+			expectError: true,
+		},
+		{
+			name:        "different mappings",
+			reconcile:   true,
+			existing:    `{"test": {"settings": {"index.number_of_shards": "1"}, "mappings": {"properties": {"field": {"type": "text"}}}}}`,
+			expectError: true,
+		},
+		{
+			name:        "not an index",
+			reconcile:   true,
+			existing:    `{".ds-test-000001": {"settings": {"index.number_of_shards": "1"}, "mappings": {}}}`,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var updates []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/":
+					fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+				case r.Method == http.MethodPut && r.URL.Path == "/test":
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error": {"root_cause": [{"type": "resource_already_exists_exception"}], "type": "resource_already_exists_exception", "reason": "index [test/abc] already exists"}, "status": 400}`)
+				case r.Method == http.MethodGet:
+					fmt.Fprint(w, tc.existing)
+				default:
+					updates = append(updates, r.URL.Path)
+					fmt.Fprint(w, `{"acknowledged": true}`)
+				}
+			}))
+			defer server.Close()
+
+			diags := PutIndex(context.Background(), newReconcilingTestClient(t, server.URL, tc.reconcile), index, params)
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got %v", tc.expectError, diags)
+			}
+			if !reflect.DeepEqual(updates, tc.updates) {
+				t.Errorf("expected the updates %v, got %v", tc.updates, updates)
+			}
+		})
+	}
+}
+
+func TestPutDataStreamReconcilesConcurrentlyCreated(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "data stream", status: http.StatusOK},
+		{name: "not a data stream", status: http.StatusNotFound, expectError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/":
+					fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+				case r.Method == http.MethodPut:
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error": {"root_cause": [{"type": "resource_already_exists_exception"}], "type": "resource_already_exists_exception", "reason": "index [test] already exists"}, "status": 400}`)
+				default:
+					w.WriteHeader(tc.status)
+					fmt.Fprint(w, `{"data_streams": [{"name": "test"}]}`)
+				}
+			}))
+			defer server.Close()
+
+			diags := PutDataStream(context.Background(), newReconcilingTestClient(t, server.URL, true), "test")
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got %v", tc.expectError, diags)
+			}
+		})
+	}
+}
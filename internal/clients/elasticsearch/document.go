@@ -0,0 +1,217 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func UpdateByQuery(ctx context.Context, apiClient *clients.ApiClient, indices []string, query *models.ByQuery, params *models.ByQueryParams) (*models.ByQueryResponse, diag.Diagnostics) {
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	updateByQuery := apiClient.GetESClient().UpdateByQuery
+	opts := []func(*esapi.UpdateByQueryRequest){
+		updateByQuery.WithContext(ctx),
+		updateByQuery.WithBody(bytes.NewReader(queryBytes)),
+		updateByQuery.WithRefresh(params.Refresh),
+		updateByQuery.WithWaitForCompletion(params.WaitForCompletion),
+	}
+	if params.Conflicts != "" {
+		opts = append(opts, updateByQuery.WithConflicts(params.Conflicts))
+	}
+	if params.Slices != "" {
+		opts = append(opts, updateByQuery.WithSlices(params.Slices))
+	}
+	res, err := updateByQuery(indices, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to update the documents by query in: %s", strings.Join(indices, ","))); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.ByQueryResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, nil
+}
+
+func DeleteByQuery(ctx context.Context, apiClient *clients.ApiClient, indices []string, query *models.ByQuery, params *models.ByQueryParams) (*models.ByQueryResponse, diag.Diagnostics) {
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	deleteByQuery := apiClient.GetESClient().DeleteByQuery
+	opts := []func(*esapi.DeleteByQueryRequest){
+		deleteByQuery.WithContext(ctx),
+		deleteByQuery.WithRefresh(params.Refresh),
+		deleteByQuery.WithWaitForCompletion(params.WaitForCompletion),
+	}
+	if params.Conflicts != "" {
+		opts = append(opts, deleteByQuery.WithConflicts(params.Conflicts))
+	}
+	if params.Slices != "" {
+		opts = append(opts, deleteByQuery.WithSlices(params.Slices))
+	}
+	res, err := deleteByQuery(indices, bytes.NewReader(queryBytes), opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the documents by query in: %s", strings.Join(indices, ","))); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.ByQueryResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, nil
+}
+
+// BulkDocuments indexes and deletes the documents of the index with a single bulk request,
+// reporting the failure of each operation.
+func BulkDocuments(ctx context.Context, apiClient *clients.ApiClient, index string, operations []models.BulkOperation, refresh string) diag.Diagnostics {
+	if len(operations) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	for _, op := range operations {
+		action, err := json.Marshal(map[string]interface{}{op.Action: map[string]string{"_id": op.Id}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		if op.Action == "index" {
+			// the documents must be written on a single line
+			if err := json.Compact(&body, op.Source); err != nil {
+				return diag.FromErr(fmt.Errorf("invalid JSON of the document %s: %w", op.Id, err))
+			}
+			body.WriteByte('\n')
+		}
+	}
+	res, err := apiClient.GetESClient().Bulk(
+		&body,
+		apiClient.GetESClient().Bulk.WithContext(ctx),
+		apiClient.GetESClient().Bulk.WithIndex(index),
+		apiClient.GetESClient().Bulk.WithRefresh(refresh),
+		apiClient.GetESClient().Bulk.WithFilterPath("errors", "items.*._id", "items.*.status", "items.*.error"),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to apply the bulk operations to the index: %s", index)); diags.HasError() {
+		return diags
+	}
+
+	var response models.BulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return diag.FromErr(err)
+	}
+	if !response.Errors {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, item := range response.Items {
+		for action, result := range item {
+			// the documents already deleted are fine
+			if result.Error == nil || (action == "delete" && result.Status == http.StatusNotFound) {
+				continue
+			}
+			detail, _ := json.Marshal(result.Error)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to %s the document %s in the index %s", action, result.Id, index),
+				Detail:   string(detail),
+			})
+		}
+	}
+	return diags
+}
+
+// GetDocuments returns the sources of the documents of the index found by their IDs, the missing documents are left out.
+func GetDocuments(ctx context.Context, apiClient *clients.ApiClient, index string, ids []string) (map[string]json.RawMessage, diag.Diagnostics) {
+	docs := make(map[string]json.RawMessage)
+	if len(ids) == 0 {
+		return docs, nil
+	}
+	body, err := json.Marshal(map[string]interface{}{"ids": ids})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().Mget(
+		bytes.NewReader(body),
+		apiClient.GetESClient().Mget.WithContext(ctx),
+		apiClient.GetESClient().Mget.WithIndex(index),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return docs, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the documents of the index: %s", index)); diags.HasError() {
+		return nil, diags
+	}
+
+	var response models.MgetResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for _, doc := range response.Docs {
+		if doc.Found {
+			docs[doc.Id] = doc.Source
+		}
+	}
+	return docs, nil
+}
+
+// GetDocument returns the document of the index with its `_source` filtered by the includes and excludes, nil if the
+// document does not exist.
+func GetDocument(ctx context.Context, apiClient *clients.ApiClient, index, id string, includes, excludes []string) (*models.Document, diag.Diagnostics) {
+	opts := []func(*esapi.GetRequest){
+		apiClient.GetESClient().Get.WithContext(ctx),
+	}
+	if len(includes) > 0 {
+		opts = append(opts, apiClient.GetESClient().Get.WithSourceIncludes(includes...))
+	}
+	if len(excludes) > 0 {
+		opts = append(opts, apiClient.GetESClient().Get.WithSourceExcludes(excludes...))
+	}
+	res, err := apiClient.GetESClient().Get(index, id, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf(`Unable to get the document "%s" of the index: %s`, id, index)); diags.HasError() {
+		return nil, diags
+	}
+
+	var doc models.Document
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if !doc.Found {
+		return nil, nil
+	}
+	return &doc, nil
+}
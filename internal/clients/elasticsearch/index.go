@@ -5,13 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
@@ -21,8 +25,7 @@ func PutIlm(ctx context.Context, apiClient *clients.ApiClient, policy *models.Po
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	req := apiClient.GetESClient().ILM.PutLifecycle.WithBody(bytes.NewReader(policyBytes))
-	res, err := apiClient.GetESClient().ILM.PutLifecycle(policy.Name, req, apiClient.GetESClient().ILM.PutLifecycle.WithContext(ctx))
+	res, err := performIlmRequest(ctx, apiClient, http.MethodPut, policy.Name, bytes.NewReader(policyBytes))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -33,10 +36,63 @@ func PutIlm(ctx context.Context, apiClient *clients.ApiClient, policy *models.Po
 	return diags
 }
 
+// performIlmRequest sends the request on the ILM policy with the timeouts of the connection, which the lifecycle APIs
+// of the client do not support.
+func performIlmRequest(ctx context.Context, apiClient *clients.ApiClient, method, policyName string, body io.Reader) (*esapi.Response, error) {
+	params := url.Values{}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		params.Set("master_timeout", formatDuration(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		params.Set("timeout", formatDuration(t))
+	}
+	path := "/_ilm/policy/" + url.PathEscape(policyName)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpRes, err := apiClient.GetESClient().Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return &esapi.Response{StatusCode: httpRes.StatusCode, Body: httpRes.Body, Header: httpRes.Header}, nil
+}
+
+// formatDuration formats the duration the same as the query parameters of the client.
+func formatDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%dnanos", int64(d))
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
 func GetIlm(ctx context.Context, apiClient *clients.ApiClient, policyName string) (*models.PolicyDefinition, diag.Diagnostics) {
+	return getIlm(ctx, apiClient, policyName, false)
+}
+
+// GetIlmWithUsage returns the ILM policy together with the indices, data streams and templates using it,
+// regardless of the `minimize_responses` setting of the connection.
+func GetIlmWithUsage(ctx context.Context, apiClient *clients.ApiClient, policyName string) (*models.PolicyDefinition, diag.Diagnostics) {
+	return getIlm(ctx, apiClient, policyName, true)
+}
+
+func getIlm(ctx context.Context, apiClient *clients.ApiClient, policyName string, withUsage bool) (*models.PolicyDefinition, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	req := apiClient.GetESClient().ILM.GetLifecycle.WithPolicy(policyName)
-	res, err := apiClient.GetESClient().ILM.GetLifecycle(req, apiClient.GetESClient().ILM.GetLifecycle.WithContext(ctx))
+	opts := []func(*esapi.ILMGetLifecycleRequest){
+		apiClient.GetESClient().ILM.GetLifecycle.WithPolicy(policyName),
+		apiClient.GetESClient().ILM.GetLifecycle.WithContext(ctx),
+	}
+	if apiClient.MinimizeResponses() && !withUsage {
+		// leave out the indices using the policy
+		opts = append(opts, apiClient.GetESClient().ILM.GetLifecycle.WithFilterPath("*.policy", "*.modified_date"))
+	}
+	res, err := apiClient.GetESClient().ILM.GetLifecycle(opts...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -68,7 +124,7 @@ func GetIlm(ctx context.Context, apiClient *clients.ApiClient, policyName string
 func DeleteIlm(ctx context.Context, apiClient *clients.ApiClient, policyName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	res, err := apiClient.GetESClient().ILM.DeleteLifecycle(policyName, apiClient.GetESClient().ILM.DeleteLifecycle.WithContext(ctx))
+	res, err := performIlmRequest(ctx, apiClient, http.MethodDelete, policyName, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -79,6 +135,41 @@ func DeleteIlm(ctx context.Context, apiClient *clients.ApiClient, policyName str
 	return diags
 }
 
+func ExplainIlm(ctx context.Context, apiClient *clients.ApiClient, index string, onlyErrors, onlyManaged bool) (map[string]models.IlmExplain, diag.Diagnostics) {
+	opts := []func(*esapi.ILMExplainLifecycleRequest){
+		apiClient.GetESClient().ILM.ExplainLifecycle.WithContext(ctx),
+	}
+	if onlyErrors {
+		opts = append(opts, apiClient.GetESClient().ILM.ExplainLifecycle.WithOnlyErrors(true))
+	}
+	if onlyManaged {
+		opts = append(opts, apiClient.GetESClient().ILM.ExplainLifecycle.WithOnlyManaged(true))
+	}
+	if apiClient.MinimizeResponses() {
+		// leave out the definitions of the phases being executed
+		opts = append(opts, apiClient.GetESClient().ILM.ExplainLifecycle.WithFilterPath(
+			"indices.*.index", "indices.*.managed", "indices.*.policy", "indices.*.phase", "indices.*.action", "indices.*.step", "indices.*.failed_step",
+			"indices.*.failed_step_retry_count", "indices.*.is_auto_retryable_error", "indices.*.age", "indices.*.step_info",
+		))
+	}
+	res, err := apiClient.GetESClient().ILM.ExplainLifecycle(index, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to explain the lifecycle of the index: %s", index)); diags.HasError() {
+		return nil, diags
+	}
+
+	var explainResponse struct {
+		Indices map[string]models.IlmExplain `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&explainResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return explainResponse.Indices, nil
+}
+
 func PutComponentTemplate(ctx context.Context, apiClient *clients.ApiClient, template *models.ComponentTemplate) diag.Diagnostics {
 	var diags diag.Diagnostics
 	templateBytes, err := json.Marshal(template)
@@ -86,7 +177,18 @@ func PutComponentTemplate(ctx context.Context, apiClient *clients.ApiClient, tem
 		return diag.FromErr(err)
 	}
 
-	res, err := apiClient.GetESClient().Cluster.PutComponentTemplate(template.Name, bytes.NewReader(templateBytes), apiClient.GetESClient().Cluster.PutComponentTemplate.WithContext(ctx))
+	opts := []func(*esapi.ClusterPutComponentTemplateRequest){
+		apiClient.GetESClient().Cluster.PutComponentTemplate.WithContext(ctx),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.PutComponentTemplate.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.PutComponentTemplate.WithTimeout(t))
+	}
+	res, err := doReconcilingConflicts(ctx, apiClient, template.Name, func() (*esapi.Response, error) {
+		return apiClient.GetESClient().Cluster.PutComponentTemplate(template.Name, bytes.NewReader(templateBytes), opts...)
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -131,9 +233,40 @@ func GetComponentTemplate(ctx context.Context, apiClient *clients.ApiClient, tem
 	return &tpl, diags
 }
 
+// GetComponentTemplates returns the component templates matching the name, which supports wildcards and comma-separated lists.
+func GetComponentTemplates(ctx context.Context, apiClient *clients.ApiClient, name string) ([]models.ComponentTemplateResponse, diag.Diagnostics) {
+	req := apiClient.GetESClient().Cluster.GetComponentTemplate.WithName(name)
+	res, err := apiClient.GetESClient().Cluster.GetComponentTemplate(req, apiClient.GetESClient().Cluster.GetComponentTemplate.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return []models.ComponentTemplateResponse{}, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the component templates: %s", name)); diags.HasError() {
+		return nil, diags
+	}
+
+	var componentTemplates models.ComponentTemplatesResponse
+	if err := json.NewDecoder(res.Body).Decode(&componentTemplates); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return componentTemplates.ComponentTemplates, nil
+}
+
 func DeleteComponentTemplate(ctx context.Context, apiClient *clients.ApiClient, templateName string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := apiClient.GetESClient().Cluster.DeleteComponentTemplate(templateName, apiClient.GetESClient().Cluster.DeleteComponentTemplate.WithContext(ctx))
+	opts := []func(*esapi.ClusterDeleteComponentTemplateRequest){
+		apiClient.GetESClient().Cluster.DeleteComponentTemplate.WithContext(ctx),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.DeleteComponentTemplate.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Cluster.DeleteComponentTemplate.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Cluster.DeleteComponentTemplate(templateName, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -151,7 +284,15 @@ func PutIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templat
 		return diag.FromErr(err)
 	}
 
-	res, err := apiClient.GetESClient().Indices.PutIndexTemplate(template.Name, bytes.NewReader(templateBytes), apiClient.GetESClient().Indices.PutIndexTemplate.WithContext(ctx))
+	opts := []func(*esapi.IndicesPutIndexTemplateRequest){
+		apiClient.GetESClient().Indices.PutIndexTemplate.WithContext(ctx),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutIndexTemplate.WithMasterTimeout(t))
+	}
+	res, err := doReconcilingConflicts(ctx, apiClient, template.Name, func() (*esapi.Response, error) {
+		return apiClient.GetESClient().Indices.PutIndexTemplate(template.Name, bytes.NewReader(templateBytes), opts...)
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -198,7 +339,16 @@ func GetIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templat
 
 func DeleteIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templateName string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := apiClient.GetESClient().Indices.DeleteIndexTemplate(templateName, apiClient.GetESClient().Indices.DeleteIndexTemplate.WithContext(ctx))
+	opts := []func(*esapi.IndicesDeleteIndexTemplateRequest){
+		apiClient.GetESClient().Indices.DeleteIndexTemplate.WithContext(ctx),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.DeleteIndexTemplate.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.DeleteIndexTemplate.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Indices.DeleteIndexTemplate(templateName, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -209,6 +359,21 @@ func DeleteIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, temp
 	return diags
 }
 
+func LegacyIndexTemplateExists(ctx context.Context, apiClient *clients.ApiClient, templateName string) (bool, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Indices.ExistsTemplate([]string{templateName}, apiClient.GetESClient().Indices.ExistsTemplate.WithContext(ctx))
+	if err != nil {
+		return false, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to check if the legacy index template exists: %s", templateName)); diags.HasError() {
+		return false, diags
+	}
+	return true, nil
+}
+
 func PutIndex(ctx context.Context, apiClient *clients.ApiClient, index *models.Index, params *models.PutIndexParams) diag.Diagnostics {
 	var diags diag.Diagnostics
 	indexBytes, err := json.Marshal(index)
@@ -231,23 +396,35 @@ func PutIndex(ctx context.Context, apiClient *clients.ApiClient, index *models.I
 		opts...,
 	)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
+	if isAlreadyExists(apiClient, res) {
+		tflog.Warn(ctx, fmt.Sprintf(`Index "%s" has been created by a concurrent change, reconciling with it`, index.Name))
+		return reconcileIndex(ctx, apiClient, index, params)
+	}
 	if diags := utils.CheckError(res, fmt.Sprintf("Unable to create index: %s", index.Name)); diags.HasError() {
 		return diags
 	}
 	return diags
 }
 
+// DeleteIndex deletes the index, the already deleted index is ignored.
 func DeleteIndex(ctx context.Context, apiClient *clients.ApiClient, name string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	res, err := apiClient.GetESClient().Indices.Delete([]string{name}, apiClient.GetESClient().Indices.Delete.WithContext(ctx))
+	res, err := apiClient.GetESClient().Indices.Delete([]string{name},
+		apiClient.GetESClient().Indices.Delete.WithContext(ctx),
+		apiClient.GetESClient().Indices.Delete.WithIgnoreUnavailable(true),
+		apiClient.GetESClient().Indices.Delete.WithAllowNoIndices(true),
+	)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return diags
+	}
 	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete the index: %s", name)); diags.HasError() {
 		return diags
 	}
@@ -255,6 +432,36 @@ func DeleteIndex(ctx context.Context, apiClient *clients.ApiClient, name string)
 	return diags
 }
 
+// CloseIndex blocks the read and write operations on the index.
+func CloseIndex(ctx context.Context, apiClient *clients.ApiClient, name string) diag.Diagnostics {
+	res, err := apiClient.GetESClient().Indices.Close([]string{name}, apiClient.GetESClient().Indices.Close.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to close the index: %s", name)); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// OpenIndex reopens the closed index, waiting for the given number of active shards.
+func OpenIndex(ctx context.Context, apiClient *clients.ApiClient, name string, waitForActiveShards string) diag.Diagnostics {
+	res, err := apiClient.GetESClient().Indices.Open(
+		[]string{name},
+		apiClient.GetESClient().Indices.Open.WithContext(ctx),
+		apiClient.GetESClient().Indices.Open.WithWaitForActiveShards(waitForActiveShards),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to open the index: %s", name)); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
 func GetIndex(ctx context.Context, apiClient *clients.ApiClient, name string) (*models.Index, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -281,13 +488,27 @@ func GetIndex(ctx context.Context, apiClient *clients.ApiClient, name string) (*
 	return &index, diags
 }
 
+// DeleteIndexAlias removes the aliases from the index, the already removed aliases are ignored.
 func DeleteIndexAlias(ctx context.Context, apiClient *clients.ApiClient, index string, aliases []string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	res, err := apiClient.GetESClient().Indices.DeleteAlias([]string{index}, aliases, apiClient.GetESClient().Indices.DeleteAlias.WithContext(ctx))
+	opts := []func(*esapi.IndicesDeleteAliasRequest){
+		apiClient.GetESClient().Indices.DeleteAlias.WithContext(ctx),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.DeleteAlias.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.DeleteAlias.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Indices.DeleteAlias([]string{index}, aliases, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
+	// the API has no ignore_unavailable, the missing aliases or index are already gone
+	if res.StatusCode == http.StatusNotFound {
+		return diags
+	}
 	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete aliases '%v' for index '%s'", index, aliases)); diags.HasError() {
 		return diags
 	}
@@ -300,8 +521,17 @@ func UpdateIndexAlias(ctx context.Context, apiClient *clients.ApiClient, index s
 	if err != nil {
 		diag.FromErr(err)
 	}
-	req := apiClient.GetESClient().Indices.PutAlias.WithBody(bytes.NewReader(aliasBytes))
-	res, err := apiClient.GetESClient().Indices.PutAlias([]string{index}, alias.Name, req, apiClient.GetESClient().Indices.PutAlias.WithContext(ctx))
+	opts := []func(*esapi.IndicesPutAliasRequest){
+		apiClient.GetESClient().Indices.PutAlias.WithContext(ctx),
+		apiClient.GetESClient().Indices.PutAlias.WithBody(bytes.NewReader(aliasBytes)),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutAlias.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutAlias.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Indices.PutAlias([]string{index}, alias.Name, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -318,8 +548,17 @@ func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, inde
 	if err != nil {
 		diag.FromErr(err)
 	}
-	req := apiClient.GetESClient().Indices.PutSettings.WithIndex(index)
-	res, err := apiClient.GetESClient().Indices.PutSettings(bytes.NewReader(settingsBytes), req, apiClient.GetESClient().Indices.PutSettings.WithContext(ctx))
+	opts := []func(*esapi.IndicesPutSettingsRequest){
+		apiClient.GetESClient().Indices.PutSettings.WithContext(ctx),
+		apiClient.GetESClient().Indices.PutSettings.WithIndex(index),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutSettings.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutSettings.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Indices.PutSettings(bytes.NewReader(settingsBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -332,8 +571,17 @@ func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, inde
 
 func UpdateIndexMappings(ctx context.Context, apiClient *clients.ApiClient, index, mappings string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	req := apiClient.GetESClient().Indices.PutMapping.WithIndex(index)
-	res, err := apiClient.GetESClient().Indices.PutMapping(strings.NewReader(mappings), req, apiClient.GetESClient().Indices.PutMapping.WithContext(ctx))
+	opts := []func(*esapi.IndicesPutMappingRequest){
+		apiClient.GetESClient().Indices.PutMapping.WithContext(ctx),
+		apiClient.GetESClient().Indices.PutMapping.WithIndex(index),
+	}
+	if t := apiClient.MasterTimeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutMapping.WithMasterTimeout(t))
+	}
+	if t := apiClient.Timeout(); t > 0 {
+		opts = append(opts, apiClient.GetESClient().Indices.PutMapping.WithTimeout(t))
+	}
+	res, err := apiClient.GetESClient().Indices.PutMapping(strings.NewReader(mappings), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -344,6 +592,145 @@ func UpdateIndexMappings(ctx context.Context, apiClient *clients.ApiClient, inde
 	return diags
 }
 
+func GetIndexMappings(ctx context.Context, apiClient *clients.ApiClient, index string) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	req := apiClient.GetESClient().Indices.GetMapping.WithIndex(index)
+	res, err := apiClient.GetESClient().Indices.GetMapping(req, apiClient.GetESClient().Indices.GetMapping.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get mappings of the index: %s", index)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make(map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	})
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if idx, ok := indices[index]; ok {
+		return idx.Mappings, diags
+	}
+	// the index might be referenced by an alias, in which case the response is keyed by the concrete index name
+	if len(indices) == 1 {
+		for _, idx := range indices {
+			return idx.Mappings, diags
+		}
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "Unable to find the index mappings in the response",
+		Detail:   fmt.Sprintf(`Unable to find mappings of "%s" index in the ES API response.`, index),
+	})
+	return nil, diags
+}
+
+// GetAliases returns the aliases matching the names of the indices matching the index patterns, keyed by the index name.
+// The missing aliases and indices are ignored.
+func GetAliases(ctx context.Context, apiClient *clients.ApiClient, names []string, indices []string) (map[string]models.Index, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Indices.GetAlias(
+		apiClient.GetESClient().Indices.GetAlias.WithContext(ctx),
+		apiClient.GetESClient().Indices.GetAlias.WithName(names...),
+		apiClient.GetESClient().Indices.GetAlias.WithIndex(indices...),
+		apiClient.GetESClient().Indices.GetAlias.WithIgnoreUnavailable(true),
+		apiClient.GetESClient().Indices.GetAlias.WithAllowNoIndices(true),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the aliases: %s", strings.Join(names, ","))); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	// the missing aliases are reported with a 404 response, which still contains the aliases found together with the
+	// `error` and the `status` of the response
+	body := make(map[string]json.RawMessage)
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if res.StatusCode == http.StatusNotFound {
+		delete(body, "error")
+		delete(body, "status")
+	}
+	aliases := make(map[string]models.Index, len(body))
+	for indexName, v := range body {
+		var index models.Index
+		if err := json.Unmarshal(v, &index); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		aliases[indexName] = index
+	}
+	return aliases, nil
+}
+
+// ResolveIndices lists the indices matching the names, the missing indices are ignored.
+func ResolveIndices(ctx context.Context, apiClient *clients.ApiClient, names []string, expandWildcards string) ([]models.ResolvedIndex, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := []func(*esapi.IndicesResolveIndexRequest){
+		apiClient.GetESClient().Indices.ResolveIndex.WithContext(ctx),
+		apiClient.GetESClient().Indices.ResolveIndex.WithExpandWildcards(expandWildcards),
+	}
+	if apiClient.MinimizeResponses() {
+		opts = append(opts, apiClient.GetESClient().Indices.ResolveIndex.WithFilterPath("indices"))
+	}
+	res, err := apiClient.GetESClient().Indices.ResolveIndex(names, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return []models.ResolvedIndex{}, diags
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to resolve the indices: %s", strings.Join(names, ","))); diags.HasError() {
+		return nil, diags
+	}
+
+	var resolved models.ResolvedIndices
+	if err := json.NewDecoder(res.Body).Decode(&resolved); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return resolved.Indices, diags
+}
+
+// GetIndicesSettings returns the flat settings of the indices matching the names keyed by the index name, together with
+// the default values of the settings which are not set if requested. The missing indices are ignored.
+func GetIndicesSettings(ctx context.Context, apiClient *clients.ApiClient, names []string, expandWildcards string, includeDefaults bool) (map[string]models.IndexSettings, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Indices.GetSettings(
+		apiClient.GetESClient().Indices.GetSettings.WithContext(ctx),
+		apiClient.GetESClient().Indices.GetSettings.WithIndex(names...),
+		apiClient.GetESClient().Indices.GetSettings.WithExpandWildcards(expandWildcards),
+		apiClient.GetESClient().Indices.GetSettings.WithFlatSettings(true),
+		apiClient.GetESClient().Indices.GetSettings.WithIncludeDefaults(includeDefaults),
+		apiClient.GetESClient().Indices.GetSettings.WithIgnoreUnavailable(true),
+		apiClient.GetESClient().Indices.GetSettings.WithAllowNoIndices(true),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return map[string]models.IndexSettings{}, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the settings of the indices: %s", strings.Join(names, ","))); diags.HasError() {
+		return nil, diags
+	}
+
+	settings := make(map[string]models.IndexSettings)
+	if err := json.NewDecoder(res.Body).Decode(&settings); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return settings, nil
+}
+
 func PutDataStream(ctx context.Context, apiClient *clients.ApiClient, dataStreamName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -352,6 +739,10 @@ func PutDataStream(ctx context.Context, apiClient *clients.ApiClient, dataStream
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
+	if isAlreadyExists(apiClient, res) {
+		tflog.Warn(ctx, fmt.Sprintf(`Data stream "%s" has been created by a concurrent change, reconciling with it`, dataStreamName))
+		return reconcileDataStream(ctx, apiClient, dataStreamName)
+	}
 	if diags := utils.CheckError(res, fmt.Sprintf("Unable to create DataStream: %s", dataStreamName)); diags.HasError() {
 		return diags
 	}
@@ -383,6 +774,7 @@ func GetDataStream(ctx context.Context, apiClient *clients.ApiClient, dataStream
 	return &ds, diags
 }
 
+// DeleteDataStream deletes the data stream, the already deleted data stream is ignored.
 func DeleteDataStream(ctx context.Context, apiClient *clients.ApiClient, dataStreamName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -391,6 +783,9 @@ func DeleteDataStream(ctx context.Context, apiClient *clients.ApiClient, dataStr
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return diags
+	}
 	if diags := utils.CheckError(res, fmt.Sprintf("Unable to delete DataStream: %s", dataStreamName)); diags.HasError() {
 		return diags
 	}
@@ -455,3 +850,27 @@ func DeleteIngestPipeline(ctx context.Context, apiClient *clients.ApiClient, nam
 	}
 	return diags
 }
+
+// ExecuteEnrichPolicy starts the execution of the enrich policy and returns the identifier of the task running it.
+func ExecuteEnrichPolicy(ctx context.Context, apiClient *clients.ApiClient, name string) (string, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().EnrichExecutePolicy(
+		name,
+		apiClient.GetESClient().EnrichExecutePolicy.WithContext(ctx),
+		apiClient.GetESClient().EnrichExecutePolicy.WithWaitForCompletion(false),
+	)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to execute the enrich policy: %s", name)); diags.HasError() {
+		return "", diags
+	}
+
+	var executeResponse struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&executeResponse); err != nil {
+		return "", diag.FromErr(err)
+	}
+	return executeResponse.Task, nil
+}
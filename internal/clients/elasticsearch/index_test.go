@@ -0,0 +1,197 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGetIlmMinimizeResponses(t *testing.T) {
+	var filterPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+			return
+		}
+		filterPath = r.URL.Query().Get("filter_path")
+		fmt.Fprint(w, `{"test": {"modified_date": "2022-01-01T00:00:00.000Z", "policy": {"phases": {"hot": {"min_age": "0ms", "actions": {}}}}}}`)
+	}))
+	defer server.Close()
+
+	connectionSchema := map[string]*schema.Schema{
+		"elasticsearch_connection": providerSchema.GetConnectionSchema("elasticsearch_connection", false),
+	}
+	for minimize, expectedFilterPath := range map[bool]string{
+		false: "",
+		true:  "*.policy,*.modified_date",
+	} {
+		d := schema.TestResourceDataRaw(t, connectionSchema, map[string]interface{}{
+			"elasticsearch_connection": []interface{}{map[string]interface{}{
+				"endpoints":          []interface{}{server.URL},
+				"minimize_responses": minimize,
+			}},
+		})
+		apiClient, diags := clients.NewApiClient(d, &clients.ApiClient{})
+		if diags.HasError() {
+			t.Fatalf("unexpected error creating client: %v", diags)
+		}
+
+		policy, diags := GetIlm(context.Background(), apiClient, "test")
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if _, ok := policy.Policy.Phases["hot"]; !ok {
+			t.Errorf("expected the policy to be read, got %v", policy)
+		}
+		if filterPath != expectedFilterPath {
+			t.Errorf("expected filter_path %q with minimize_responses %t, got %q", expectedFilterPath, minimize, filterPath)
+		}
+	}
+}
+
+func TestDeleteIgnoresMissing(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+			return
+		}
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"type": "index_not_found_exception", "reason": "no such index [test]"}, "status": 404}`)
+	}))
+	defer server.Close()
+
+	connectionSchema := map[string]*schema.Schema{
+		"elasticsearch_connection": providerSchema.GetConnectionSchema("elasticsearch_connection", false),
+	}
+	d := schema.TestResourceDataRaw(t, connectionSchema, map[string]interface{}{
+		"elasticsearch_connection": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}},
+	})
+	apiClient, diags := clients.NewApiClient(d, &clients.ApiClient{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	ctx := context.Background()
+	if diags := DeleteIndex(ctx, apiClient, "test"); diags.HasError() {
+		t.Errorf("unexpected error deleting the missing index: %v", diags)
+	}
+	if query.Get("ignore_unavailable") != "true" || query.Get("allow_no_indices") != "true" {
+		t.Errorf("expected the index to be deleted with ignore_unavailable and allow_no_indices, got %v", query)
+	}
+	if diags := DeleteIndexAlias(ctx, apiClient, "test", []string{"alias"}); diags.HasError() {
+		t.Errorf("unexpected error deleting the missing alias: %v", diags)
+	}
+	if diags := DeleteDataStream(ctx, apiClient, "test"); diags.HasError() {
+		t.Errorf("unexpected error deleting the missing data stream: %v", diags)
+	}
+}
+
+func TestIlmTimeouts(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+			return
+		}
+		requests = append(requests, r)
+		fmt.Fprint(w, `{"acknowledged": true}`)
+	}))
+	defer server.Close()
+
+	resourceSchema := map[string]*schema.Schema{
+		"elasticsearch_connection": providerSchema.GetConnectionSchema("elasticsearch_connection", false),
+	}
+	utils.AddTimeoutsSchema(resourceSchema)
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"elasticsearch_connection": []interface{}{map[string]interface{}{
+			"endpoints":      []interface{}{server.URL},
+			"master_timeout": "1m",
+			"timeout":        "10s",
+		}},
+		// the timeout of the resource overrides the one of the connection
+		"master_timeout": "2m",
+	})
+	apiClient, diags := clients.NewApiClient(d, &clients.ApiClient{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	if diags := PutIlm(context.Background(), apiClient, &models.Policy{Name: "test", Phases: map[string]models.Phase{}}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := DeleteIlm(context.Background(), apiClient, "test"); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	for i, method := range []string{http.MethodPut, http.MethodDelete} {
+		r := requests[i]
+		if r.Method != method || r.URL.Path != "/_ilm/policy/test" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if masterTimeout := r.URL.Query().Get("master_timeout"); masterTimeout != "120000ms" {
+			t.Errorf("expected the master_timeout of the resource, got %q", masterTimeout)
+		}
+		if timeout := r.URL.Query().Get("timeout"); timeout != "10000ms" {
+			t.Errorf("expected the timeout of the connection, got %q", timeout)
+		}
+	}
+}
+
+func TestGetAliasesPartiallyMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": "alias [missing] missing", "status": 404, "test": {"aliases": {"found": {"is_write_index": true}}}}`)
+	}))
+	defer server.Close()
+
+	connectionSchema := map[string]*schema.Schema{
+		"elasticsearch_connection": providerSchema.GetConnectionSchema("elasticsearch_connection", false),
+	}
+	d := schema.TestResourceDataRaw(t, connectionSchema, map[string]interface{}{
+		"elasticsearch_connection": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}},
+	})
+	apiClient, diags := clients.NewApiClient(d, &clients.ApiClient{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	aliases, diags := GetAliases(context.Background(), apiClient, []string{"found", "missing"}, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(aliases) != 1 {
+		t.Fatalf("expected only the index with the found alias, got %v", aliases)
+	}
+	if _, ok := aliases["test"].Aliases["found"]; !ok {
+		t.Errorf("expected the found alias of the index, got %v", aliases["test"])
+	}
+}
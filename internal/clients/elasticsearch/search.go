@@ -0,0 +1,102 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func QuerySql(ctx context.Context, apiClient *clients.ApiClient, query *models.SqlQuery) (*models.SqlQueryResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().SQL.Query(bytes.NewReader(queryBytes), apiClient.GetESClient().SQL.Query.WithFormat("json"), apiClient.GetESClient().SQL.Query.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to run the SQL query"); diags.HasError() {
+		return nil, diags
+	}
+	var response models.SqlQueryResponse
+	decoder := json.NewDecoder(res.Body)
+	// keep the numbers as they are returned
+	decoder.UseNumber()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, diags
+}
+
+// QuerySqlText runs the SQL query returning the results in the given text format (csv, tsv or txt), and the cursor of the next page if any.
+func QuerySqlText(ctx context.Context, apiClient *clients.ApiClient, query *models.SqlQuery, format string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().SQL.Query(bytes.NewReader(queryBytes), apiClient.GetESClient().SQL.Query.WithFormat(format), apiClient.GetESClient().SQL.Query.WithContext(ctx))
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to run the SQL query"); diags.HasError() {
+		return "", "", diags
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+	return string(body), res.Header.Get("Cursor"), diags
+}
+
+func ClearSqlCursor(ctx context.Context, apiClient *clients.ApiClient, cursor string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	cursorBytes, err := json.Marshal(models.SqlQuery{Cursor: cursor})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().SQL.ClearCursor(bytes.NewReader(cursorBytes), apiClient.GetESClient().SQL.ClearCursor.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to clear the SQL cursor"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func Search(ctx context.Context, apiClient *clients.ApiClient, index string, search *models.SearchRequest) (*models.SearchResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	searchBytes, err := json.Marshal(search)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().Search(
+		apiClient.GetESClient().Search.WithContext(ctx),
+		apiClient.GetESClient().Search.WithIndex(index),
+		apiClient.GetESClient().Search.WithBody(bytes.NewReader(searchBytes)),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to search the index: %s", index)); diags.HasError() {
+		return nil, diags
+	}
+	var response models.SearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, diags
+}
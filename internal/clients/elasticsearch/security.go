@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -19,7 +22,9 @@ func PutUser(ctx context.Context, apiClient *clients.ApiClient, user *models.Use
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := apiClient.GetESClient().Security.PutUser(user.Username, bytes.NewReader(userBytes), apiClient.GetESClient().Security.PutUser.WithContext(ctx))
+	res, err := doReconcilingConflicts(ctx, apiClient, user.Username, func() (*esapi.Response, error) {
+		return apiClient.GetESClient().Security.PutUser(user.Username, bytes.NewReader(userBytes), apiClient.GetESClient().Security.PutUser.WithContext(ctx))
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -126,11 +131,13 @@ func ChangeUserPassword(ctx context.Context, apiClient *clients.ApiClient, usern
 func PutRole(ctx context.Context, apiClient *clients.ApiClient, role *models.Role) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	roleBytes, err := json.Marshal(role)
+	roleBytes, err := utils.MarshalJSON(role)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := apiClient.GetESClient().Security.PutRole(role.Name, bytes.NewReader(roleBytes), apiClient.GetESClient().Security.PutRole.WithContext(ctx))
+	res, err := doReconcilingConflicts(ctx, apiClient, role.Name, func() (*esapi.Response, error) {
+		return apiClient.GetESClient().Security.PutRole(role.Name, bytes.NewReader(roleBytes), apiClient.GetESClient().Security.PutRole.WithContext(ctx))
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -173,6 +180,60 @@ func GetRole(ctx context.Context, apiClient *clients.ApiClient, rolename string)
 	return nil, diags
 }
 
+// GetRoles returns all the roles of the cluster, including the reserved ones.
+func GetRoles(ctx context.Context, apiClient *clients.ApiClient) (map[string]models.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := apiClient.GetESClient().Security.GetRole(apiClient.GetESClient().Security.GetRole.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the roles."); diags.HasError() {
+		return nil, diags
+	}
+	roles := make(map[string]models.Role)
+	if err := json.NewDecoder(res.Body).Decode(&roles); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return roles, diags
+}
+
+// GetBuiltinRoles returns the names of the reserved roles of the cluster.
+func GetBuiltinRoles(ctx context.Context, apiClient *clients.ApiClient) ([]string, diag.Diagnostics) {
+	roles, diags := GetRoles(ctx, apiClient)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	names := make([]string, 0)
+	for name, role := range roles {
+		if role.IsReserved() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, diags
+}
+
+func GetBuiltinPrivileges(ctx context.Context, apiClient *clients.ApiClient) (*models.BuiltinPrivileges, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := apiClient.GetESClient().Security.GetBuiltinPrivileges(apiClient.GetESClient().Security.GetBuiltinPrivileges.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get the built-in privileges."); diags.HasError() {
+		return nil, diags
+	}
+	var privileges models.BuiltinPrivileges
+	if err := json.NewDecoder(res.Body).Decode(&privileges); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &privileges, diags
+}
+
 func DeleteRole(ctx context.Context, apiClient *clients.ApiClient, rolename string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	res, err := apiClient.GetESClient().Security.DeleteRole(rolename, apiClient.GetESClient().Security.DeleteRole.WithContext(ctx))
@@ -187,8 +248,73 @@ func DeleteRole(ctx context.Context, apiClient *clients.ApiClient, rolename stri
 	return diags
 }
 
+func PutApplicationPrivilege(ctx context.Context, apiClient *clients.ApiClient, privilege *models.ApplicationPrivilege) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	privileges := map[string]map[string]*models.ApplicationPrivilege{
+		privilege.Application: {privilege.Name: privilege},
+	}
+	privilegeBytes, err := json.Marshal(privileges)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().Security.PutPrivileges(bytes.NewReader(privilegeBytes), apiClient.GetESClient().Security.PutPrivileges.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create or update the application privilege"); diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+func GetApplicationPrivilege(ctx context.Context, apiClient *clients.ApiClient, application, name string) (*models.ApplicationPrivilege, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	req := apiClient.GetESClient().Security.GetPrivileges
+	res, err := req(req.WithApplication(application), req.WithName(name), req.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, "Unable to get the application privilege."); diags.HasError() {
+		return nil, diags
+	}
+	privileges := make(map[string]map[string]models.ApplicationPrivilege)
+	if err := json.NewDecoder(res.Body).Decode(&privileges); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// the API responds with an empty object when the privilege does not exist
+	if privilege, ok := privileges[application][name]; ok {
+		privilege.Application = application
+		privilege.Name = name
+		return &privilege, diags
+	}
+	return nil, nil
+}
+
+func DeleteApplicationPrivilege(ctx context.Context, apiClient *clients.ApiClient, application, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	res, err := apiClient.GetESClient().Security.DeletePrivileges(name, application, apiClient.GetESClient().Security.DeletePrivileges.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to delete the application privilege"); diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
 func PutRoleMapping(ctx context.Context, apiClient *clients.ApiClient, roleMapping *models.RoleMapping) diag.Diagnostics {
-	roleMappingBytes, err := json.Marshal(roleMapping)
+	roleMappingBytes, err := utils.MarshalJSON(roleMapping)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -231,6 +357,32 @@ func GetRoleMapping(ctx context.Context, apiClient *clients.ApiClient, roleMappi
 	return nil, diag.Errorf("unable to find role mapping '%s' in the cluster", roleMappingName)
 }
 
+// GetRoleMappings returns all the role mappings of the cluster keyed by the name.
+func GetRoleMappings(ctx context.Context, apiClient *clients.ApiClient) (map[string]models.RoleMapping, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Security.GetRoleMapping(apiClient.GetESClient().Security.GetRoleMapping.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+
+	roleMappings := make(map[string]models.RoleMapping)
+	// no role mappings defined in the cluster
+	if res.StatusCode == http.StatusNotFound {
+		return roleMappings, nil
+	}
+	if diags := utils.CheckError(res, "Unable to get the role mappings."); diags.HasError() {
+		return nil, diags
+	}
+	if err := json.NewDecoder(res.Body).Decode(&roleMappings); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for name, roleMapping := range roleMappings {
+		roleMapping.Name = name
+		roleMappings[name] = roleMapping
+	}
+	return roleMappings, nil
+}
+
 func DeleteRoleMapping(ctx context.Context, apiClient *clients.ApiClient, roleMappingName string) diag.Diagnostics {
 	res, err := apiClient.GetESClient().Security.DeleteRoleMapping(roleMappingName, apiClient.GetESClient().Security.DeleteRoleMapping.WithContext(ctx))
 	if err != nil {
@@ -269,6 +421,31 @@ func PutApiKey(apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.Api
 	return &apiKey, diags
 }
 
+// UpdateApiKey replaces the role descriptors and the metadata of the API key, the esapi client doesn't provide the endpoint yet.
+func UpdateApiKey(ctx context.Context, apiClient *clients.ApiClient, id string, apikey *models.ApiKeyUpdate) diag.Diagnostics {
+	apikeyBytes, err := json.Marshal(apikey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/_security/api_key/"+url.PathEscape(id), bytes.NewReader(apikeyBytes))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpRes, err := apiClient.GetESClient().Perform(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res := &esapi.Response{StatusCode: httpRes.StatusCode, Body: httpRes.Body, Header: httpRes.Header}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update the apikey"); diags.HasError() {
+		return diags
+	}
+
+	return nil
+}
+
 func GetApiKey(apiClient *clients.ApiClient, id string) (*models.ApiKeyResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	req := apiClient.GetESClient().Security.GetAPIKey.WithID(id)
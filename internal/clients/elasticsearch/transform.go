@@ -0,0 +1,36 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// PreviewTransform returns the documents the transform would produce and the generated mappings of its destination index.
+func PreviewTransform(ctx context.Context, apiClient *clients.ApiClient, transform *models.TransformPreviewRequest) (*models.TransformPreviewResponse, diag.Diagnostics) {
+	transformBytes, err := json.Marshal(transform)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := apiClient.GetESClient().TransformPreviewTransform(
+		apiClient.GetESClient().TransformPreviewTransform.WithContext(ctx),
+		apiClient.GetESClient().TransformPreviewTransform.WithBody(bytes.NewReader(transformBytes)),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to preview the transform"); diags.HasError() {
+		return nil, diags
+	}
+	var response models.TransformPreviewResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response, nil
+}
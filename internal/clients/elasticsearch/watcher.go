@@ -0,0 +1,98 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func GetWatchStatus(ctx context.Context, apiClient *clients.ApiClient, watchID string) (*models.WatchStatus, diag.Diagnostics) {
+	res, err := apiClient.GetESClient().Watcher.GetWatch(watchID, apiClient.GetESClient().Watcher.GetWatch.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get watch: %s", watchID)); diags.HasError() {
+		return nil, diags
+	}
+
+	var watchResponse struct {
+		Found  bool                `json:"found"`
+		Status *models.WatchStatus `json:"status"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&watchResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if !watchResponse.Found {
+		return nil, nil
+	}
+	return watchResponse.Status, nil
+}
+
+func AckWatch(ctx context.Context, apiClient *clients.ApiClient, watchID string, actionIDs []string) (*models.WatchStatus, diag.Diagnostics) {
+	opts := []func(*esapi.WatcherAckWatchRequest){
+		apiClient.GetESClient().Watcher.AckWatch.WithContext(ctx),
+	}
+	if len(actionIDs) > 0 {
+		opts = append(opts, apiClient.GetESClient().Watcher.AckWatch.WithActionID(actionIDs...))
+	}
+	res, err := apiClient.GetESClient().Watcher.AckWatch(watchID, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to acknowledge watch: %s", watchID)); diags.HasError() {
+		return nil, diags
+	}
+
+	var ackResponse struct {
+		Status *models.WatchStatus `json:"status"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&ackResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return ackResponse.Status, nil
+}
+
+// ExecuteWatch runs the stored watch, or the inline watch of the execution if the watch ID is empty, in the debug mode.
+func ExecuteWatch(ctx context.Context, apiClient *clients.ApiClient, watchID string, execution *models.WatchExecution) (*models.WatchRecord, diag.Diagnostics) {
+	executionBytes, err := json.Marshal(execution)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	opts := []func(*esapi.WatcherExecuteWatchRequest){
+		apiClient.GetESClient().Watcher.ExecuteWatch.WithContext(ctx),
+		apiClient.GetESClient().Watcher.ExecuteWatch.WithBody(bytes.NewReader(executionBytes)),
+		apiClient.GetESClient().Watcher.ExecuteWatch.WithDebug(true),
+	}
+	if watchID != "" {
+		opts = append(opts, apiClient.GetESClient().Watcher.ExecuteWatch.WithWatchID(watchID))
+	}
+	res, err := apiClient.GetESClient().Watcher.ExecuteWatch(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to execute the watch"); diags.HasError() {
+		return nil, diags
+	}
+
+	var executeResponse struct {
+		WatchRecord *models.WatchRecord `json:"watch_record"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&executeResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return executeResponse.WatchRecord, nil
+}
@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+var _ esapi.Transport = &limitedTransport{}
+
+// limitedTransport waits for the limiter of the connection before sending the requests.
+type limitedTransport struct {
+	transport esapi.Transport
+	limiter   *requestLimiter
+}
+
+func newLimitedTransport(transport esapi.Transport, limiter *requestLimiter) *limitedTransport {
+	return &limitedTransport{
+		transport: transport,
+		limiter:   limiter,
+	}
+}
+
+func (l *limitedTransport) Perform(r *http.Request) (*http.Response, error) {
+	release, err := l.limiter.wait(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return l.transport.Perform(r)
+}
+
+// requestLimiter caps the rate of the requests with a token bucket refilled every interval, and the number of the requests in flight.
+type requestLimiter struct {
+	interval time.Duration
+	slots    chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRequestLimiter(rateLimit float64, maxConcurrentRequests int) *requestLimiter {
+	l := &requestLimiter{}
+	if rateLimit > 0 {
+		l.interval = time.Duration(float64(time.Second) / rateLimit)
+	}
+	if maxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	return l
+}
+
+// wait blocks until the request can be sent, the returned function must be called once the request is completed.
+func (l *requestLimiter) wait(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		now := time.Now()
+		if l.next.Before(now) {
+			l.next = now
+		}
+		delay := l.next.Sub(now)
+		l.next = l.next.Add(l.interval)
+		l.mu.Unlock()
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
+
+var (
+	limitersMu sync.Mutex
+	// limiters holds the limiters shared by the clients created for the same connection, e.g. by the resources using the same `elasticsearch_connection`.
+	limiters = make(map[string]*requestLimiter)
+)
+
+// connectionLimiter returns the limiter shared by the clients of the connection, nil if the requests are not limited.
+func connectionLimiter(settings map[string]interface{}) *requestLimiter {
+	rateLimit, _ := settings["rate_limit"].(float64)
+	maxConcurrentRequests, _ := settings["max_concurrent_requests"].(int)
+	if rateLimit <= 0 && maxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	var endpoints []string
+	if v, ok := settings["endpoints"].([]interface{}); ok {
+		for _, e := range v {
+			endpoints = append(endpoints, e.(string))
+		}
+	}
+	sort.Strings(endpoints)
+	key := fmt.Sprintf("%s|%v|%d", strings.Join(endpoints, ","), rateLimit, maxConcurrentRequests)
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	if l, ok := limiters[key]; ok {
+		return l
+	}
+	l := newRequestLimiter(rateLimit, maxConcurrentRequests)
+	limiters[key] = l
+	return l
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiterMaxConcurrentRequests(t *testing.T) {
+	limiter := newRequestLimiter(0, 2)
+
+	var inFlight, maxInFlight int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.wait(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			defer release()
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestRequestLimiterRateLimit(t *testing.T) {
+	limiter := newRequestLimiter(50, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.wait(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		release()
+	}
+	// the first request is sent right away, the next ones every 20ms
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected the requests to be spread over at least 80ms, took %s", elapsed)
+	}
+}
+
+func TestRequestLimiterCancelledContext(t *testing.T) {
+	limiter := newRequestLimiter(0, 1)
+	release, err := limiter.wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.wait(ctx); err == nil {
+		t.Error("expected the wait to fail once the context is done")
+	}
+}
+
+func TestConnectionLimiterIsShared(t *testing.T) {
+	settings := map[string]interface{}{
+		"endpoints":               []interface{}{"http://b:9200", "http://a:9200"},
+		"max_concurrent_requests": 3,
+	}
+	if connectionLimiter(map[string]interface{}{}) != nil {
+		t.Error("expected no limiter when the requests are not limited")
+	}
+	first := connectionLimiter(settings)
+	if first == nil {
+		t.Fatal("expected a limiter")
+	}
+	second := connectionLimiter(map[string]interface{}{
+		"endpoints":               []interface{}{"http://a:9200", "http://b:9200"},
+		"max_concurrent_requests": 3,
+	})
+	if first != second {
+		t.Error("expected the clients of the same connection to share the limiter")
+	}
+	if other := connectionLimiter(map[string]interface{}{"endpoints": []interface{}{"http://c:9200"}, "max_concurrent_requests": 3}); other == first {
+		t.Error("expected another connection to have its own limiter")
+	}
+}
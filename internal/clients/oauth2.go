@@ -0,0 +1,177 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+var _ esapi.Transport = &oauth2Transport{}
+
+// oauth2Transport authenticates the requests with the bearer token acquired by the token source.
+type oauth2Transport struct {
+	transport esapi.Transport
+	source    *oauth2TokenSource
+}
+
+func newOAuth2Transport(transport esapi.Transport, source *oauth2TokenSource) *oauth2Transport {
+	return &oauth2Transport{
+		transport: transport,
+		source:    source,
+	}
+}
+
+func (o *oauth2Transport) Perform(r *http.Request) (*http.Response, error) {
+	token, err := o.source.token(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	res, err := o.transport.Perform(r)
+	if err == nil && res.StatusCode == http.StatusUnauthorized {
+		// the token may have been revoked before its expiry, the next request acquires a new one
+		o.source.invalidate(token)
+	}
+	return res, err
+}
+
+// oauth2TokenRefreshMargin is how long before its expiry the token is renewed, so it doesn't expire while a request is in flight.
+const oauth2TokenRefreshMargin = time.Minute
+
+// oauth2TokenSource acquires the tokens with the OAuth2 client credentials grant, and caches them until they expire.
+type oauth2TokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (s *oauth2TokenSource) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.accessToken != "" && (s.expiry.IsZero() || time.Now().Before(s.expiry)) {
+		return s.accessToken, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to acquire the OAuth2 token from %s: %w", s.tokenURL, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the OAuth2 token response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to acquire the OAuth2 token from %s: %s: %s", s.tokenURL, res.Status, body)
+	}
+
+	var tokenRes oauth2TokenResponse
+	if err := json.Unmarshal(body, &tokenRes); err != nil {
+		return "", fmt.Errorf("unable to parse the OAuth2 token response: %w", err)
+	}
+	if tokenRes.AccessToken == "" {
+		return "", fmt.Errorf("the OAuth2 token response from %s doesn't contain an access token", s.tokenURL)
+	}
+	if tokenRes.TokenType != "" && !strings.EqualFold(tokenRes.TokenType, "bearer") {
+		return "", fmt.Errorf(`unsupported OAuth2 token type "%s", only bearer tokens are supported`, tokenRes.TokenType)
+	}
+
+	s.accessToken = tokenRes.AccessToken
+	s.expiry = time.Time{}
+	if tokenRes.ExpiresIn > 0 {
+		lifetime := time.Duration(tokenRes.ExpiresIn) * time.Second
+		margin := oauth2TokenRefreshMargin
+		if margin > lifetime/2 {
+			margin = lifetime / 2
+		}
+		s.expiry = time.Now().Add(lifetime - margin)
+	}
+	return s.accessToken, nil
+}
+
+// invalidate drops the cached token, unless it has been renewed in the meantime.
+func (s *oauth2TokenSource) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.accessToken == token {
+		s.accessToken = ""
+	}
+}
+
+var (
+	tokenSourcesMu sync.Mutex
+	// tokenSources holds the token sources shared by the clients using the same OAuth2 client, so the token is acquired only once.
+	tokenSources = make(map[string]*oauth2TokenSource)
+)
+
+// connectionTokenSource returns the token source shared by the clients of the connection, nil if OAuth2 is not configured.
+func connectionTokenSource(settings map[string]interface{}, transport http.RoundTripper) *oauth2TokenSource {
+	v, ok := settings["oauth2"].([]interface{})
+	if !ok || len(v) == 0 || v[0] == nil {
+		return nil
+	}
+	oauth2 := v[0].(map[string]interface{})
+
+	var scopes []string
+	if s, ok := oauth2["scopes"].([]interface{}); ok {
+		for _, scope := range s {
+			scopes = append(scopes, scope.(string))
+		}
+	}
+	sort.Strings(scopes)
+	tokenURL, _ := oauth2["token_url"].(string)
+	clientID, _ := oauth2["client_id"].(string)
+	clientSecret, _ := oauth2["client_secret"].(string)
+	key := strings.Join([]string{tokenURL, clientID, clientSecret, strings.Join(scopes, " ")}, "|")
+
+	tokenSourcesMu.Lock()
+	defer tokenSourcesMu.Unlock()
+	if s, ok := tokenSources[key]; ok {
+		return s
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	s := &oauth2TokenSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		httpClient:   &http.Client{Transport: transport, Timeout: time.Minute},
+	}
+	tokenSources[key] = s
+	return s
+}
@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var issued int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse the token request: %v", err)
+		}
+		if grantType := r.PostForm.Get("grant_type"); grantType != "client_credentials" {
+			t.Errorf("expected the client_credentials grant, got %q", grantType)
+		}
+		if scope := r.PostForm.Get("scope"); scope != "read write" {
+			t.Errorf("expected the scopes to be requested, got %q", scope)
+		}
+		if id, secret, ok := r.BasicAuth(); !ok || id != "terraform" || secret != "secret" {
+			t.Errorf("expected the client credentials, got %q %q", id, secret)
+		}
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 1}`, n)
+	}))
+	defer tokenServer.Close()
+
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+	}))
+	defer server.Close()
+
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+		esConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+			"oauth2": []interface{}{map[string]interface{}{
+				"token_url":     tokenServer.URL,
+				"client_id":     "terraform",
+				"client_secret": "secret",
+				"scopes":        []interface{}{"write", "read"},
+			}},
+		}},
+	})
+	client, diags := NewApiClient(d, &ApiClient{version: "test"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+
+	es := client.GetESClient()
+	for i := 0; i < 3; i++ {
+		// the token expiring after a second is renewed after half of it
+		if i == 2 {
+			time.Sleep(600 * time.Millisecond)
+		}
+		res, err := es.Info()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+	}
+
+	// the first request is preceded by the product check of the client
+	expected := []string{"Bearer token-1", "Bearer token-1", "Bearer token-1", "Bearer token-2"}
+	if fmt.Sprint(authorizations) != fmt.Sprint(expected) {
+		t.Errorf("expected the authorizations %v, got %v", expected, authorizations)
+	}
+}
@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// VariantElasticsearch is the default `variant` of the provider.
+	VariantElasticsearch = "elasticsearch"
+	// VariantOpenSearch is the `variant` of the provider connecting to OpenSearch clusters.
+	VariantOpenSearch = "opensearch"
+)
+
+var _ esapi.Transport = &openSearchTransport{}
+
+// openSearchTransport marks the responses of OpenSearch as coming from Elasticsearch, as the client otherwise
+// refuses to talk to a cluster not identifying itself as Elasticsearch.
+type openSearchTransport struct {
+	transport esapi.Transport
+}
+
+func newOpenSearchTransport(transport esapi.Transport) *openSearchTransport {
+	return &openSearchTransport{
+		transport: transport,
+	}
+}
+
+func (o *openSearchTransport) Perform(r *http.Request) (*http.Response, error) {
+	res, err := o.transport.Perform(r)
+	if err != nil || res == nil {
+		return res, err
+	}
+	if res.Header == nil {
+		res.Header = http.Header{}
+	}
+	if res.Header.Get("X-Elastic-Product") == "" {
+		res.Header.Set("X-Elastic-Product", "Elasticsearch")
+	}
+	return res, err
+}
+
+// setVariant sets the variant of the cluster the client connects to.
+func (a *ApiClient) setVariant(variant string) {
+	a.variant = variant
+	if variant == VariantOpenSearch && a.es != nil {
+		a.es.Transport = newOpenSearchTransport(a.es.Transport)
+	}
+}
+
+// IsOpenSearch reports whether the provider is configured with the `opensearch` variant.
+func (a *ApiClient) IsOpenSearch() bool {
+	return a.variant == VariantOpenSearch
+}
+
+// EnforceNotOpenSearch returns an error diagnostic when the provider is configured with the `opensearch` variant.
+func (a *ApiClient) EnforceNotOpenSearch(resourceName string) diag.Diagnostics {
+	if a.IsOpenSearch() {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s is not supported on OpenSearch", resourceName),
+			Detail:   fmt.Sprintf("%s uses APIs specific to Elasticsearch, it can not be used with the `opensearch` variant of the provider.", resourceName),
+		}}
+	}
+	return nil
+}
+
+// NotSupportedOnOpenSearch makes the resource or the data source fail with a clear error when the provider is
+// configured with the `opensearch` variant, instead of failing with the error returned by the missing API.
+// The delete is left as is, so that the resource can still be removed. The note added to the description must also
+// be added to the docs templates.
+func NotSupportedOnOpenSearch(resourceName string, r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			// the variant is only set on the provider, the resource level connection doesn't change it
+			if client, ok := meta.(*ApiClient); ok && client != nil {
+				if diags := client.EnforceNotOpenSearch(resourceName); diags.HasError() {
+					return diags
+				}
+			}
+			return f(ctx, d, meta)
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.Description += " Not supported on OpenSearch."
+	return r
+}
@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOpenSearchVariant(t *testing.T) {
+	// OpenSearch does not send the product header expected by the Elasticsearch client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"cluster_uuid": "test", "version": {"distribution": "opensearch", "number": "2.11.0"}, "tagline": "The OpenSearch Project: https://opensearch.org/"}`)
+	}))
+	defer server.Close()
+
+	resourceSchemaMap := map[string]*schema.Schema{
+		esConnectionKey: providerSchema.GetConnectionSchema(esConnectionKey, false),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchemaMap, map[string]interface{}{
+		esConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}},
+	})
+
+	client, diags := NewApiClient(d, &ApiClient{version: "test"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+	if _, diags := client.ClusterID(context.Background()); !diags.HasError() {
+		t.Fatal("expected the elasticsearch variant to reject the cluster")
+	}
+
+	// the resource level connection keeps the variant of the provider
+	client, diags = NewApiClient(d, &ApiClient{version: "test", variant: VariantOpenSearch})
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+	clusterID, diags := client.ClusterID(context.Background())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if *clusterID != "test" {
+		t.Errorf("expected the cluster UUID, got %s", *clusterID)
+	}
+	serverVersion, diags := client.ServerVersion(context.Background())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if serverVersion != nil {
+		t.Errorf("expected the version check to be skipped, got %v", serverVersion)
+	}
+}
+
+func TestEnforceNotOpenSearch(t *testing.T) {
+	for _, tc := range []struct {
+		variant     string
+		expectError bool
+	}{
+		{variant: ""},
+		{variant: VariantElasticsearch},
+		{variant: VariantOpenSearch, expectError: true},
+	} {
+		t.Run(tc.variant, func(t *testing.T) {
+			client := &ApiClient{variant: tc.variant}
+
+			diags := client.EnforceNotOpenSearch("elasticstack_elasticsearch_security_role")
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %v, got %v", tc.expectError, diags)
+			}
+			if tc.expectError && !strings.Contains(diags[0].Summary, "not supported on OpenSearch") {
+				t.Errorf("unexpected error summary: %s", diags[0].Summary)
+			}
+		})
+	}
+}
+
+func TestNotSupportedOnOpenSearch(t *testing.T) {
+	for _, tc := range []struct {
+		variant     string
+		expectError bool
+	}{
+		{variant: VariantElasticsearch},
+		{variant: VariantOpenSearch, expectError: true},
+	} {
+		t.Run(tc.variant, func(t *testing.T) {
+			read := false
+			r := NotSupportedOnOpenSearch("elasticstack_elasticsearch_security_role", &schema.Resource{
+				ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+					read = true
+					return nil
+				},
+			})
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+
+			diags := r.ReadContext(context.Background(), d, &ApiClient{variant: tc.variant})
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %v, got %v", tc.expectError, diags)
+			}
+			if read == tc.expectError {
+				t.Errorf("expected the read to be called: %v", !tc.expectError)
+			}
+		})
+	}
+}
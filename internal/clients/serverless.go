@@ -0,0 +1,65 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverlessBuildFlavor is the build flavor reported by `GET /` for the Elasticsearch Serverless projects.
+const serverlessBuildFlavor = "serverless"
+
+// IsServerless reports whether the client is connected to an Elasticsearch Serverless project.
+func (a *ApiClient) IsServerless(ctx context.Context) (bool, diag.Diagnostics) {
+	info, diags := a.serverInfo(ctx)
+	if diags.HasError() {
+		return false, diags
+	}
+	return info.Version.BuildFlavor == serverlessBuildFlavor, nil
+}
+
+// EnforceNotServerless returns an error diagnostic when the client is connected to an Elasticsearch Serverless project.
+func (a *ApiClient) EnforceNotServerless(ctx context.Context, resourceName string) diag.Diagnostics {
+	serverless, diags := a.IsServerless(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if serverless {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s is not supported on Elasticsearch Serverless", resourceName),
+			Detail:   fmt.Sprintf("The APIs used by %s are not available in the Elasticsearch Serverless projects, use it only with the self-managed or Elastic Cloud deployments.", resourceName),
+		}}
+	}
+	return nil
+}
+
+// NotSupportedOnServerless makes the resource or the data source fail with a clear error when used with an
+// Elasticsearch Serverless project, instead of failing with the error returned by the unavailable API.
+// The delete is left as is, so that the resource can still be removed. None of the wrapped APIs has an equivalent
+// endpoint on Serverless, e.g. the ILM policies are replaced by the data stream lifecycle with a different model, so
+// the requests are not routed elsewhere. The note added to the description must also be added to the docs templates.
+func NotSupportedOnServerless(resourceName string, r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			client, diags := NewApiClient(d, meta)
+			if diags.HasError() {
+				return diags
+			}
+			if diags := client.EnforceNotServerless(ctx, resourceName); diags.HasError() {
+				return diags
+			}
+			return f(ctx, d, meta)
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.Description += " Not supported on Elasticsearch Serverless."
+	return r
+}
@@ -0,0 +1,34 @@
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+)
+
+func TestEnforceNotServerless(t *testing.T) {
+	for _, tc := range []struct {
+		flavor      string
+		expectError bool
+	}{
+		{flavor: "default"},
+		{flavor: "oss"},
+		{flavor: "serverless", expectError: true},
+	} {
+		t.Run(tc.flavor, func(t *testing.T) {
+			info := &models.ClusterInfo{}
+			info.Version.BuildFlavor = tc.flavor
+			client := &ApiClient{elasticsearchClusterInfo: info}
+
+			diags := client.EnforceNotServerless(context.Background(), "elasticstack_elasticsearch_cluster_settings")
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %v, got %v", tc.expectError, diags)
+			}
+			if tc.expectError && !strings.Contains(diags[0].Summary, "not supported on Elasticsearch Serverless") {
+				t.Errorf("unexpected error summary: %s", diags[0].Summary)
+			}
+		})
+	}
+}
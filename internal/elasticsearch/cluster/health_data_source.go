@@ -0,0 +1,179 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceClusterHealth() *schema.Resource {
+	healthSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"indices": {
+			Description: "Limits the information returned to the specific data streams, indices or aliases. Supports wildcards.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"wait_for_status": {
+			Description:  "Waits until the cluster health reaches the given status or better.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "red"}, false),
+		},
+		"wait_for_nodes": {
+			Description: "Waits until the specified number of nodes is available. Also accepts `>=N`, `<=N`, `>N` and `<N`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"wait_for_active_shards": {
+			Description: "Waits until the specified number of shards is active, or `all` to wait for all the shards.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"timeout": {
+			Description:  "Period to wait for the wait conditions to be met. The data source fails, if the conditions are not met within the timeout.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "30s",
+			ValidateFunc: utils.StringIsDuration,
+		},
+		"cluster_name": {
+			Description: "Name of the cluster.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "Health status of the cluster, based on the state of its primary and replica shards.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"number_of_nodes": {
+			Description: "Number of nodes within the cluster.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"number_of_data_nodes": {
+			Description: "Number of nodes that are dedicated data nodes.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"active_primary_shards": {
+			Description: "Number of active primary shards.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"active_shards": {
+			Description: "Total number of active primary and replica shards.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"relocating_shards": {
+			Description: "Number of shards that are under relocation.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"initializing_shards": {
+			Description: "Number of shards that are under initialization.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"unassigned_shards": {
+			Description: "Number of shards that are not allocated.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"active_shards_percent": {
+			Description: "Ratio of active shards in the cluster expressed as a percentage.",
+			Type:        schema.TypeFloat,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(healthSchema)
+
+	return &schema.Resource{
+		Description: "Gets the health status of the cluster, optionally waiting for the given conditions to be met. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-health.html",
+
+		ReadContext: dataSourceClusterHealthRead,
+
+		Schema: healthSchema,
+	}
+}
+
+func dataSourceClusterHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	clusterId, diags := client.ClusterID(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	timeout, err := time.ParseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := models.ClusterHealthParams{
+		WaitForStatus:       d.Get("wait_for_status").(string),
+		WaitForNodes:        d.Get("wait_for_nodes").(string),
+		WaitForActiveShards: d.Get("wait_for_active_shards").(string),
+		Timeout:             timeout,
+	}
+	for _, i := range d.Get("indices").([]interface{}) {
+		params.Indices = append(params.Indices, i.(string))
+	}
+
+	health, diags := elasticsearch.GetClusterHealth(ctx, client, &params)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("cluster_name", health.ClusterName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", health.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("number_of_nodes", health.NumberOfNodes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("number_of_data_nodes", health.NumberOfDataNodes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("active_primary_shards", health.ActivePrimaryShards); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("active_shards", health.ActiveShards); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("relocating_shards", health.RelocatingShards); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("initializing_shards", health.InitializingShards); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("unassigned_shards", health.UnassignedShards); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("active_shards_percent", health.ActiveShardsPercentAsNumber); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return diags
+}
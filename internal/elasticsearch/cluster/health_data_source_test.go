@@ -0,0 +1,54 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceClusterHealth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceClusterHealth,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_cluster_health.test", "cluster_name"),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_cluster_health.test", "status", regexp.MustCompile(`^(green|yellow)$`)),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_cluster_health.test", "number_of_nodes"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_cluster_health.test", "active_shards_percent"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_cluster_health.test", "unassigned_shards"),
+				),
+			},
+			{
+				Config:      testAccDataSourceClusterHealthTimeout,
+				ExpectError: regexp.MustCompile("Timed out waiting for the cluster health"),
+			},
+		},
+	})
+}
+
+const testAccDataSourceClusterHealth = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_cluster_health" "test" {
+  wait_for_status = "yellow"
+  timeout         = "10s"
+}
+`
+
+const testAccDataSourceClusterHealthTimeout = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_cluster_health" "test" {
+  wait_for_nodes = ">=100"
+  timeout        = "1s"
+}
+`
@@ -0,0 +1,229 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nodeRoles maps the abbreviations used by the cat nodes API to the names of the node roles.
+var nodeRoles = map[rune]string{
+	'c': "data_cold",
+	'd': "data",
+	'f': "data_frozen",
+	'h': "data_hot",
+	'i': "ingest",
+	'l': "ml",
+	'm': "master",
+	'r': "remote_cluster_client",
+	's': "data_content",
+	't': "transform",
+	'v': "voting_only",
+	'w': "data_warm",
+}
+
+func DataSourceNodes() *schema.Resource {
+	nodesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"role": {
+			Description:  "Returns only the nodes having the given role, e.g. `data_hot` or `master`. Use `coordinating_only` to return the nodes without any role.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(append(roleNames(), "coordinating_only"), false),
+		},
+		"nodes": {
+			Description: "The list of the nodes in the cluster, sorted by the node name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "Unique identifier of the node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"name": {
+						Description: "Name of the node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"ip": {
+						Description: "IP address of the node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"roles": {
+						Description: "Roles of the node.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"master": {
+						Description: "Whether the node is the elected master node.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"version": {
+						Description: "Elasticsearch version of the node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"heap_used_in_bytes": {
+						Description: "Used heap memory in bytes.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"heap_max_in_bytes": {
+						Description: "Maximum heap memory in bytes.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"heap_used_percent": {
+						Description: "Used heap memory in percent.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"disk_used_in_bytes": {
+						Description: "Used disk space in bytes.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"disk_total_in_bytes": {
+						Description: "Total disk space in bytes.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"disk_used_percent": {
+						Description: "Used disk space in percent.",
+						Type:        schema.TypeFloat,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(nodesSchema)
+
+	return &schema.Resource{
+		Description: "Gets the nodes of the cluster with their roles, heap and disk usage. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-nodes.html",
+		ReadContext: dataSourceNodesRead,
+		Schema:      nodesSchema,
+	}
+}
+
+func dataSourceNodesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	clusterId, diags := client.ClusterID(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	catNodes, diags := elasticsearch.GetNodes(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Slice(catNodes, func(i, j int) bool { return catNodes[i].Name < catNodes[j].Name })
+
+	role := d.Get("role").(string)
+	nodes := make([]interface{}, 0, len(catNodes))
+	for _, n := range catNodes {
+		node, err := flattenNode(n)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if role != "" && !hasRole(node["roles"].([]string), role) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	if err := d.Set("nodes", nodes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return diags
+}
+
+func flattenNode(n models.CatNode) (map[string]interface{}, error) {
+	roles := make([]string, 0)
+	for _, r := range n.NodeRole {
+		if name, ok := nodeRoles[r]; ok {
+			roles = append(roles, name)
+		}
+	}
+	sort.Strings(roles)
+
+	node := map[string]interface{}{
+		"id":      n.Id,
+		"name":    n.Name,
+		"ip":      n.Ip,
+		"roles":   roles,
+		"master":  n.Master == "*",
+		"version": n.Version,
+	}
+	for key, value := range map[string]string{
+		"heap_used_in_bytes":  n.HeapCurrent,
+		"heap_max_in_bytes":   n.HeapMax,
+		"heap_used_percent":   n.HeapPercent,
+		"disk_used_in_bytes":  n.DiskUsed,
+		"disk_total_in_bytes": n.DiskTotal,
+	} {
+		// the disk stats are missing for the nodes without the data paths
+		if value == "" {
+			continue
+		}
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf(`unable to parse "%s" of the node "%s": %w`, key, n.Name, err)
+		}
+		node[key] = v
+	}
+	if n.DiskUsedPercent != "" {
+		v, err := strconv.ParseFloat(n.DiskUsedPercent, 64)
+		if err != nil {
+			return nil, fmt.Errorf(`unable to parse "disk_used_percent" of the node "%s": %w`, n.Name, err)
+		}
+		node["disk_used_percent"] = v
+	}
+	return node, nil
+}
+
+func hasRole(roles []string, role string) bool {
+	if role == "coordinating_only" {
+		return len(roles) == 0
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func roleNames() []string {
+	names := make([]string, 0, len(nodeRoles))
+	for _, name := range nodeRoles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,41 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNodes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNodes,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_nodes.all", "nodes.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_nodes.all", "nodes.0.name"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_nodes.all", "nodes.0.version"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_nodes.all", "nodes.0.heap_max_in_bytes"),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_nodes.master", "nodes.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_nodes.master", "nodes.0.roles.*", "master"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceNodes = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_nodes" "all" {}
+
+data "elasticstack_elasticsearch_nodes" "master" {
+  role = "master"
+}
+`
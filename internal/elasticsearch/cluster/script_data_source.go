@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceScript() *schema.Resource {
+	scriptSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"script_id": {
+			Description: "Identifier of the stored script.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"lang": {
+			Description: "Script language.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"source": {
+			Description: "The script, or the search template for the `mustache` scripts.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(scriptSchema)
+
+	return &schema.Resource{
+		Description: "Gets a stored script or search template, e.g. to check that a script managed elsewhere exists before referencing it. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/get-stored-script-api.html",
+		ReadContext: dataSourceScriptRead,
+		Schema:      scriptSchema,
+	}
+}
+
+func dataSourceScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	scriptId := d.Get("script_id").(string)
+	id, diags := client.ID(ctx, scriptId)
+	if diags.HasError() {
+		return diags
+	}
+
+	script, diags := elasticsearch.GetScript(ctx, client, scriptId)
+	if script == nil && diags == nil {
+		return diag.Errorf(`Script "%s" not found`, scriptId)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("lang", script.Language); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("source", script.Source); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
@@ -0,0 +1,64 @@
+package cluster_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceScript(t *testing.T) {
+	scriptID := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceScript(scriptID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_script.test", "script_id", scriptID),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_script.test", "lang", "painless"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_script.test", "source", "Math.log(_score * 2) + params['my_modifier']"),
+				),
+			},
+			{
+				Config:      testAccDataSourceScriptNotFound(scriptID),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Script "%s-missing" not found`, scriptID)),
+			},
+		},
+	})
+}
+
+func testAccDataSourceScript(scriptID string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_script" "test" {
+  script_id = "%s"
+  lang      = "painless"
+  source    = "Math.log(_score * 2) + params['my_modifier']"
+}
+
+data "elasticstack_elasticsearch_script" "test" {
+  script_id = elasticstack_elasticsearch_script.test.script_id
+}
+	`, scriptID)
+}
+
+func testAccDataSourceScriptNotFound(scriptID string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_script" "test" {
+  script_id = "%s-missing"
+}
+	`, scriptID)
+}
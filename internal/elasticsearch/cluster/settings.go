@@ -68,6 +68,7 @@ func ResourceSettings() *schema.Resource {
 	}
 
 	utils.AddConnectionSchema(settingsSchema)
+	utils.AddTimeoutsSchema(settingsSchema)
 
 	return &schema.Resource{
 		Description: "Updates cluster-wide settings. If the Elasticsearch security features are enabled, you must have the manage cluster privilege to use this API. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-update-settings.html",
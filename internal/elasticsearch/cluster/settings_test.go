@@ -65,6 +65,18 @@ func TestAccResourceClusterSettings(t *testing.T) {
 					resource.TestCheckNoResourceAttr("elasticstack_elasticsearch_cluster_settings.test", "transient.#"),
 				),
 			},
+			{
+				Config: testAccResourceClusterSettingsTimeouts(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_cluster_settings.test", "persistent.0.setting.*",
+						map[string]string{
+							"name":  "indices.lifecycle.poll_interval",
+							"value": "15m",
+						}),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cluster_settings.test", "master_timeout", "2m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_cluster_settings.test", "timeout", "1m"),
+				),
+			},
 		},
 	})
 }
@@ -130,6 +142,26 @@ resource "elasticstack_elasticsearch_cluster_settings" "test" {
 `
 }
 
+func testAccResourceClusterSettingsTimeouts() string {
+	return `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_cluster_settings" "test" {
+  persistent {
+    setting {
+      name  = "indices.lifecycle.poll_interval"
+      value = "15m"
+    }
+  }
+
+  master_timeout = "2m"
+  timeout        = "1m"
+}
+`
+}
+
 func checkResourceClusterSettingsDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
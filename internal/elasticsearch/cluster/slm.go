@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
@@ -115,9 +116,11 @@ func ResourceSlm() *schema.Resource {
 			Required:    true,
 		},
 		"expire_after": {
-			Description: "Time period after which a snapshot is considered expired and eligible for deletion.",
-			Type:        schema.TypeString,
-			Optional:    true,
+			Description:      "Time period after which a snapshot is considered expired and eligible for deletion.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     utils.StringIsTimeValue,
+			DiffSuppressFunc: utils.DiffTimeValueSuppress,
 		},
 		"max_count": {
 			Description: "Maximum number of snapshots to retain, even if the snapshots have not yet expired.",
@@ -150,6 +153,11 @@ func ResourceSlm() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		// the operations are retried while another snapshot is running
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: slmSchema,
 	}
 }
@@ -0,0 +1,250 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const snapshotIdSeparator = ":"
+
+func ResourceSnapshot() *schema.Resource {
+	snapshotSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"repository": {
+			Description: "Name of the snapshot repository to store the snapshot in.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"snapshot": {
+			Description: "Name of the snapshot. Must be unique in the repository.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"indices": {
+			Description: "Data streams and indices to include in the snapshot. Supports wildcards. All data streams and indices are included when not set.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"include_global_state": {
+			Description: "If true, the current cluster state is included in the snapshot.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			ForceNew:    true,
+		},
+		"partial": {
+			Description: "If false, the entire snapshot fails if one or more indices included in the snapshot do not have all primary shards available.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+		},
+		"wait_for_completion": {
+			Description: "If true, waits until the snapshot is completed on create.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			ForceNew:    true,
+		},
+		"uuid": {
+			Description: "Unique identifier of the snapshot.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"state": {
+			Description: "State of the snapshot, e.g. `IN_PROGRESS`, `SUCCESS` or `PARTIAL`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"start_time_in_millis": {
+			Description: "Time the snapshot was started, in milliseconds since the epoch.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"end_time_in_millis": {
+			Description: "Time the snapshot was completed, in milliseconds since the epoch.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"duration_in_millis": {
+			Description: "How long it took to take the snapshot, in milliseconds.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"shards_total": {
+			Description: "Total number of the shards included in the snapshot.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"shards_successful": {
+			Description: "Number of the shards successfully stored in the snapshot.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"shards_failed": {
+			Description: "Number of the shards which failed to be stored in the snapshot.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(snapshotSchema)
+
+	return &schema.Resource{
+		Description: "Takes a snapshot of the cluster or of the given data streams and indices. Changing any of the arguments takes a new snapshot, destroying the resource deletes the snapshot. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/create-snapshot-api.html",
+
+		CreateContext: resourceSnapshotCreate,
+		// only the connection can be updated, all the other arguments force a new snapshot
+		UpdateContext: resourceSnapshotRead,
+		ReadContext:   resourceSnapshotRead,
+		DeleteContext: resourceSnapshotDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: snapshotSchema,
+	}
+}
+
+func snapshotFromId(id string) (string, string, diag.Diagnostics) {
+	compId, diags := clients.CompositeIdFromStr(id)
+	if diags.HasError() {
+		return "", "", diags
+	}
+	repository, name, ok := strings.Cut(compId.ResourceId, snapshotIdSeparator)
+	if !ok {
+		return "", "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Wrong resource ID.",
+			Detail:   "Resource ID must have following format: <cluster_uuid>/<repository>:<snapshot name>",
+		}}
+	}
+	return repository, name, nil
+}
+
+func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	repository := d.Get("repository").(string)
+	name := d.Get("snapshot").(string)
+	id, diags := client.ID(ctx, repository+snapshotIdSeparator+name)
+	if diags.HasError() {
+		return diags
+	}
+
+	includeGlobalState := d.Get("include_global_state").(bool)
+	partial := d.Get("partial").(bool)
+	snapshot := models.Snapshot{
+		IncludeGlobalState: &includeGlobalState,
+		Partial:            &partial,
+	}
+	if v, ok := d.GetOk("indices"); ok {
+		for _, index := range v.([]interface{}) {
+			snapshot.Indices = append(snapshot.Indices, index.(string))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	info, diags := elasticsearch.CreateSnapshot(ctx, client, repository, name, &snapshot, d.Get("wait_for_completion").(bool))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	if info != nil && info.State == "FAILED" {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(`Snapshot "%s" failed`, name),
+			Detail:   snapshotFailures(info),
+		}}
+	}
+	return resourceSnapshotRead(ctx, d, meta)
+}
+
+func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	repository, name, diags := snapshotFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	info, diags := elasticsearch.GetSnapshot(ctx, client, repository, name)
+	if info == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Snapshot "%s" not found in the repository "%s", removing from state`, name, repository))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	// the indices are not read back, the configured wildcards are resolved in the snapshot
+	for key, value := range map[string]interface{}{
+		"repository":           repository,
+		"snapshot":             info.Snapshot,
+		"include_global_state": info.IncludeGlobalState,
+		"uuid":                 info.Uuid,
+		"state":                info.State,
+		"start_time_in_millis": info.StartTimeInMillis,
+		"end_time_in_millis":   info.EndTimeInMillis,
+		"duration_in_millis":   info.DurationInMillis,
+		"shards_total":         info.Shards.Total,
+		"shards_successful":    info.Shards.Successful,
+		"shards_failed":        info.Shards.Failed,
+	} {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
+
+func resourceSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	repository, name, diags := snapshotFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	return elasticsearch.DeleteSnapshot(ctx, client, repository, name)
+}
+
+func snapshotFailures(info *models.SnapshotInfo) string {
+	if len(info.Failures) == 0 {
+		return "The snapshot failed without reporting any shard failures."
+	}
+	failures := make([]string, len(info.Failures))
+	for i, f := range info.Failures {
+		failures[i] = fmt.Sprintf("[%s][%d]: %s", f.Index, f.ShardId, f.Reason)
+	}
+	return strings.Join(failures, "\n")
+}
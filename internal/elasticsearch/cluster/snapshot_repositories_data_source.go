@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceSnapshotRepositories() *schema.Resource {
+	reposSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"repositories": {
+			Description: "All the registered snapshot repositories, sorted by the name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the snapshot repository.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": {
+						Description: "Repository type.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"settings": {
+						Description: "JSON object with the settings of the repository. The values of the settings holding credentials are masked.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(reposSchema)
+
+	return &schema.Resource{
+		Description: "Retrieves all the registered snapshot repositories, e.g. to check that the repository of a snapshot lifecycle policy is registered outside of Terraform. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/get-snapshot-repo-api.html",
+		ReadContext: dataSourceSnapReposRead,
+		Schema:      reposSchema,
+	}
+}
+
+func dataSourceSnapReposRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	clusterId, diags := client.ClusterID(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	repositories, diags := elasticsearch.GetSnapshotRepositories(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	names := make([]string, 0, len(repositories))
+	for name := range repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]interface{}, len(names))
+	for i, name := range names {
+		settings, err := json.Marshal(maskRepoSettings(repositories[name].Settings))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result[i] = map[string]interface{}{
+			"name":     name,
+			"type":     repositories[name].Type,
+			"settings": string(settings),
+		}
+	}
+	if err := d.Set("repositories", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return diags
+}
@@ -0,0 +1,53 @@
+package cluster_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSnapRepos(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSnapRepos(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchTypeSetElemNestedAttrs("data.elasticstack_elasticsearch_snapshot_repositories.all", "repositories.*", map[string]*regexp.Regexp{
+						"name":     regexp.MustCompile(fmt.Sprintf("^%s$", name)),
+						"type":     regexp.MustCompile("^fs$"),
+						"settings": regexp.MustCompile(`"location":"/tmp"`),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSnapRepos(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_snapshot_repository" "test_fs_repo" {
+  name = "%s"
+
+  fs {
+    location = "/tmp"
+    compress = true
+  }
+}
+
+data "elasticstack_elasticsearch_snapshot_repositories" "all" {
+  depends_on = [elasticstack_elasticsearch_snapshot_repository.test_fs_repo]
+}
+	`, name)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
@@ -311,6 +312,11 @@ func ResourceSnapshotRepository() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		// the operations are retried while another snapshot is running
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: snapRepoSchema,
 	}
 }
@@ -2,7 +2,9 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
@@ -191,6 +193,11 @@ func DataSourceSnapshotRespository() *schema.Resource {
 			Type:        schema.TypeString,
 			Computed:    true,
 		},
+		"settings": {
+			Description: "JSON object with all the settings of the repository, including the settings without a typed attribute. The values of the settings holding credentials are masked.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
 		"fs": {
 			Description: "Shared filesystem repository. Set only if the type of the fetched repo is `fs`.",
 			Type:        schema.TypeList,
@@ -263,29 +270,61 @@ func dataSourceSnapRepoRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diags
 	}
 	currentRepo, diags := elasticsearch.GetSnapshotRepository(ctx, client, repoName)
+	if currentRepo == nil && diags == nil {
+		return diag.Errorf(`Snapshot repository "%s" not found`, repoName)
+	}
 	if diags.HasError() {
 		return diags
 	}
 
-	// get the schema of the Elem of the current repo type
-	schemaSettings := DataSourceSnapshotRespository().Schema[currentRepo.Type].Elem.(*schema.Resource).Schema
-	settings, err := flattenRepoSettings(currentRepo, schemaSettings)
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to parse snapshot repository settings.",
-			Detail:   fmt.Sprintf(`Unable to parse settings returned by ES API: %v`, err),
-		})
-		return diags
-	}
-	if err := d.Set(currentRepo.Type, settings); err != nil {
-		return diag.FromErr(err)
+	// get the schema of the Elem of the current repo type, the other types, e.g. `source`, are only read into `settings`
+	if typeSchema, ok := DataSourceSnapshotRespository().Schema[currentRepo.Type]; ok && typeSchema.Type == schema.TypeList {
+		schemaSettings := typeSchema.Elem.(*schema.Resource).Schema
+		settings, err := flattenRepoSettings(currentRepo, schemaSettings)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to parse snapshot repository settings.",
+				Detail:   fmt.Sprintf(`Unable to parse settings returned by ES API: %v`, err),
+			})
+			return diags
+		}
+		if err := d.Set(currentRepo.Type, settings); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if err := d.Set("type", currentRepo.Type); err != nil {
 		return diag.FromErr(err)
 	}
+	settings, err := json.Marshal(maskRepoSettings(currentRepo.Settings))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("settings", string(settings)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(id.String())
 	return diags
 }
+
+// maskedSettingValue replaces the value of the repository settings holding credentials.
+const maskedSettingValue = "********"
+
+// maskRepoSettings returns the repository settings with the values of the settings holding credentials masked,
+// e.g. the insecure `access_key` and `secret_key` of the S3 repositories.
+func maskRepoSettings(settings map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		masked[k] = v
+		key := strings.ToLower(k)
+		for _, secret := range []string{"secret", "password", "access_key", "token", "credentials"} {
+			if strings.Contains(key, secret) {
+				masked[k] = maskedSettingValue
+				break
+			}
+		}
+	}
+	return masked
+}
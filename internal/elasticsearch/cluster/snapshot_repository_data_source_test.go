@@ -2,6 +2,7 @@ package cluster_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -26,12 +27,36 @@ func TestAccDataSourceSnapRepoFs(t *testing.T) {
 					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_snapshot_repository.test_fs_repo", "fs.0.location", "/tmp"),
 					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_snapshot_repository.test_fs_repo", "fs.0.compress", "true"),
 					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_snapshot_repository.test_fs_repo", "fs.0.max_restore_bytes_per_sec", "10mb"),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_snapshot_repository.test_fs_repo", "settings", regexp.MustCompile(`"location":"/tmp"`)),
 				),
 			},
 		},
 	})
 }
 
+func TestAccDataSourceSnapRepoNotFound(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_snapshot_repository" "missing" {
+  name = "%s"
+}
+	`, name),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Snapshot repository "%s" not found`, name)),
+			},
+		},
+	})
+}
+
 func testAccDataSourceSnapRepoFs(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -0,0 +1,88 @@
+package cluster_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSnapshot(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkSnapshotDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotCreate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_snapshot.test", "snapshot", name),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_snapshot.test", "state", "SUCCESS"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_snapshot.test", "include_global_state", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_snapshot.test", "shards_failed", "0"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_snapshot.test", "uuid"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_snapshot.test", "shards_total"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSnapshotCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_snapshot_repository" "test" {
+  name = "%[1]s"
+
+  fs {
+    location = "/tmp"
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%[1]s"
+}
+
+resource "elasticstack_elasticsearch_snapshot" "test" {
+  repository           = elasticstack_elasticsearch_snapshot_repository.test.name
+  snapshot             = "%[1]s"
+  indices              = [elasticstack_elasticsearch_index.test.name]
+  include_global_state = false
+}
+	`, name)
+}
+
+func checkSnapshotDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_snapshot" {
+			continue
+		}
+
+		repository, snapshot := rs.Primary.Attributes["repository"], rs.Primary.Attributes["snapshot"]
+		res, err := client.GetESClient().Snapshot.Get(repository, []string{snapshot})
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		// the repository may already be deleted as well
+		if res.StatusCode != 404 {
+			return fmt.Errorf("Snapshot (%s) still exists", snapshot)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceTaskWait() *schema.Resource {
+	taskWaitSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"task_id": {
+			Description: "Identifier of the task to wait for, in the `<node>:<id>` format, e.g. the `task_id` of a resource created with `wait_for_completion = false`.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"completed": {
+			Description: "Whether the task is completed.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"response": {
+			Description: "JSON object with the response of the completed task, e.g. the number of the reindexed documents.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(taskWaitSchema)
+
+	return &schema.Resource{
+		Description: "Waits for an Elasticsearch task to complete, and fails if the task failed. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/tasks.html",
+
+		CreateContext: resourceTaskWaitCreate,
+		// only the connection can be updated, the task is never awaited again
+		UpdateContext: resourceTaskWaitRead,
+		ReadContext:   resourceTaskWaitRead,
+		DeleteContext: resourceTaskWaitDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: taskWaitSchema,
+	}
+}
+
+func resourceTaskWaitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	taskId := d.Get("task_id").(string)
+	id, diags := client.ID(ctx, taskId)
+	if diags.HasError() {
+		return diags
+	}
+
+	task, diags := elasticsearch.WaitForTask(ctx, client, taskId)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := checkTaskFailures(taskId, task); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceTaskWaitRead(ctx, d, meta)
+}
+
+func resourceTaskWaitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	taskId := compId.ResourceId
+
+	task, diags := elasticsearch.GetTask(ctx, client, taskId)
+	if diags.HasError() {
+		return diags
+	}
+	// the results of the completed tasks are removed from the .tasks index eventually, which doesn't undo the wait
+	if task == nil {
+		tflog.Debug(ctx, fmt.Sprintf(`Task "%s" not found, keeping the last known state`, taskId))
+		return diags
+	}
+
+	if err := d.Set("task_id", taskId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("completed", task.Completed); err != nil {
+		return diag.FromErr(err)
+	}
+	response := ""
+	if task.Response != nil {
+		r, err := json.Marshal(task.Response)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		response = string(r)
+	}
+	if err := d.Set("response", response); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceTaskWaitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, fmt.Sprintf(`Removing the wait for the task "%s" from the state`, d.Get("task_id").(string)))
+	return nil
+}
+
+// checkTaskFailures returns an error if the task failed, or some of the documents it processed failed.
+func checkTaskFailures(taskId string, task *models.Task) diag.Diagnostics {
+	if task.Error != nil {
+		return diag.Errorf(`Task "%s" failed: %v: %v`, taskId, task.Error["type"], task.Error["reason"])
+	}
+	if failures, ok := task.Response["failures"].([]interface{}); ok && len(failures) > 0 {
+		f, err := json.Marshal(failures)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return diag.Errorf(`Task "%s" completed with %d failures: %s`, taskId, len(failures), f)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceTaskWaitNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTaskWaitNotFound,
+				ExpectError: regexp.MustCompile(`task "oTUltX4IQMOUUVeiohTt8A:12345" not found`),
+			},
+		},
+	})
+}
+
+const testAccResourceTaskWaitNotFound = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_task_wait" "test" {
+  task_id = "oTUltX4IQMOUUVeiohTt8A:12345"
+}
+`
@@ -0,0 +1,222 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTasks() *schema.Resource {
+	tasksSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"task_id": {
+			Description:   "Returns only the task with the given identifier, in the `<node>:<id>` format, even if the task is completed.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"actions", "nodes"},
+		},
+		"actions": {
+			Description: "Returns only the tasks running the given actions, e.g. `*reindex` or `cluster:*`. Supports wildcards.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"nodes": {
+			Description: "Returns only the tasks running on the given nodes, e.g. the node IDs or `_local`.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"detailed": {
+			Description: "Returns the detailed description and status of the tasks.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"tasks": {
+			Description: "The list of the tasks, sorted by their start time.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"task_id": {
+						Description: "Identifier of the task, in the `<node>:<id>` format.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"node": {
+						Description: "Identifier of the node running the task.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"action": {
+						Description: "Action run by the task, e.g. `indices:data/write/reindex`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": {
+						Description: "Type of the task, e.g. `transport` or `persistent`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"description": {
+						Description: "Description of the task, only returned when `detailed` is enabled.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"start_time_in_millis": {
+						Description: "Start time of the task, in milliseconds since the epoch.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"running_time": {
+						Description: "How long the task has been running, e.g. `1m30s`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"running_time_in_nanos": {
+						Description: "How long the task has been running, in nanoseconds.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"cancellable": {
+						Description: "Whether the task can be cancelled.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"cancelled": {
+						Description: "Whether the task has been cancelled.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"parent_task_id": {
+						Description: "Identifier of the parent task, if any.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"status": {
+						Description: "JSON object with the status of the task, e.g. the progress of a reindex.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"completed": {
+						Description: "Whether the task is completed. Only completed tasks looked up by `task_id` can be returned.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(tasksSchema)
+
+	return &schema.Resource{
+		Description: "Gets the tasks currently running in the cluster, e.g. to follow long-running reindex operations. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/tasks.html",
+		ReadContext: dataSourceTasksRead,
+		Schema:      tasksSchema,
+	}
+}
+
+func dataSourceTasksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	clusterId, diags := client.ClusterID(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	var tasks []interface{}
+	if taskId := d.Get("task_id").(string); taskId != "" {
+		task, diags := elasticsearch.GetTask(ctx, client, taskId)
+		if diags.HasError() {
+			return diags
+		}
+		if task == nil {
+			return diag.Errorf(`Task "%s" not found`, taskId)
+		}
+		t, err := flattenTask(task.Task, task.Completed)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tasks = append(tasks, t)
+	} else {
+		actions := make([]string, 0)
+		for _, a := range d.Get("actions").([]interface{}) {
+			actions = append(actions, a.(string))
+		}
+		nodes := make([]string, 0)
+		for _, n := range d.Get("nodes").([]interface{}) {
+			nodes = append(nodes, n.(string))
+		}
+
+		running, diags := elasticsearch.GetTasks(ctx, client, actions, nodes, d.Get("detailed").(bool))
+		if diags.HasError() {
+			return diags
+		}
+		sort.Slice(running, func(i, j int) bool {
+			if running[i].StartTimeInMillis == running[j].StartTimeInMillis {
+				return running[i].TaskId() < running[j].TaskId()
+			}
+			return running[i].StartTimeInMillis < running[j].StartTimeInMillis
+		})
+		tasks = make([]interface{}, 0, len(running))
+		for _, r := range running {
+			t, err := flattenTask(r, false)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			tasks = append(tasks, t)
+		}
+	}
+	if err := d.Set("tasks", tasks); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return diags
+}
+
+func flattenTask(t models.TaskInfo, completed bool) (map[string]interface{}, error) {
+	task := map[string]interface{}{
+		"task_id":               t.TaskId(),
+		"node":                  t.Node,
+		"action":                t.Action,
+		"type":                  t.Type,
+		"description":           t.Description,
+		"start_time_in_millis":  t.StartTimeInMillis,
+		"running_time":          time.Duration(t.RunningTimeInNanos).String(),
+		"running_time_in_nanos": t.RunningTimeInNanos,
+		"cancellable":           t.Cancellable,
+		"cancelled":             t.Cancelled,
+		"parent_task_id":        t.ParentTaskId,
+		"completed":             completed,
+	}
+	if t.Status != nil {
+		status, err := json.Marshal(t.Status)
+		if err != nil {
+			return nil, fmt.Errorf(`unable to marshal the status of the task "%s": %w`, t.TaskId(), err)
+		}
+		task["status"] = string(status)
+	}
+	return task, nil
+}
@@ -0,0 +1,40 @@
+package cluster_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTasks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTasks,
+				Check: resource.ComposeTestCheckFunc(
+					// the list tasks request is a task itself
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_tasks.list", "tasks.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_tasks.list", "tasks.0.action", "cluster:monitor/tasks/lists"),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_tasks.list", "tasks.0.task_id", regexp.MustCompile(`^.+:\d+$`)),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_tasks.list", "tasks.0.running_time"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_tasks.list", "tasks.0.completed", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceTasks = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_tasks" "list" {
+  actions  = ["cluster:monitor/tasks/lists"]
+  detailed = true
+}
+`
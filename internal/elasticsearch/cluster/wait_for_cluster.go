@@ -0,0 +1,151 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// waitForClusterHealthTimeout is how long a single cluster health request waits for the conditions, before it's retried.
+const waitForClusterHealthTimeout = 30 * time.Second
+
+func ResourceWaitForCluster() *schema.Resource {
+	waitSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"wait_for_status": {
+			Description:  "Waits until the cluster health reaches the given status or better.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "yellow",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "red"}, false),
+		},
+		"wait_for_nodes": {
+			Description: "Waits until the specified number of nodes is available. Also accepts `>=N`, `<=N`, `>N` and `<N`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"cluster_name": {
+			Description: "Name of the cluster.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "Health status of the cluster once ready.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"number_of_nodes": {
+			Description: "Number of the nodes in the cluster once ready.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(waitSchema)
+
+	return &schema.Resource{
+		Description: "Waits until the cluster is reachable and its health reaches the given status, e.g. to create the other resources with `depends_on` only once a new cluster is ready. Nothing is created in the cluster. Set `verify_connection = false` on the provider when the cluster may not be reachable yet when Terraform starts.",
+
+		CreateContext: resourceWaitForClusterCreate,
+		// only the connection can be updated, the cluster is never awaited again
+		UpdateContext: resourceWaitForClusterRead,
+		ReadContext:   resourceWaitForClusterRead,
+		DeleteContext: resourceWaitForClusterDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: waitSchema,
+	}
+}
+
+func resourceWaitForClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	params := &models.ClusterHealthParams{
+		WaitForStatus: d.Get("wait_for_status").(string),
+		WaitForNodes:  d.Get("wait_for_nodes").(string),
+		Timeout:       waitForClusterHealthTimeout,
+	}
+	if timeout < params.Timeout {
+		params.Timeout = timeout
+	}
+
+	var clusterId *string
+	var health *models.ClusterHealth
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		id, diags := client.ClusterID(ctx)
+		if diags.HasError() {
+			tflog.Debug(ctx, fmt.Sprintf("The cluster is not reachable yet: %s", utils.DiagsAsError(diags)))
+			return resource.RetryableError(fmt.Errorf("the cluster is not reachable: %w", utils.DiagsAsError(diags)))
+		}
+		serverless, diags := client.IsServerless(ctx)
+		if diags.HasError() {
+			return resource.RetryableError(fmt.Errorf("the cluster is not reachable: %w", utils.DiagsAsError(diags)))
+		}
+		if serverless {
+			// the cluster health is not available on Serverless, the project is ready once it responds
+			tflog.Debug(ctx, "Connected to an Elasticsearch Serverless project, not waiting for the cluster health")
+			clusterId, health = id, &models.ClusterHealth{}
+			return nil
+		}
+		h, diags := elasticsearch.GetClusterHealth(ctx, client, params)
+		if diags.HasError() {
+			tflog.Debug(ctx, fmt.Sprintf("The cluster is not ready yet: %s", utils.DiagsAsError(diags)))
+			return resource.RetryableError(fmt.Errorf("the cluster is not ready: %w", utils.DiagsAsError(diags)))
+		}
+		clusterId, health = id, h
+		return nil
+	})
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Timed out waiting for the Elasticsearch cluster",
+			Detail:   fmt.Sprintf("The cluster was not ready within %s, increase the create timeout of the resource to wait longer. Last error: %s", timeout, err),
+		}}
+	}
+
+	if err := d.Set("cluster_name", health.ClusterName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", health.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("number_of_nodes", health.NumberOfNodes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return resourceWaitForClusterRead(ctx, d, meta)
+}
+
+func resourceWaitForClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the cluster is awaited only on create, its state is kept as is
+	return nil
+}
+
+func resourceWaitForClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,36 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceWaitForCluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWaitForCluster,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_wait_for_cluster.test", "cluster_name"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_wait_for_cluster.test", "status"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_wait_for_cluster.test", "number_of_nodes"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceWaitForCluster = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_wait_for_cluster" "test" {
+  wait_for_status = "yellow"
+  wait_for_nodes  = ">=1"
+}
+`
@@ -0,0 +1,177 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceBulkDocuments() *schema.Resource {
+	bulkDocumentsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Name of the index the documents are stored in.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"documents": {
+			Description:      "The JSON documents keyed by their `_id`. Only the added, changed and removed documents are indexed or deleted, the other documents of the index are left untouched.",
+			Type:             schema.TypeMap,
+			Required:         true,
+			ValidateDiagFunc: validateDocuments,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"refresh": {
+			Description:  "Whether the changes are made visible to search: `true` to refresh the affected shards, `wait_for` to wait for the next refresh or `false`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "false",
+			ValidateFunc: validation.StringInSlice([]string{"true", "false", "wait_for"}, false),
+		},
+	}
+
+	utils.AddConnectionSchema(bulkDocumentsSchema)
+
+	return &schema.Resource{
+		Description: "Manages a set of documents of an index with the bulk API, e.g. to seed reference data. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html",
+
+		CreateContext: resourceBulkDocumentsPut,
+		UpdateContext: resourceBulkDocumentsPut,
+		ReadContext:   resourceBulkDocumentsRead,
+		DeleteContext: resourceBulkDocumentsDelete,
+
+		Schema: bulkDocumentsSchema,
+	}
+}
+
+func resourceBulkDocumentsPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	index := d.Get("index").(string)
+	id, diags := client.ID(ctx, index)
+	if diags.HasError() {
+		return diags
+	}
+
+	oldDocs, newDocs := d.GetChange("documents")
+	operations := bulkDocumentsChanges(oldDocs.(map[string]interface{}), newDocs.(map[string]interface{}))
+	if diags := elasticsearch.BulkDocuments(ctx, client, index, operations, d.Get("refresh").(string)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceBulkDocumentsRead(ctx, d, meta)
+}
+
+// validateDocuments checks that every document is valid JSON, the validation of a map is called once with the whole map.
+func validateDocuments(i interface{}, path cty.Path) diag.Diagnostics {
+	docs, ok := i.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("expected type of documents to be a map")
+	}
+	ids := make([]string, 0, len(docs))
+	for docId := range docs {
+		ids = append(ids, docId)
+	}
+	sort.Strings(ids)
+
+	var diags diag.Diagnostics
+	for _, docId := range ids {
+		if _, errs := validation.StringIsJSON(docs[docId], docId); len(errs) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf(`The document "%s" is not valid JSON`, docId),
+				Detail:        errs[0].Error(),
+				AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(docId)}),
+			})
+		}
+	}
+	return diags
+}
+
+// bulkDocumentsChanges returns the bulk operations indexing the added and changed documents and deleting the removed ones,
+// sorted by the document ID.
+func bulkDocumentsChanges(oldDocs, newDocs map[string]interface{}) []models.BulkOperation {
+	operations := make([]models.BulkOperation, 0)
+	for docId, source := range newDocs {
+		if old, ok := oldDocs[docId]; ok {
+			if equal, _ := utils.JSONBytesEqual([]byte(old.(string)), []byte(source.(string))); equal {
+				continue
+			}
+		}
+		operations = append(operations, models.BulkOperation{Action: "index", Id: docId, Source: json.RawMessage(source.(string))})
+	}
+	for docId := range oldDocs {
+		if _, ok := newDocs[docId]; !ok {
+			operations = append(operations, models.BulkOperation{Action: "delete", Id: docId})
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool { return operations[i].Id < operations[j].Id })
+	return operations
+}
+
+func resourceBulkDocumentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	index := d.Get("index").(string)
+
+	managed := d.Get("documents").(map[string]interface{})
+	ids := make([]string, 0, len(managed))
+	for docId := range managed {
+		ids = append(ids, docId)
+	}
+	sort.Strings(ids)
+	sources, diags := elasticsearch.GetDocuments(ctx, client, index, ids)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the missing documents are left out to index them again
+	documents := make(map[string]interface{}, len(sources))
+	for _, docId := range ids {
+		source, ok := sources[docId]
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf(`Document "%s" not found in the index "%s", removing from state`, docId, index))
+			continue
+		}
+		documents[docId] = string(source)
+	}
+	if err := d.Set("documents", documents); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceBulkDocumentsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	index := d.Get("index").(string)
+
+	operations := bulkDocumentsChanges(d.Get("documents").(map[string]interface{}), map[string]interface{}{})
+	return elasticsearch.BulkDocuments(ctx, client, index, operations, d.Get("refresh").(string))
+}
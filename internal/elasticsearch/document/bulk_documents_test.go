@@ -0,0 +1,166 @@
+package document_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/document"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceBulkDocuments(t *testing.T) {
+	index := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkBulkDocumentsDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBulkDocuments(index, `
+    fr = jsonencode({ name = "France" })
+    de = jsonencode({ name = "Germany" })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_bulk_documents.test", "documents.%", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_bulk_documents.test", "documents.fr", `{"name":"France"}`),
+					checkDocumentCount(index, 2),
+				),
+			},
+			{
+				// one document is changed, one removed and one added
+				Config: testAccResourceBulkDocuments(index, `
+    fr = jsonencode({ name = "France", capital = "Paris" })
+    jp = jsonencode({ name = "Japan" })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_bulk_documents.test", "documents.%", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_bulk_documents.test", "documents.fr", `{"capital":"Paris","name":"France"}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_bulk_documents.test", "documents.jp", `{"name":"Japan"}`),
+					checkDocumentCount(index, 2),
+				),
+			},
+			{
+				// the document deleted outside of Terraform is indexed again
+				PreConfig: func() {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						t.Fatalf("Failed to create testing client: %v", err)
+					}
+					res, err := client.GetESClient().Delete(index, "jp", client.GetESClient().Delete.WithRefresh("true"))
+					if err != nil {
+						t.Fatalf("Failed to delete the document: %v", err)
+					}
+					res.Body.Close()
+				},
+				Config: testAccResourceBulkDocuments(index, `
+    fr = jsonencode({ name = "France", capital = "Paris" })
+    jp = jsonencode({ name = "Japan" })`),
+				Check: checkDocumentCount(index, 2),
+			},
+		},
+	})
+}
+
+func TestResourceBulkDocumentsValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		documents   map[string]interface{}
+		expectError string
+	}{
+		"valid": {
+			documents: map[string]interface{}{"fr": `{"name":"France"}`, "de": `{"name":"Germany"}`},
+		},
+		"invalid": {
+			documents:   map[string]interface{}{"fr": `{"name":"France"}`, "de": `{"name":`},
+			expectError: `The document "de" is not valid JSON`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := document.ResourceBulkDocuments().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"index":     "countries",
+				"documents": tc.documents,
+			}))
+			if tc.expectError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() || diags[0].Summary != tc.expectError {
+				t.Fatalf("expected error %q, got %v", tc.expectError, diags)
+			}
+		})
+	}
+}
+
+func testAccResourceBulkDocuments(index, documents string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+}
+
+resource "elasticstack_elasticsearch_bulk_documents" "test" {
+  index   = elasticstack_elasticsearch_index.test.name
+  refresh = "true"
+
+  documents = {%s
+  }
+}
+	`, index, documents)
+}
+
+func checkDocumentCount(index string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		res, err := client.GetESClient().Count(client.GetESClient().Count.WithIndex(index))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		var count struct {
+			Count int `json:"count"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&count); err != nil {
+			return err
+		}
+		if count.Count != expected {
+			return fmt.Errorf("expected %d documents in the index %s, got %d", expected, index, count.Count)
+		}
+		return nil
+	}
+}
+
+func checkBulkDocumentsDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_bulk_documents" {
+			continue
+		}
+
+		res, err := client.GetESClient().Get(rs.Primary.Attributes["index"], "fr")
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		// the index may already be deleted as well
+		if res.StatusCode != 404 {
+			return fmt.Errorf("Document (fr) still exists")
+		}
+	}
+	return nil
+}
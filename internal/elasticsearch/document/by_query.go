@@ -0,0 +1,149 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// byQuerySchema returns the schema shared by the update and delete by query resources.
+// The operations are run only once, so all the arguments force the replacement of the resource.
+func byQuerySchema() map[string]*schema.Schema {
+	byQuerySchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Comma-separated list of the data streams, indices and aliases to run the operation on. Supports wildcards.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"query": {
+			Description:      "JSON object with the query selecting the documents, using the Query DSL.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"conflicts": {
+			Description:  "What to do if the operation hits version conflicts: `abort` or `proceed`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "abort",
+			ValidateFunc: validation.StringInSlice([]string{"abort", "proceed"}, false),
+		},
+		"slices": {
+			Description: "The number of slices the operation is divided into, or `auto` to let Elasticsearch choose it.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.Any(
+				validation.StringInSlice([]string{"auto"}, false),
+				validation.StringMatch(regexp.MustCompile(`^[1-9]\d*$`), "must be a positive number"),
+			),
+		},
+		"refresh": {
+			Description: "Refresh the affected shards once the operation is completed.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+		},
+		"total": {
+			Description: "The number of the documents processed by the operation, 0 if the operation runs in a task.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"version_conflicts": {
+			Description: "The number of the version conflicts hit by the operation.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"failures": {
+			Description: "The failures of the operation as a JSON array, the operation failing the apply once its counts are recorded. Taints the resource, so that the operation is run again by the next apply.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(byQuerySchema)
+	utils.AddTaskSchema(byQuerySchema)
+
+	return byQuerySchema
+}
+
+func expandByQuery(d *schema.ResourceData) ([]string, *models.ByQuery, *models.ByQueryParams, diag.Diagnostics) {
+	indices := strings.Split(d.Get("index").(string), ",")
+
+	var byQuery models.ByQuery
+	if v, ok := d.GetOk("query"); ok {
+		query := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v.(string)), &query); err != nil {
+			return nil, nil, nil, diag.FromErr(err)
+		}
+		byQuery.Query = query
+	}
+
+	params := models.ByQueryParams{
+		Conflicts:         d.Get("conflicts").(string),
+		Slices:            d.Get("slices").(string),
+		Refresh:           d.Get("refresh").(bool),
+		WaitForCompletion: d.Get("wait_for_completion").(bool),
+	}
+	return indices, &byQuery, &params, nil
+}
+
+// setByQueryResponse sets the counts of the completed operation, or the task id of the operation running asynchronously.
+// The failures of the operation are recorded before returning them as an error, the ID being already set by the caller.
+func setByQueryResponse(d *schema.ResourceData, response *models.ByQueryResponse, counts map[string]int) diag.Diagnostics {
+	counts["total"] = response.Total
+	counts["version_conflicts"] = response.VersionConflicts
+	for key, count := range counts {
+		if err := d.Set(key, count); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("task_id", response.Task); err != nil {
+		return diag.FromErr(err)
+	}
+
+	failures := ""
+	if len(response.Failures) > 0 {
+		f, err := json.Marshal(response.Failures)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		failures = string(f)
+	}
+	if err := d.Set("failures", failures); err != nil {
+		return diag.FromErr(err)
+	}
+	if failures != "" {
+		return diag.Errorf("The operation completed with %d failures: %s", len(response.Failures), failures)
+	}
+	return nil
+}
+
+// resourceByQueryRead keeps the state, the documents affected by the operation can't be read back.
+func resourceByQueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceByQueryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, fmt.Sprintf(`The operation on "%s" cannot be reverted, it's only removed from the state`, d.Get("index").(string)))
+	return nil
+}
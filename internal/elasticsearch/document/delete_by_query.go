@@ -0,0 +1,54 @@
+package document
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceDeleteByQuery() *schema.Resource {
+	deleteByQuerySchema := byQuerySchema()
+	deleteByQuerySchema["deleted"] = &schema.Schema{
+		Description: "The number of the deleted documents.",
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Deletes the documents matching the query once, e.g. to purge documents as part of a migration. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-delete-by-query.html",
+
+		CreateContext: resourceDeleteByQueryCreate,
+		// only the connection can be updated, the operation is never run again
+		UpdateContext: resourceByQueryRead,
+		ReadContext:   resourceByQueryRead,
+		DeleteContext: resourceByQueryDelete,
+
+		Schema: deleteByQuerySchema,
+	}
+}
+
+func resourceDeleteByQueryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	id, diags := client.ID(ctx, d.Get("index").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	indices, query, params, diags := expandByQuery(d)
+	if diags.HasError() {
+		return diags
+	}
+	response, diags := elasticsearch.DeleteByQuery(ctx, client, indices, query, params)
+	if diags.HasError() {
+		return diags
+	}
+	// the ID is set before the failures are reported, the documents processed by the operation being already changed
+	d.SetId(id.String())
+	return setByQueryResponse(d, response, map[string]int{"deleted": response.Deleted})
+}
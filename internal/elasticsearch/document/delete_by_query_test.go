@@ -0,0 +1,132 @@
+package document_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/document"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccResourceDeleteByQuery(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					putTestDocuments(t, indexName, `{"status":"old"}`, `{"status":"old"}`, `{"status":"new"}`)
+				},
+				Config: testAccResourceDeleteByQuery(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_delete_by_query.test", "total", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_delete_by_query.test", "deleted", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceDeleteByQueryFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `{"cluster_uuid": "cluster-uuid", "version": {"number": "7.17.7", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+			return
+		}
+		fmt.Fprint(w, `{"total": 3, "deleted": 2, "version_conflicts": 0, "failures": [{"index": "test", "id": "3", "cause": {"type": "es_rejected_execution_exception"}}]}`)
+	}))
+	defer server.Close()
+
+	r := document.ResourceDeleteByQuery()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"index": "test",
+		"elasticsearch_connection": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{server.URL},
+		}},
+	})
+	diags := r.CreateContext(context.Background(), d, &clients.ApiClient{})
+	if !diags.HasError() {
+		t.Fatal("expected the failures to be reported")
+	}
+	// the partially applied operation is still recorded
+	if d.Id() != "cluster-uuid/test" {
+		t.Errorf("expected the ID to be set, got %q", d.Id())
+	}
+	if deleted := d.Get("deleted").(int); deleted != 2 {
+		t.Errorf("expected 2 deleted documents, got %d", deleted)
+	}
+	if failures := d.Get("failures").(string); failures != `[{"cause":{"type":"es_rejected_execution_exception"},"id":"3","index":"test"}]` {
+		t.Errorf("unexpected failures %s", failures)
+	}
+}
+
+func TestAccResourceDeleteByQueryTask(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					putTestDocuments(t, indexName, `{"status":"old"}`, `{"status":"old"}`, `{"status":"new"}`)
+				},
+				Config: testAccResourceDeleteByQueryTask(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_delete_by_query.test", "task_id", regexp.MustCompile(`^.+:\d+$`)),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_delete_by_query.test", "deleted", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_task_wait.test", "completed", "true"),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_task_wait.test", "response", regexp.MustCompile(`"deleted":2`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDeleteByQuery(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_delete_by_query" "test" {
+  index = "%s"
+  query = jsonencode({
+    term = { "status.keyword" = "old" }
+  })
+}
+	`, name)
+}
+
+func testAccResourceDeleteByQueryTask(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_delete_by_query" "test" {
+  index = "%s"
+  query = jsonencode({
+    term = { "status.keyword" = "old" }
+  })
+  conflicts           = "proceed"
+  wait_for_completion = false
+}
+
+resource "elasticstack_elasticsearch_task_wait" "test" {
+  task_id = elasticstack_elasticsearch_delete_by_query.test.task_id
+}
+	`, name)
+}
@@ -0,0 +1,123 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceDocument() *schema.Resource {
+	documentSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Name of the index the document is stored in.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"document_id": {
+			Description: "The `_id` of the document.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"source_includes": {
+			Description: "The fields of the `_source` to return, supports wildcards. All the fields are returned by default.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"source_excludes": {
+			Description: "The fields to leave out of the returned `_source`, supports wildcards.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"version": {
+			Description: "The version of the document.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"source": {
+			Description: "JSON object with the filtered `_source` of the document.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(documentSchema)
+
+	return &schema.Resource{
+		Description: "Retrieves a document of an index by its ID, optionally only the needed fields of its `_source`. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-get.html",
+
+		ReadContext: dataSourceDocumentRead,
+
+		Schema: documentSchema,
+	}
+}
+
+func dataSourceDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	index := d.Get("index").(string)
+	docId := d.Get("document_id").(string)
+	id, diags := client.ID(ctx, fmt.Sprintf("%s/%s", index, docId))
+	if diags.HasError() {
+		return diags
+	}
+
+	includes := make([]string, 0)
+	for _, f := range d.Get("source_includes").([]interface{}) {
+		includes = append(includes, f.(string))
+	}
+	excludes := make([]string, 0)
+	for _, f := range d.Get("source_excludes").([]interface{}) {
+		excludes = append(excludes, f.(string))
+	}
+
+	doc, diags := elasticsearch.GetDocument(ctx, client, index, docId, includes, excludes)
+	if diags.HasError() {
+		return diags
+	}
+	if doc == nil {
+		return diag.Errorf(`Document "%s" not found in the index "%s"`, docId, index)
+	}
+
+	if err := d.Set("version", doc.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	// the source is marshalled with the keys sorted, which gives the canonical JSON
+	var source interface{}
+	if len(doc.Source) > 0 {
+		if err := json.Unmarshal(doc.Source, &source); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if source == nil {
+		source = map[string]interface{}{}
+	}
+	sourceJson, err := json.Marshal(source)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("source", string(sourceJson)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
@@ -0,0 +1,73 @@
+package document_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDocument(t *testing.T) {
+	index := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDocument(index, "fr"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_document.all", "source", `{"capital":"Paris","name":"France","population":{"city":2,"total":68}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_document.all", "version", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_document.includes", "source", `{"name":"France","population":{"total":68}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_document.excludes", "source", `{"capital":"Paris","name":"France"}`),
+				),
+			},
+			{
+				Config:      testAccDataSourceDocument(index, "missing"),
+				ExpectError: regexp.MustCompile(`Document "missing" not found`),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDocument(index, docId string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+}
+
+resource "elasticstack_elasticsearch_bulk_documents" "test" {
+  index   = elasticstack_elasticsearch_index.test.name
+  refresh = "true"
+
+  documents = {
+    fr = jsonencode({ name = "France", capital = "Paris", population = { total = 68, city = 2 } })
+  }
+}
+
+data "elasticstack_elasticsearch_document" "all" {
+  index       = elasticstack_elasticsearch_bulk_documents.test.index
+  document_id = "%s"
+}
+
+data "elasticstack_elasticsearch_document" "includes" {
+  index           = elasticstack_elasticsearch_bulk_documents.test.index
+  document_id     = "%s"
+  source_includes = ["name", "population.total"]
+}
+
+data "elasticstack_elasticsearch_document" "excludes" {
+  index           = elasticstack_elasticsearch_bulk_documents.test.index
+  document_id     = "%s"
+  source_excludes = ["population"]
+}
+	`, index, docId, docId, docId)
+}
@@ -0,0 +1,38 @@
+package document_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+)
+
+// putTestDocuments indexes the documents into a new index, which is deleted at the end of the test
+func putTestDocuments(t *testing.T, index string, docs ...string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body strings.Builder
+	for _, doc := range docs {
+		body.WriteString(fmt.Sprintf("{\"index\":{\"_index\":\"%s\"}}\n%s\n", index, doc))
+	}
+	res, err := client.GetESClient().Bulk(strings.NewReader(body.String()), client.GetESClient().Bulk.WithRefresh("true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to index the documents: %s", res.String())
+	}
+
+	t.Cleanup(func() {
+		res, err := client.GetESClient().Indices.Delete([]string{index})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		res.Body.Close()
+	})
+}
@@ -0,0 +1,114 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceUpdateByQuery() *schema.Resource {
+	updateByQuerySchema := byQuerySchema()
+	updateByQuerySchema["query"].Description = "JSON object with the query selecting the documents, using the Query DSL. All the documents are updated if omitted."
+	updateByQuerySchema["query"].Required = false
+	updateByQuerySchema["query"].Optional = true
+	updateByQuerySchema["script"] = &schema.Schema{
+		Description: "Script updating the documents. If omitted, the documents are reindexed in place, e.g. to pick up the mapping changes.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source": {
+					Description: "The script source, e.g. `ctx._source.count++`.",
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+				},
+				"lang": {
+					Description: "The language of the script.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+					Default:     "painless",
+				},
+				"params": {
+					Description:      "JSON object with the parameters of the script.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					ValidateFunc:     validation.StringIsJSON,
+					DiffSuppressFunc: utils.DiffJsonSuppress,
+				},
+			},
+		},
+	}
+	updateByQuerySchema["updated"] = &schema.Schema{
+		Description: "The number of the updated documents.",
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+	updateByQuerySchema["noops"] = &schema.Schema{
+		Description: "The number of the documents left unchanged, because the script set `ctx.op` to `noop`.",
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Updates the documents matching the query once, e.g. to migrate the documents to a new schema. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-update-by-query.html",
+
+		CreateContext: resourceUpdateByQueryCreate,
+		// only the connection can be updated, the operation is never run again
+		UpdateContext: resourceByQueryRead,
+		ReadContext:   resourceByQueryRead,
+		DeleteContext: resourceByQueryDelete,
+
+		Schema: updateByQuerySchema,
+	}
+}
+
+func resourceUpdateByQueryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	id, diags := client.ID(ctx, d.Get("index").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	indices, query, params, diags := expandByQuery(d)
+	if diags.HasError() {
+		return diags
+	}
+	if v, ok := d.GetOk("script"); ok {
+		s := v.([]interface{})[0].(map[string]interface{})
+		script := models.ByQueryScript{
+			Source: s["source"].(string),
+			Lang:   s["lang"].(string),
+		}
+		if p := s["params"].(string); p != "" {
+			params := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(p), &params); err != nil {
+				return diag.FromErr(err)
+			}
+			script.Params = params
+		}
+		query.Script = &script
+	}
+
+	response, diags := elasticsearch.UpdateByQuery(ctx, client, indices, query, params)
+	if diags.HasError() {
+		return diags
+	}
+	// the ID is set before the failures are reported, the documents processed by the operation being already changed
+	d.SetId(id.String())
+	return setByQueryResponse(d, response, map[string]int{"updated": response.Updated, "noops": response.Noops})
+}
@@ -0,0 +1,60 @@
+package document_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceUpdateByQuery(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					putTestDocuments(t, indexName, `{"status":"old"}`, `{"status":"old"}`, `{"status":"new"}`)
+				},
+				Config: testAccResourceUpdateByQuery(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_update_by_query.test", "total", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_update_by_query.test", "updated", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_update_by_query.test", "version_conflicts", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_update_by_query.test", "task_id", ""),
+				),
+			},
+			{
+				// the operation is not run again
+				Config:   testAccResourceUpdateByQuery(indexName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccResourceUpdateByQuery(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_update_by_query" "test" {
+  index = "%s"
+  query = jsonencode({
+    term = { "status.keyword" = "old" }
+  })
+
+  script {
+    source = "ctx._source.status = params.status"
+    params = jsonencode({ status = "new" })
+  }
+
+  refresh = true
+}
+	`, name)
+}
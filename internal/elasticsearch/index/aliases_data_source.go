@@ -0,0 +1,147 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceAliases() *schema.Resource {
+	aliasesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the aliases to list. Supports wildcards and comma-separated lists, the missing aliases are ignored.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"index": {
+			Description: "Name of the indices or data streams whose aliases are listed. Supports wildcards and comma-separated lists, the missing indices are ignored. All the indices by default.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"aliases": {
+			Description: "The matching aliases, one entry per alias and index, sorted by the alias name and the index name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the alias.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"index": {
+						Description: "Name of the index or data stream the alias points to.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"filter": {
+						Description: "JSON query filtering the documents of the index accessible through the alias, empty if the alias is not filtered.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"index_routing": {
+						Description: "Value used to route the indexing operations to a specific shard.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"search_routing": {
+						Description: "Value used to route the search operations to a specific shard.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"is_hidden": {
+						Description: "Whether the alias is hidden.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"is_write_index": {
+						Description: "Whether the index is the write index of the alias.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(aliasesSchema)
+
+	return &schema.Resource{
+		Description: "Lists the aliases matching the name and the indices they point to, e.g. to find the write index of an alias. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-alias.html",
+		ReadContext: dataSourceAliasesRead,
+		Schema:      aliasesSchema,
+	}
+}
+
+func dataSourceAliasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+
+	var indexPatterns []string
+	if v := d.Get("index").(string); v != "" {
+		indexPatterns = strings.Split(v, ",")
+	}
+	indices, diags := elasticsearch.GetAliases(ctx, client, strings.Split(name, ","), indexPatterns)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliases := make([]map[string]interface{}, 0)
+	for indexName, index := range indices {
+		for aliasName, alias := range index.Aliases {
+			a := map[string]interface{}{
+				"name":           aliasName,
+				"index":          indexName,
+				"index_routing":  alias.IndexRouting,
+				"search_routing": alias.SearchRouting,
+				"is_hidden":      alias.IsHidden,
+				"is_write_index": alias.IsWriteIndex,
+			}
+			if alias.Filter != nil {
+				filter, err := json.Marshal(alias.Filter)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				a["filter"] = string(filter)
+			}
+			aliases = append(aliases, a)
+		}
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i]["name"] != aliases[j]["name"] {
+			return aliases[i]["name"].(string) < aliases[j]["name"].(string)
+		}
+		return aliases[i]["index"].(string) < aliases[j]["index"].(string)
+	})
+
+	result := make([]interface{}, len(aliases))
+	for i, a := range aliases {
+		result[i] = a
+	}
+	if err := d.Set("aliases", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
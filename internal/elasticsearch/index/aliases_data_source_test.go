@@ -0,0 +1,85 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAliases(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAliases(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.#", "3"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.0.name", name+"-alias"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.0.index", name+"-first"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.0.is_write_index", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.1.index", name+"-second"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.1.is_write_index", "false"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.2.name", name+"-filtered"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.2.filter", `{"term":{"user.id":"kimchy"}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.all", "aliases.2.index_routing", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.by_index", "aliases.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.by_index", "aliases.0.index", name+"-second"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_aliases.missing", "aliases.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAliases(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "first" {
+  name = "%[1]s-first"
+
+  alias {
+    name           = "%[1]s-alias"
+    is_write_index = true
+  }
+  alias {
+    name    = "%[1]s-filtered"
+    routing = "1"
+    filter = jsonencode({
+      term = { "user.id" = "kimchy" }
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "second" {
+  name = "%[1]s-second"
+
+  alias {
+    name = "%[1]s-alias"
+  }
+}
+
+data "elasticstack_elasticsearch_aliases" "all" {
+  name = "%[1]s-*"
+
+  depends_on = [elasticstack_elasticsearch_index.first, elasticstack_elasticsearch_index.second]
+}
+
+data "elasticstack_elasticsearch_aliases" "by_index" {
+  name  = "%[1]s-*"
+  index = elasticstack_elasticsearch_index.second.name
+}
+
+data "elasticstack_elasticsearch_aliases" "missing" {
+  name = "%[1]s-missing"
+}
+	`, name)
+}
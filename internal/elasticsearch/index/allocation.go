@@ -0,0 +1,90 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var allocationFilterKinds = []string{"require", "include", "exclude"}
+
+func routingAllocationSchema() *schema.Schema {
+	filterSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	return &schema.Schema{
+		Description: "Shard allocation filters of the index, keyed by the node attribute (e.g. `_name`, `_ip`, `_host`, `_tier` or a custom attribute like `box_type`) with a comma-separated list of values. Only the configured attributes are tracked, the filters added by Elasticsearch (e.g. `include._tier_preference`) are ignored. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/shard-allocation-filtering.html",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"require": filterSchema("Assigns the index to the nodes having all of the given attribute values."),
+				"include": filterSchema("Assigns the index to the nodes having at least one of the given attribute values."),
+				"exclude": filterSchema("Assigns the index to the nodes having none of the given attribute values."),
+			},
+		},
+	}
+}
+
+// expandRoutingAllocation converts the `routing_allocation` block into the `routing.allocation.*` index settings.
+func expandRoutingAllocation(v []interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	if len(v) == 0 || v[0] == nil {
+		return settings
+	}
+	filters := v[0].(map[string]interface{})
+	for _, kind := range allocationFilterKinds {
+		for attr, value := range filters[kind].(map[string]interface{}) {
+			settings[fmt.Sprintf("routing.allocation.%s.%s", kind, attr)] = value
+		}
+	}
+	return settings
+}
+
+// routingAllocationChanges returns the index settings to update for the changed allocation filters,
+// the filters removed from the configuration are reset to the default.
+func routingAllocationChanges(old, new []interface{}) map[string]interface{} {
+	oldSettings := expandRoutingAllocation(old)
+	changes := expandRoutingAllocation(new)
+	for k, v := range oldSettings {
+		if nv, ok := changes[k]; !ok {
+			changes[k] = nil
+		} else if nv == v {
+			delete(changes, k)
+		}
+	}
+	return changes
+}
+
+// flattenRoutingAllocation reads the allocation filters configured in the current state from the flat index settings.
+func flattenRoutingAllocation(settings map[string]interface{}, current []interface{}) []interface{} {
+	if len(current) == 0 || current[0] == nil {
+		return nil
+	}
+	configured := current[0].(map[string]interface{})
+	filters := make(map[string]interface{}, len(allocationFilterKinds))
+	for _, kind := range allocationFilterKinds {
+		values := make(map[string]interface{})
+		prefix := fmt.Sprintf("index.routing.allocation.%s.", kind)
+		for key, value := range settings {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			attr := strings.TrimPrefix(key, prefix)
+			if _, ok := configured[kind].(map[string]interface{})[attr]; ok {
+				values[attr] = fmt.Sprintf("%v", value)
+			}
+		}
+		filters[kind] = values
+	}
+	return []interface{}{filters}
+}
@@ -0,0 +1,408 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var analysisSections = []string{"analyzer", "normalizer", "tokenizer", "filter", "char_filter"}
+
+// Built-in analysis components, which can be referenced without defining them in the analysis block.
+// See, https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis.html
+var (
+	builtinTokenizers = []string{
+		"char_group", "classic", "edge_ngram", "keyword", "letter", "lowercase", "ngram", "path_hierarchy", "pattern",
+		"simple_pattern", "simple_pattern_split", "standard", "thai", "uax_url_email", "whitespace",
+	}
+	builtinFilters = []string{
+		"apostrophe", "arabic_normalization", "arabic_stem", "asciifolding", "bengali_normalization", "brazilian_stem", "cjk_bigram", "cjk_width",
+		"classic", "common_grams", "condition", "czech_stem", "decimal_digit", "delimited_payload", "dictionary_decompounder", "dutch_stem",
+		"edge_ngram", "elision", "fingerprint", "flatten_graph", "french_stem", "german_normalization", "german_stem", "hindi_normalization",
+		"hunspell", "hyphenation_decompounder", "indic_normalization", "keep", "keep_types", "keyword_marker", "keyword_repeat", "kstem",
+		"length", "limit", "lowercase", "min_hash", "multiplexer", "ngram", "persian_normalization", "pattern_capture", "pattern_replace",
+		"porter_stem", "predicate_token_filter", "remove_duplicates", "reverse", "russian_stem", "scandinavian_folding",
+		"scandinavian_normalization", "serbian_normalization", "shingle", "snowball", "sorani_normalization", "stemmer", "stemmer_override",
+		"stop", "synonym", "synonym_graph", "trim", "truncate", "unique", "uppercase", "word_delimiter", "word_delimiter_graph",
+	}
+	builtinCharFilters = []string{"html_strip", "mapping", "pattern_replace"}
+	// analysis components provided by the official analysis plugins
+	pluginComponentPrefixes = []string{"icu_", "kuromoji_", "nori_", "phonetic", "smartcn_", "stempel_", "ukrainian_"}
+)
+
+func analysisSchema(conflictsWith ...string) *schema.Schema {
+	componentSettingsSchema := func() *schema.Schema {
+		return &schema.Schema{
+			Description:      "JSON object with the additional parameters of the component.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffIndexSettingSuppress,
+			ValidateFunc:     validation.StringIsJSON,
+		}
+	}
+	referencesSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	componentSchema := func(kind string) *schema.Schema {
+		return &schema.Schema{
+			Description: fmt.Sprintf("Custom %s definition.", kind),
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: fmt.Sprintf("Name of the %s.", kind),
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"type": {
+						Description: fmt.Sprintf("Type of the %s.", kind),
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"settings": componentSettingsSchema(),
+				},
+			},
+		}
+	}
+
+	return &schema.Schema{
+		Description:   "Typed definition of the analysis components, serialized into the `index.analysis` settings.",
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictsWith,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"analyzer": {
+					Description: "Custom analyzer definition.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Description: "Name of the analyzer.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"type": {
+								Description: "Type of the analyzer.",
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     "custom",
+							},
+							"tokenizer": {
+								Description: "Built-in or custom tokenizer used by the analyzer.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"filter":      referencesSchema("Built-in or custom token filters used by the analyzer, in the order they are applied."),
+							"char_filter": referencesSchema("Built-in or custom character filters used by the analyzer, in the order they are applied."),
+							"settings":    componentSettingsSchema(),
+						},
+					},
+				},
+				"normalizer": {
+					Description: "Custom normalizer definition.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Description: "Name of the normalizer.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"type": {
+								Description: "Type of the normalizer.",
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     "custom",
+							},
+							"filter":      referencesSchema("Built-in or custom token filters used by the normalizer, in the order they are applied."),
+							"char_filter": referencesSchema("Built-in or custom character filters used by the normalizer, in the order they are applied."),
+							"settings":    componentSettingsSchema(),
+						},
+					},
+				},
+				"tokenizer":   componentSchema("tokenizer"),
+				"filter":      componentSchema("token filter"),
+				"char_filter": componentSchema("character filter"),
+			},
+		},
+	}
+}
+
+// analysisSettingsChanges returns the flat analysis settings to update on a closed index, the removed ones being reset.
+func analysisSettingsChanges(oldAnalysis, newAnalysis interface{}) (map[string]interface{}, diag.Diagnostics) {
+	o, diags := expandAnalysis(oldAnalysis.([]interface{}))
+	if diags.HasError() {
+		return nil, diags
+	}
+	n, diags := expandAnalysis(newAnalysis.([]interface{}))
+	if diags.HasError() {
+		return nil, diags
+	}
+	os := utils.FlattenMap(map[string]interface{}{"analysis": o})
+	ns := utils.FlattenMap(map[string]interface{}{"analysis": n})
+	changes := make(map[string]interface{})
+	for k := range os {
+		if _, ok := ns[k]; !ok {
+			changes[k] = nil
+		}
+	}
+	for k, v := range ns {
+		if ov, ok := os[k]; !ok || !reflect.DeepEqual(ov, v) {
+			changes[k] = v
+		}
+	}
+	return changes, nil
+}
+
+// expandAnalysis converts the analysis block into the `index.analysis` settings object.
+func expandAnalysis(definedAnalysis []interface{}) (map[string]interface{}, diag.Diagnostics) {
+	analysis := make(map[string]interface{})
+	if len(definedAnalysis) == 0 || definedAnalysis[0] == nil {
+		return analysis, nil
+	}
+	a := definedAnalysis[0].(map[string]interface{})
+	for _, section := range analysisSections {
+		components := make(map[string]interface{})
+		for _, c := range a[section].([]interface{}) {
+			component := c.(map[string]interface{})
+			definition := make(map[string]interface{})
+			if s, ok := component["settings"]; ok && s.(string) != "" {
+				if err := json.Unmarshal([]byte(s.(string)), &definition); err != nil {
+					return nil, diag.FromErr(err)
+				}
+			}
+			for k, v := range component {
+				switch k {
+				case "name", "settings":
+					continue
+				case "filter", "char_filter":
+					if refs := v.([]interface{}); len(refs) > 0 {
+						definition[k] = refs
+					}
+				default:
+					if v.(string) != "" {
+						definition[k] = v
+					}
+				}
+			}
+			components[component["name"].(string)] = definition
+		}
+		if len(components) > 0 {
+			analysis[section] = components
+		}
+	}
+	return analysis, nil
+}
+
+// flattenAnalysis converts the `index.analysis` settings object into the analysis block.
+// The components are kept in the same order as they are currently defined, to avoid diffs caused by the server side ordering.
+func flattenAnalysis(analysis map[string]interface{}, currentAnalysis []interface{}) ([]interface{}, diag.Diagnostics) {
+	current := make(map[string]interface{})
+	if len(currentAnalysis) > 0 && currentAnalysis[0] != nil {
+		current = currentAnalysis[0].(map[string]interface{})
+	}
+
+	a := make(map[string]interface{})
+	for _, section := range analysisSections {
+		definitions, ok := analysis[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		names := make([]string, 0, len(definitions))
+		known := make(map[string]bool)
+		if cs, ok := current[section].([]interface{}); ok {
+			for _, c := range cs {
+				if c == nil {
+					continue
+				}
+				name := c.(map[string]interface{})["name"].(string)
+				if _, ok := definitions[name]; ok && !known[name] {
+					names = append(names, name)
+					known[name] = true
+				}
+			}
+		}
+		rest := make([]string, 0)
+		for name := range definitions {
+			if !known[name] {
+				rest = append(rest, name)
+			}
+		}
+		sort.Strings(rest)
+		names = append(names, rest...)
+
+		components := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			definition, ok := definitions[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			component := map[string]interface{}{"name": name}
+			settings := make(map[string]interface{})
+			for k, v := range definition {
+				switch {
+				case k == "type":
+					component[k] = v
+				case k == "tokenizer" && section == "analyzer":
+					component[k] = v
+				case (k == "filter" || k == "char_filter") && (section == "analyzer" || section == "normalizer"):
+					component[k] = referencesList(v)
+				default:
+					settings[k] = v
+				}
+			}
+			if len(settings) > 0 {
+				s, err := json.Marshal(settings)
+				if err != nil {
+					return nil, diag.FromErr(err)
+				}
+				component["settings"] = string(s)
+			}
+			components = append(components, component)
+		}
+		a[section] = components
+	}
+	return []interface{}{a}, nil
+}
+
+// extractAnalysisSettings splits the given index settings into the analysis object and the rest of the settings.
+// It supports the flat (e.g. `index.analysis.analyzer.my_analyzer.type`) as well as the nested settings format.
+func extractAnalysisSettings(settings map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	analysis := make(map[string]interface{})
+	rest := make(map[string]interface{})
+	for k, v := range utils.FlattenMap(settings) {
+		key := strings.TrimPrefix(k, "index.")
+		if !strings.HasPrefix(key, "analysis.") {
+			rest[k] = v
+			continue
+		}
+		// <section>.<component name>.<parameter>
+		parts := strings.SplitN(strings.TrimPrefix(key, "analysis."), ".", 3)
+		if len(parts) != 3 {
+			rest[k] = v
+			continue
+		}
+		section, ok := analysis[parts[0]].(map[string]interface{})
+		if !ok {
+			section = make(map[string]interface{})
+			analysis[parts[0]] = section
+		}
+		component, ok := section[parts[1]].(map[string]interface{})
+		if !ok {
+			component = make(map[string]interface{})
+			section[parts[1]] = component
+		}
+		component[parts[2]] = v
+	}
+	return analysis, rest
+}
+
+// validateAnalysis checks that the analyzers and normalizers reference only the built-in or defined components.
+func validateAnalysis(key string, definedAnalysis []interface{}) error {
+	if len(definedAnalysis) == 0 || definedAnalysis[0] == nil {
+		return nil
+	}
+	a := definedAnalysis[0].(map[string]interface{})
+
+	defined := make(map[string]map[string]bool)
+	for _, section := range []string{"tokenizer", "filter", "char_filter"} {
+		defined[section] = make(map[string]bool)
+		for _, c := range a[section].([]interface{}) {
+			defined[section][c.(map[string]interface{})["name"].(string)] = true
+		}
+	}
+	builtins := map[string][]string{
+		"tokenizer":   builtinTokenizers,
+		"filter":      builtinFilters,
+		"char_filter": builtinCharFilters,
+	}
+	isKnown := func(section, name string) bool {
+		if defined[section][name] {
+			return true
+		}
+		for _, b := range builtins[section] {
+			if b == name {
+				return true
+			}
+		}
+		for _, p := range pluginComponentPrefixes {
+			if strings.HasPrefix(name, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var errs []string
+	for _, section := range []string{"analyzer", "normalizer"} {
+		for i, c := range a[section].([]interface{}) {
+			component := c.(map[string]interface{})
+			path := fmt.Sprintf("%s.0.%s.%d", key, section, i)
+			if tokenizer, ok := component["tokenizer"]; ok && tokenizer.(string) != "" && !isKnown("tokenizer", tokenizer.(string)) {
+				errs = append(errs, fmt.Sprintf(`%s: tokenizer "%s" is not defined`, path, tokenizer))
+			}
+			for _, kind := range []string{"filter", "char_filter"} {
+				for _, ref := range component[kind].([]interface{}) {
+					if !isKnown(kind, ref.(string)) {
+						errs = append(errs, fmt.Sprintf(`%s: %s "%s" is not defined`, path, kind, ref))
+					}
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("undefined analysis components are referenced:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// expandTemplateAnalysis merges the analysis block of the template into the template settings.
+func expandTemplateAnalysis(definedTempl map[string]interface{}, templ *models.Template) diag.Diagnostics {
+	definedAnalysis, ok := definedTempl["analysis"].([]interface{})
+	if !ok || len(definedAnalysis) == 0 {
+		return nil
+	}
+	analysis, diags := expandAnalysis(definedAnalysis)
+	if diags.HasError() {
+		return diags
+	}
+	if templ.Settings == nil {
+		templ.Settings = make(map[string]interface{})
+	}
+	if existing, _ := extractAnalysisSettings(templ.Settings); len(existing) > 0 {
+		return diag.FromErr(fmt.Errorf("the analysis is already defined in the `settings`, please remove it from `settings` to use the `analysis` block"))
+	}
+	templ.Settings["analysis"] = analysis
+	return nil
+}
+
+func referencesList(v interface{}) []interface{} {
+	switch refs := v.(type) {
+	case []interface{}:
+		return refs
+	case string:
+		return []interface{}{refs}
+	}
+	return nil
+}
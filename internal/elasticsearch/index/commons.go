@@ -1,9 +1,15 @@
 package index
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -80,3 +86,133 @@ func FlattenIndexAlias(name string, alias models.IndexAlias) (interface{}, diag.
 
 	return a, diags
 }
+
+const (
+	indexTemplateResourceType     = "elasticstack_elasticsearch_index_template"
+	componentTemplateResourceType = "elasticstack_elasticsearch_component_template"
+)
+
+// importTemplate returns the import function for the template resources. It accepts the `<cluster_uuid>/<template_name>`
+// as well as the plain `<template_name>` ID, and detects which kind of template the name refers to,
+// so the user is pointed to the right resource type if the template cannot be managed by the imported one.
+func importTemplate(resourceType string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		client, diags := clients.NewApiClient(d, meta)
+		if diags.HasError() {
+			return nil, utils.DiagsAsError(diags)
+		}
+
+		templateName := d.Id()
+		if strings.Contains(templateName, "/") {
+			compId, diags := clients.CompositeIdFromStr(templateName)
+			if diags.HasError() {
+				return nil, utils.DiagsAsError(diags)
+			}
+			templateName = compId.ResourceId
+		}
+		if diags := client.CheckResourceNamePrefix(templateName); diags.HasError() {
+			return nil, utils.DiagsAsError(diags)
+		}
+		id, diags := client.ID(ctx, templateName)
+		if diags.HasError() {
+			return nil, utils.DiagsAsError(diags)
+		}
+
+		kind, diags := detectTemplateKind(ctx, client, templateName)
+		if diags.HasError() {
+			return nil, utils.DiagsAsError(diags)
+		}
+		switch kind {
+		case resourceType:
+			d.SetId(id.String())
+			return []*schema.ResourceData{d}, nil
+		case "":
+			return nil, fmt.Errorf(`template "%s" not found`, templateName)
+		case "legacy":
+			return nil, fmt.Errorf(`"%s" is a legacy index template (_template API), which is not supported by %s. Migrate it to a composable index template and import it using %s`, templateName, resourceType, indexTemplateResourceType)
+		default:
+			return nil, fmt.Errorf(`"%s" is not a template managed by %s, import it using the %s resource instead`, templateName, resourceType, kind)
+		}
+	}
+}
+
+// detectTemplateKind returns the resource type managing the template with the given name,
+// "legacy" for the legacy index templates or an empty string if the template does not exist.
+func detectTemplateKind(ctx context.Context, client *clients.ApiClient, templateName string) (string, diag.Diagnostics) {
+	indexTemplate, diags := elasticsearch.GetIndexTemplate(ctx, client, templateName)
+	if diags.HasError() {
+		return "", diags
+	}
+	if indexTemplate != nil {
+		return indexTemplateResourceType, nil
+	}
+
+	componentTemplate, diags := elasticsearch.GetComponentTemplate(ctx, client, templateName)
+	if diags.HasError() {
+		return "", diags
+	}
+	if componentTemplate != nil {
+		return componentTemplateResourceType, nil
+	}
+
+	exists, diags := elasticsearch.LegacyIndexTemplateExists(ctx, client, templateName)
+	if diags.HasError() {
+		return "", diags
+	}
+	if exists {
+		return "legacy", nil
+	}
+	return "", nil
+}
+
+func ignoreSettingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Setting keys (e.g. `index.routing.allocation.include._tier_preference`) or prefixes ending with `*` (e.g. `index.routing.*`), which values injected by Elasticsearch are ignored when detecting the drift of the template settings. The ignored settings defined in the `settings` are still compared.",
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// filterIgnoredSettings removes the ignored settings from the settings read from the cluster,
+// unless they are part of the current settings and therefore managed by the resource.
+func filterIgnoredSettings(settings, current map[string]interface{}, ignored []string) map[string]interface{} {
+	if len(ignored) == 0 {
+		return settings
+	}
+	currentSettings := utils.NormalizeIndexSettings(utils.FlattenMap(current))
+	filtered := make(map[string]interface{})
+	for k, v := range utils.FlattenMap(settings) {
+		key := normalizeSettingKey(k)
+		if _, ok := currentSettings[key]; !ok && isIgnoredSetting(key, ignored) {
+			continue
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
+
+func isIgnoredSetting(key string, ignored []string) bool {
+	for _, i := range ignored {
+		pattern := normalizeSettingKey(i)
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+			continue
+		}
+		if key == pattern || strings.HasPrefix(key, pattern+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeSettingKey(key string) string {
+	if strings.HasPrefix(key, "index.") {
+		return key
+	}
+	return "index." + key
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -30,12 +31,13 @@ func ResourceComponentTemplate() *schema.Resource {
 			Required:    true,
 			ForceNew:    true,
 		},
+		"ignore_settings": ignoreSettingsSchema(),
 		"metadata": {
-			Description:      "Optional user metadata about the component template.",
+			Description:      "Optional user metadata about the component template. The metadata marking the component template as managed by another system (`managed` is `true`, e.g. set by Fleet) is kept when not configured.",
 			Type:             schema.TypeString,
 			Optional:         true,
 			ValidateFunc:     validation.StringIsJSON,
-			DiffSuppressFunc: utils.DiffJsonSuppress,
+			DiffSuppressFunc: utils.DiffManagedMetadataSuppress,
 		},
 		"template": {
 			Description: "Template to be applied. It may optionally include an aliases, mappings, or settings configuration.",
@@ -92,6 +94,7 @@ func ResourceComponentTemplate() *schema.Resource {
 							},
 						},
 					},
+					"analysis": analysisSchema(),
 					"mappings": {
 						Description:      "Mapping for fields in the index.",
 						Type:             schema.TypeString,
@@ -99,6 +102,10 @@ func ResourceComponentTemplate() *schema.Resource {
 						DiffSuppressFunc: utils.DiffJsonSuppress,
 						ValidateFunc:     validation.StringIsJSON,
 					},
+					"mapping_dynamic":  mappingDynamicSchema(),
+					"mapping_source":   mappingSourceSchema(false),
+					"default_pipeline": templatePipelineSchema("default"),
+					"final_pipeline":   templatePipelineSchema("final"),
 					"settings": {
 						Description:      "Configuration options for the index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#index-modules-settings",
 						Type:             schema.TypeString,
@@ -106,17 +113,21 @@ func ResourceComponentTemplate() *schema.Resource {
 						DiffSuppressFunc: utils.DiffIndexSettingSuppress,
 						ValidateFunc:     validation.StringIsJSON,
 					},
+					"index_settings": templateIndexSettingsSchema(),
 				},
 			},
 		},
 		"version": {
-			Description: "Version number used to manage component templates externally.",
-			Type:        schema.TypeInt,
-			Optional:    true,
+			Description:      "Version number used to manage component templates externally. The version of the component template marked as managed by another system is kept when not configured.",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffManagedVersionSuppress,
 		},
 	}
 
 	utils.AddConnectionSchema(componentTemplateSchema)
+	utils.AddTimeoutsSchema(componentTemplateSchema)
+	utils.AddIgnoreManagedSchema(componentTemplateSchema)
 
 	return &schema.Resource{
 		Description: "Creates or updates a component template. Component templates are building blocks for constructing index templates that specify index mappings, settings, and aliases. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-component-template.html",
@@ -127,9 +138,17 @@ func ResourceComponentTemplate() *schema.Resource {
 		DeleteContext: resourceComponentTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importTemplate(componentTemplateResourceType),
 		},
 
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAnalysis("template.0.analysis", d.Get("template.0.analysis").([]interface{}))
+			},
+			validateTemplateSortDiff("template.0.settings"),
+			validateTemplatePipelineReferences,
+		),
+
 		Schema: componentTemplateSchema,
 	}
 }
@@ -139,11 +158,26 @@ func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, m
 	if diags.HasError() {
 		return diags
 	}
-	componentId := d.Get("name").(string)
+	componentId, diags := client.TargetName(d)
+	if diags.HasError() {
+		return diags
+	}
 	id, diags := client.ID(ctx, componentId)
 	if diags.HasError() {
 		return diags
 	}
+	if d.Get("ignore_managed").(bool) {
+		existing, diags := elasticsearch.GetComponentTemplate(ctx, client, componentId)
+		if diags.HasError() {
+			return diags
+		}
+		if existing != nil {
+			if diags := utils.CheckManaged(d, "component template", componentId, existing.ComponentTemplate.Meta); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
 	var componentTemplate models.ComponentTemplate
 	componentTemplate.Name = componentId
 
@@ -155,6 +189,7 @@ func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, m
 		componentTemplate.Meta = metadata
 	}
 
+	var pipelineDiags diag.Diagnostics
 	if v, ok := d.GetOk("template"); ok {
 		// only one template block allowed to be declared
 		definedTempl := v.([]interface{})[0].(map[string]interface{})
@@ -210,6 +245,22 @@ func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, m
 				templ.Settings = sets
 			}
 		}
+		if diags := expandTemplateAnalysis(definedTempl, &templ); diags.HasError() {
+			return diags
+		}
+		if diags := expandTemplateMappingOptions(definedTempl, &templ); diags.HasError() {
+			return diags
+		}
+		if diags := expandTemplatePipelines(definedTempl, &templ); diags.HasError() {
+			return diags
+		}
+		if diags := expandTemplateIndexSettings(d, &templ); diags.HasError() {
+			return diags
+		}
+		pipelineDiags = checkPipelineReferences(ctx, client, templatePipelines(definedTempl))
+		if pipelineDiags.HasError() {
+			return pipelineDiags
+		}
 
 		componentTemplate.Template = &templ
 	}
@@ -224,7 +275,7 @@ func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(id.String())
-	return resourceComponentTemplateRead(ctx, d, meta)
+	return append(pipelineDiags, resourceComponentTemplateRead(ctx, d, meta)...)
 }
 
 func resourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -249,7 +300,11 @@ func resourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// set the fields
-	if err := d.Set("name", tpl.Name); err != nil {
+	// the object marked as managed since it was created is not refreshed, so that it can still be destroyed
+	if diags := utils.WarnManaged(d, "component template", tpl.Name, tpl.ComponentTemplate.Meta); len(diags) > 0 {
+		return diags
+	}
+	if err := d.Set("name", client.ConfiguredName(tpl.Name)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -264,7 +319,7 @@ func resourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if tpl.ComponentTemplate.Template != nil {
-		template, diags := flattenTemplateData(tpl.ComponentTemplate.Template)
+		template, diags := flattenTemplateData(tpl.ComponentTemplate.Template, d)
 		if diags.HasError() {
 			return diags
 		}
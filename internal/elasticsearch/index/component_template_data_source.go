@@ -0,0 +1,142 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceComponentTemplate() *schema.Resource {
+	componentTemplateSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the component templates to get. Supports wildcards and comma-separated lists.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"component_templates": {
+			Description: "The matching component templates, sorted by their name. Empty if none matches.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the component template.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"version": {
+						Description: "Version number of the component template.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"metadata": {
+						Description: "JSON object with the user metadata of the component template.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"aliases": {
+						Description: "JSON object with the aliases of the template, keyed by the alias name.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"mappings": {
+						Description: "JSON object with the mappings of the template.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"settings": {
+						Description: "JSON object with the index settings of the template.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(componentTemplateSchema)
+
+	return &schema.Resource{
+		Description: "Gets the component templates, e.g. to check that the components exist before composing an index template. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/getting-component-templates.html",
+		ReadContext: dataSourceComponentTemplateRead,
+		Schema:      componentTemplateSchema,
+	}
+}
+
+func dataSourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+
+	tpls, diags := elasticsearch.GetComponentTemplates(ctx, client, name)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Slice(tpls, func(i, j int) bool { return tpls[i].Name < tpls[j].Name })
+
+	componentTemplates := make([]interface{}, len(tpls))
+	for i, tpl := range tpls {
+		componentTemplate, err := flattenComponentTemplate(tpl)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		componentTemplates[i] = componentTemplate
+	}
+	if err := d.Set("component_templates", componentTemplates); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func flattenComponentTemplate(tpl models.ComponentTemplateResponse) (map[string]interface{}, error) {
+	componentTemplate := map[string]interface{}{
+		"name": tpl.Name,
+	}
+	if tpl.ComponentTemplate.Version != nil {
+		componentTemplate["version"] = *tpl.ComponentTemplate.Version
+	}
+
+	objects := make(map[string]interface{})
+	if len(tpl.ComponentTemplate.Meta) > 0 {
+		objects["metadata"] = tpl.ComponentTemplate.Meta
+	}
+	if t := tpl.ComponentTemplate.Template; t != nil {
+		if len(t.Aliases) > 0 {
+			objects["aliases"] = t.Aliases
+		}
+		if len(t.Mappings) > 0 {
+			objects["mappings"] = t.Mappings
+		}
+		if len(t.Settings) > 0 {
+			objects["settings"] = t.Settings
+		}
+	}
+	for key, object := range objects {
+		j, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		componentTemplate[key] = string(j)
+	}
+	return componentTemplate, nil
+}
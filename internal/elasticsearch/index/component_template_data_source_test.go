@@ -0,0 +1,86 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceComponentTemplate(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceComponentTemplate(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.one", "component_templates.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.one", "component_templates.0.name", templateName+"-settings"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.one", "component_templates.0.version", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.one", "component_templates.0.metadata", `{"owner":"test"}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.one", "component_templates.0.settings", `{"index":{"number_of_shards":"3"}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.all", "component_templates.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.all", "component_templates.0.name", templateName+"-mappings"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.all", "component_templates.0.mappings", `{"properties":{"message":{"type":"text"}}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.missing", "component_templates.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceComponentTemplate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_component_template" "settings" {
+  name    = "%[1]s-settings"
+  version = 2
+  metadata = jsonencode({
+    owner = "test"
+  })
+
+  template {
+    settings = jsonencode({
+      number_of_shards = "3"
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_component_template" "mappings" {
+  name = "%[1]s-mappings"
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        message = { type = "text" }
+      }
+    })
+  }
+}
+
+data "elasticstack_elasticsearch_component_template" "one" {
+  name = elasticstack_elasticsearch_component_template.settings.name
+}
+
+data "elasticstack_elasticsearch_component_template" "all" {
+  name = "%[1]s-*"
+
+  depends_on = [
+    elasticstack_elasticsearch_component_template.settings,
+    elasticstack_elasticsearch_component_template.mappings,
+  ]
+}
+
+data "elasticstack_elasticsearch_component_template" "missing" {
+  name = "%[1]s-missing-*"
+}
+`, name)
+}
@@ -2,6 +2,7 @@ package index_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -28,6 +29,52 @@ func TestAccResourceComponentTemplate(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "template.0.settings", `{"index":{"number_of_shards":"3"}}`),
 				),
 			},
+			{
+				// the import by the plain template name
+				ResourceName:            "elasticstack_elasticsearch_component_template.test",
+				ImportState:             true,
+				ImportStateId:           templateName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_managed"},
+			},
+		},
+	})
+}
+
+func TestAccResourceComponentTemplateIgnoreSettings(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceComponentTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceComponentTemplateIgnoreSettings(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test_ignore", "ignore_settings.#", "1"),
+				),
+			},
+			{
+				// the settings injected outside of Terraform must not produce any diff
+				PreConfig: func() {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					body := `{"template": {"settings": {"index": {"number_of_shards": "1", "routing": {"allocation": {"include": {"_tier_preference": "data_hot"}}}}}}}`
+					res, err := client.GetESClient().Cluster.PutComponentTemplate(templateName, strings.NewReader(body))
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer res.Body.Close()
+					if res.IsError() {
+						t.Fatalf("unable to update the component template: %s", res.String())
+					}
+				},
+				Config:   testAccResourceComponentTemplateIgnoreSettings(templateName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -53,6 +100,24 @@ resource "elasticstack_elasticsearch_component_template" "test" {
 }`, name)
 }
 
+func testAccResourceComponentTemplateIgnoreSettings(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_component_template" "test_ignore" {
+  name            = "%s"
+  ignore_settings = ["index.routing.*"]
+
+  template {
+    settings = jsonencode({
+      number_of_shards = "1"
+    })
+  }
+}`, name)
+}
+
 func checkResourceComponentTemplateDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,6 +36,19 @@ func ResourceDataStream() *schema.Resource {
 				validation.StringMatch(regexp.MustCompile(`^[a-z0-9!$%&'()+.;=@[\]^{}~_-]+$`), "must contain lower case alphanumeric characters and selected punctuation, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-data-stream.html#indices-create-data-stream-api-path-params"),
 			),
 		},
+		"backing_index_settings": {
+			Description:      "Settings applied to all the current backing indices of the data stream, e.g. to change the `index.number_of_replicas` of the existing indices. Only the dynamic settings can be changed on the existing indices. The settings are read from the write index.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffIndexSettingSuppress,
+		},
+		"update_template": {
+			Description: "If `true`, the `backing_index_settings` are also set on the index template of the data stream, so they apply to the future backing indices. Don't use it with an index template managed by Terraform.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
 		"timestamp_field": {
 			Description: "Contains information about the data stream’s @timestamp field.",
 			Type:        schema.TypeString,
@@ -101,6 +116,7 @@ func ResourceDataStream() *schema.Resource {
 	}
 
 	utils.AddConnectionSchema(dataStreamSchema)
+	utils.AddTimeoutsSchema(dataStreamSchema)
 
 	return &schema.Resource{
 		Description: "Managing Elasticsearch data streams, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/data-stream-apis.html",
@@ -111,7 +127,7 @@ func ResourceDataStream() *schema.Resource {
 		DeleteContext: resourceDataStreamDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStatePrefixedPassthroughContext,
 		},
 
 		Schema: dataStreamSchema,
@@ -123,20 +139,87 @@ func resourceDataStreamPut(ctx context.Context, d *schema.ResourceData, meta int
 	if diags.HasError() {
 		return diags
 	}
-	dsId := d.Get("name").(string)
+	dsId, diags := client.TargetName(d)
+	if diags.HasError() {
+		return diags
+	}
 	id, diags := client.ID(ctx, dsId)
 	if diags.HasError() {
 		return diags
 	}
 
-	if diags := elasticsearch.PutDataStream(ctx, client, dsId); diags.HasError() {
-		return diags
+	if d.IsNewResource() {
+		if diags := elasticsearch.PutDataStream(ctx, client, dsId); diags.HasError() {
+			return diags
+		}
+		d.SetId(id.String())
+	}
+
+	if d.HasChanges("backing_index_settings", "update_template") {
+		if diags := updateBackingIndexSettings(ctx, client, d, dsId); diags.HasError() {
+			return diags
+		}
 	}
 
-	d.SetId(id.String())
 	return resourceDataStreamRead(ctx, d, meta)
 }
 
+// updateBackingIndexSettings applies the settings to each backing index, to report all the indices which can't be updated, e.g. when changing a static setting.
+func updateBackingIndexSettings(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData, dsId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	v, ok := d.GetOk("backing_index_settings")
+	if !ok {
+		return diags
+	}
+	settings := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(v.(string)), &settings); err != nil {
+		return diag.FromErr(err)
+	}
+	settings = flattenIndexSettingKeys(settings)
+
+	ds, diags := elasticsearch.GetDataStream(ctx, client, dsId)
+	if diags.HasError() {
+		return diags
+	}
+	if ds == nil {
+		return diag.Errorf(`data stream "%s" not found`, dsId)
+	}
+
+	for _, idx := range ds.Indices {
+		for _, diagnostic := range elasticsearch.UpdateIndexSettings(ctx, client, idx.IndexName, settings) {
+			diagnostic.Summary = fmt.Sprintf(`Unable to update the settings of the backing index "%s"`, idx.IndexName)
+			diags = append(diags, diagnostic)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.Get("update_template").(bool) {
+		tpl, diags := elasticsearch.GetIndexTemplate(ctx, client, ds.Template)
+		if diags.HasError() {
+			return diags
+		}
+		if tpl == nil {
+			return diag.Errorf(`index template "%s" of the data stream "%s" not found`, ds.Template, dsId)
+		}
+		template := tpl.IndexTemplate
+		template.Name = tpl.Name
+		if template.Template == nil {
+			template.Template = &models.Template{}
+		}
+		merged := flattenIndexSettingKeys(template.Template.Settings)
+		for k, v := range settings {
+			merged[k] = v
+		}
+		template.Template.Settings = merged
+		if diags := elasticsearch.PutIndexTemplate(ctx, client, &template); diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
 func resourceDataStreamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -159,7 +242,7 @@ func resourceDataStreamRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diags
 	}
 
-	if err := d.Set("name", ds.Name); err != nil {
+	if err := d.Set("name", client.ConfiguredName(ds.Name)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("timestamp_field", ds.TimestampField.Name); err != nil {
@@ -196,6 +279,34 @@ func resourceDataStreamRead(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
+	if v, ok := d.GetOk("backing_index_settings"); ok && len(ds.Indices) > 0 {
+		configured := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v.(string)), &configured); err != nil {
+			return diag.FromErr(err)
+		}
+		writeIndex := ds.Indices[len(ds.Indices)-1].IndexName
+		index, diags := elasticsearch.GetIndex(ctx, client, writeIndex)
+		if diags.HasError() {
+			return diags
+		}
+		if index != nil {
+			current := utils.NormalizeIndexSettings(utils.FlattenMap(index.Settings))
+			settings := make(map[string]interface{})
+			for k := range utils.NormalizeIndexSettings(utils.FlattenMap(configured)) {
+				if value, ok := current[k]; ok {
+					settings[k] = value
+				}
+			}
+			settingsBytes, err := json.Marshal(settings)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set("backing_index_settings", string(settingsBytes)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	indices := make([]interface{}, len(ds.Indices))
 	for i, idx := range ds.Indices {
 		index := make(map[string]interface{})
@@ -226,3 +337,16 @@ func resourceDataStreamDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	return diags
 }
+
+// flattenIndexSettingKeys flattens the settings to the `index.` prefixed keys, keeping the values as they are,
+// so the nested and the dotted notations of the same setting don't conflict.
+func flattenIndexSettingKeys(settings map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+	for k, v := range utils.FlattenMap(settings) {
+		if !strings.HasPrefix(k, "index.") {
+			k = "index." + k
+		}
+		out[k] = v
+	}
+	return out
+}
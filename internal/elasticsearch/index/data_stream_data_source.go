@@ -0,0 +1,122 @@
+package index
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceDataStream() *schema.Resource {
+	dataStreamSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the data stream.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"timestamp_field": {
+			Description: "Contains information about the data stream’s @timestamp field.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"indices": {
+			Description: "Array of objects containing information about the data stream’s backing indices, ordered by the generation. The last item in this array contains information about the stream’s current write index.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index_name": {
+						Description: "Name of the backing index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"index_uuid": {
+						Description: "Universally unique identifier (UUID) for the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"generation": {
+			Description: "Current generation for the data stream.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"metadata": {
+			Description: "Custom metadata for the stream, copied from the _meta object of the stream’s matching index template.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "Health status of the data stream.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"template": {
+			Description: "Name of the index template used to create the data stream’s backing indices.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ilm_policy": {
+			Description: "Name of the current ILM lifecycle policy in the stream’s matching index template.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"hidden": {
+			Description: "If `true`, the data stream is hidden.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"system": {
+			Description: "If `true`, the data stream is created and managed by an Elastic stack component and cannot be modified through normal user interaction.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"replicated": {
+			Description: "If `true`, the data stream is created and managed by cross-cluster replication and the local cluster can not write into this data stream or change its mappings.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(dataStreamSchema)
+
+	return &schema.Resource{
+		Description: "Gets the information about the data stream, e.g. its backing indices and generation. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-data-stream.html",
+
+		ReadContext: dataSourceDataStreamRead,
+
+		Schema: dataStreamSchema,
+	}
+}
+
+func dataSourceDataStreamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	dsId := d.Get("name").(string)
+	id, diags := client.ID(ctx, dsId)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+
+	diags = resourceDataStreamRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		// the data stream is not found, the ID being reset by the resource read
+		return diag.Errorf(`Data stream "%s" not found`, dsId)
+	}
+	return diags
+}
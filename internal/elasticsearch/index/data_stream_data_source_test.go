@@ -0,0 +1,76 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDataStream(t *testing.T) {
+	dsName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDataStream(dsName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_data_stream.test", "name", dsName),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_data_stream.test", "generation", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_data_stream.test", "template", dsName),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_data_stream.test", "indices.#", "1"),
+					resource.TestCheckResourceAttrPair("data.elasticstack_elasticsearch_data_stream.test", "indices.0.index_name", "elasticstack_elasticsearch_data_stream.test", "indices.0.index_name"),
+				),
+			},
+			{
+				Config:      testAccDataSourceDataStreamNotFound(dsName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Data stream "%s-missing" not found`, dsName)),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDataStream(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name = "%s"
+
+  index_patterns = ["%s*"]
+
+  data_stream {}
+}
+
+resource "elasticstack_elasticsearch_data_stream" "test" {
+  name = "%s"
+
+  depends_on = [
+    elasticstack_elasticsearch_index_template.test
+  ]
+}
+
+data "elasticstack_elasticsearch_data_stream" "test" {
+  name = elasticstack_elasticsearch_data_stream.test.name
+}
+	`, name, name, name)
+}
+
+func testAccDataSourceDataStreamNotFound(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_data_stream" "test" {
+  name = "%s-missing"
+}
+	`, name)
+}
@@ -2,6 +2,7 @@ package index_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -89,6 +90,61 @@ resource "elasticstack_elasticsearch_data_stream" "test_ds" {
 	`, name, name, name, name)
 }
 
+func TestAccResourceDataStreamBackingIndexSettings(t *testing.T) {
+	dsName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceDataStreamDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDataStreamBackingIndexSettings(dsName, `"index.number_of_replicas" = "0"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream.test_ds", "backing_index_settings", `{"index.number_of_replicas":"0"}`),
+				),
+			},
+			{
+				Config: testAccResourceDataStreamBackingIndexSettings(dsName, `"index.number_of_replicas" = "0", "index.refresh_interval" = "10s"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_data_stream.test_ds", "backing_index_settings", `{"index.number_of_replicas":"0","index.refresh_interval":"10s"}`),
+				),
+			},
+			{
+				// static settings can't be changed on the existing backing indices
+				Config:      testAccResourceDataStreamBackingIndexSettings(dsName, `"index.codec" = "best_compression"`),
+				ExpectError: regexp.MustCompile(`Unable to update the settings of the backing index ".ds-`),
+			},
+		},
+	})
+}
+
+func testAccResourceDataStreamBackingIndexSettings(name, settings string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_ds_template" {
+  name = "%s"
+
+  index_patterns = ["%s*"]
+
+  data_stream {}
+}
+
+resource "elasticstack_elasticsearch_data_stream" "test_ds" {
+  name = "%s"
+
+  backing_index_settings = jsonencode({ %s })
+
+  depends_on = [
+    elasticstack_elasticsearch_index_template.test_ds_template
+  ]
+}
+	`, name, name, name, settings)
+}
+
 func checkResourceDataStreamDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
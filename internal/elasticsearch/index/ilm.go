@@ -97,6 +97,7 @@ func ResourceIlm() *schema.Resource {
 	}
 
 	utils.AddConnectionSchema(ilmSchema)
+	utils.AddTimeoutsSchema(ilmSchema)
 
 	return &schema.Resource{
 		Description: "Creates or updates lifecycle policy. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-index-lifecycle.html",
@@ -107,9 +108,11 @@ func ResourceIlm() *schema.Resource {
 		DeleteContext: resourceIlmDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStatePrefixedPassthroughContext,
 		},
 
+		CustomizeDiff: validateIlmPolicy,
+
 		Schema: ilmSchema,
 	}
 }
@@ -254,9 +257,11 @@ var supportedActions = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"max_age": {
-					Description: "Triggers rollover after the maximum elapsed time from index creation is reached.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:      "Triggers rollover after the maximum elapsed time from index creation is reached.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     utils.StringIsTimeValue,
+					DiffSuppressFunc: utils.DiffTimeValueSuppress,
 				},
 				"max_docs": {
 					Description: "Triggers rollover after the specified maximum number of documents is reached.",
@@ -274,9 +279,11 @@ var supportedActions = map[string]*schema.Schema{
 					Optional:    true,
 				},
 				"min_age": {
-					Description: "Prevents rollover until after the minimum elapsed time from index creation is reached. Supported from Elasticsearch version **8.4**",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:      "Prevents rollover until after the minimum elapsed time from index creation is reached. Supported from Elasticsearch version **8.4**",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     utils.StringIsTimeValue,
+					DiffSuppressFunc: utils.DiffTimeValueSuppress,
 				},
 				"min_docs": {
 					Description: "Prevents rollover until after the specified minimum number of documents is reached. Supported from Elasticsearch version **8.4**",
@@ -400,10 +407,12 @@ func getSchema(actions ...string) map[string]*schema.Schema {
 	}
 	// min age can be set for all the phases
 	sch["min_age"] = &schema.Schema{
-		Description: "ILM moves indices through the lifecycle according to their age. To control the timing of these transitions, you set a minimum age for each phase.",
-		Type:        schema.TypeString,
-		Optional:    true,
-		Computed:    true,
+		Description:      "ILM moves indices through the lifecycle according to their age. To control the timing of these transitions, you set a minimum age for each phase.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateFunc:     utils.StringIsTimeValue,
+		DiffSuppressFunc: utils.DiffTimeValueSuppress,
 	}
 	return sch
 }
@@ -413,7 +422,10 @@ func resourceIlmPut(ctx context.Context, d *schema.ResourceData, meta interface{
 	if diags.HasError() {
 		return diags
 	}
-	ilmId := d.Get("name").(string)
+	ilmId, diags := client.TargetName(d)
+	if diags.HasError() {
+		return diags
+	}
 	id, diags := client.ID(ctx, ilmId)
 	if diags.HasError() {
 		return diags
@@ -559,7 +571,7 @@ func expandAction(a []interface{}, serverVersion *version.Version, settings ...s
 			if v, ok := action.(map[string]interface{})[setting]; ok && v != nil {
 				options := ilmActionSettingOptions[setting]
 
-				if options.minVersion != nil && options.minVersion.GreaterThan(serverVersion) {
+				if options.minVersion != nil && serverVersion != nil && options.minVersion.GreaterThan(serverVersion) {
 					if v != options.def {
 						return nil, diag.Errorf("[%s] is not supported in the target Elasticsearch server. Remove the setting from your module definition or set it to the default [%s] value", setting, options.def)
 					}
@@ -621,7 +633,7 @@ func resourceIlmRead(ctx context.Context, d *schema.ResourceData, meta interface
 			return diag.FromErr(err)
 		}
 	}
-	if err := d.Set("name", policyId); err != nil {
+	if err := d.Set("name", client.ConfiguredName(policyId)); err != nil {
 		return diag.FromErr(err)
 	}
 	for _, ph := range supportedIlmPhases {
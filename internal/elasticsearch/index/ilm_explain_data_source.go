@@ -0,0 +1,183 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIlmExplain() *schema.Resource {
+	explainSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Name of the index, data stream or alias to explain. Supports wildcards and comma-separated lists.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"only_errors": {
+			Description: "Returns only the indices which are in the error state, either due to an encountered failure while executing the policy, or attempting to use a policy that does not exist.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"only_managed": {
+			Description: "Returns only the indices which are managed by ILM.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"indices": {
+			Description: "The lifecycle state of the matching indices, sorted by the index name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Description: "Name of the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"managed": {
+						Description: "Whether the index is managed by ILM.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"policy": {
+						Description: "Name of the ILM policy applied to the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"age": {
+						Description: "Age of the index since its creation or the rollover.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"phase": {
+						Description: "Current phase of the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"action": {
+						Description: "Current action of the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"step": {
+						Description: "Current step of the index, `ERROR` if the execution of the policy failed.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"failed_step": {
+						Description: "The step which failed, if the index is in the `ERROR` step.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"failed_step_retry_count": {
+						Description: "How many times the failed step has been retried.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"is_auto_retryable_error": {
+						Description: "Whether the failed step is retried automatically.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"step_info": {
+						Description: "JSON object with the details of the current step, e.g. the cause of the failure.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"error": {
+						Description: "The type and reason of the failure, if the index is in the `ERROR` step.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(explainSchema)
+
+	return &schema.Resource{
+		Description: "Gets the current lifecycle state of the indices, e.g. to find out why an index is stuck in a phase. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-explain-lifecycle.html",
+		ReadContext: dataSourceIlmExplainRead,
+		Schema:      explainSchema,
+	}
+}
+
+func dataSourceIlmExplainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	index := d.Get("index").(string)
+	id, diags := client.ID(ctx, index)
+	if diags.HasError() {
+		return diags
+	}
+
+	explained, diags := elasticsearch.ExplainIlm(ctx, client, index, d.Get("only_errors").(bool), d.Get("only_managed").(bool))
+	if diags.HasError() {
+		return diags
+	}
+
+	names := make([]string, 0, len(explained))
+	for name := range explained {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indices := make([]interface{}, len(names))
+	for i, name := range names {
+		explain, err := flattenIlmExplain(name, explained[name])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		indices[i] = explain
+	}
+	if err := d.Set("indices", indices); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func flattenIlmExplain(name string, explain models.IlmExplain) (map[string]interface{}, error) {
+	e := map[string]interface{}{
+		"index":                   name,
+		"managed":                 explain.Managed,
+		"policy":                  explain.Policy,
+		"age":                     explain.Age,
+		"phase":                   explain.Phase,
+		"action":                  explain.Action,
+		"step":                    explain.Step,
+		"failed_step":             explain.FailedStep,
+		"failed_step_retry_count": explain.FailedStepRetryCount,
+		"is_auto_retryable_error": explain.IsAutoRetryableError,
+	}
+	if explain.StepInfo != nil {
+		stepInfo, err := json.Marshal(explain.StepInfo)
+		if err != nil {
+			return nil, err
+		}
+		e["step_info"] = string(stepInfo)
+		if reason, ok := explain.StepInfo["reason"].(string); ok && explain.FailedStep != "" {
+			e["error"] = fmt.Sprintf("%v: %s", explain.StepInfo["type"], reason)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,72 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIlmExplain(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIlmExplain(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_explain.test", "indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_explain.test", "indices.0.index", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_explain.test", "indices.0.managed", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_explain.test", "indices.0.policy", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_explain.test", "indices.0.error", ""),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_explain.errors", "indices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIlmExplain(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    min_age = "1h"
+
+    set_priority {
+      priority = 10
+    }
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  settings {
+    setting {
+      name  = "index.lifecycle.name"
+      value = elasticstack_elasticsearch_index_lifecycle.test.name
+    }
+  }
+}
+
+data "elasticstack_elasticsearch_ilm_explain" "test" {
+  index = elasticstack_elasticsearch_index.test.name
+}
+
+data "elasticstack_elasticsearch_ilm_explain" "errors" {
+  index       = elasticstack_elasticsearch_index.test.name
+  only_errors = true
+}
+	`, name, name)
+}
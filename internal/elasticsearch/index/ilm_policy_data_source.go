@@ -0,0 +1,128 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIlmPolicy() *schema.Resource {
+	policySchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the ILM policy.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"policy": {
+			Description: "JSON object with the phases and the metadata of the policy.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"modified_date": {
+			Description: "The DateTime of the last modification.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"version": {
+			Description: "Version of the policy, incremented on each update.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"in_use_by_indices": {
+			Description: "Indices managed by the policy, sorted by name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"in_use_by_data_streams": {
+			Description: "Data streams which backing indices are managed by the policy, sorted by name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"in_use_by_composable_templates": {
+			Description: "Composable index templates referencing the policy, sorted by name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(policySchema)
+
+	return &schema.Resource{
+		Description: "Gets an index lifecycle policy together with the indices, data streams and index templates using it, e.g. to check the impact of a change to the policy before applying it. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-get-lifecycle.html",
+		ReadContext: dataSourceIlmPolicyRead,
+		Schema:      policySchema,
+	}
+}
+
+func dataSourceIlmPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+
+	ilmDef, diags := elasticsearch.GetIlmWithUsage(ctx, client, name)
+	if ilmDef == nil && diags == nil {
+		return diag.Errorf(`ILM policy "%s" not found`, name)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	policy, err := json.Marshal(ilmDef.Policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	values := map[string]interface{}{
+		"policy":                         string(policy),
+		"modified_date":                  ilmDef.Modified,
+		"version":                        ilmDef.Version,
+		"in_use_by_indices":              []string{},
+		"in_use_by_data_streams":         []string{},
+		"in_use_by_composable_templates": []string{},
+	}
+	if inUseBy := ilmDef.InUseBy; inUseBy != nil {
+		values["in_use_by_indices"] = sortedNames(inUseBy.Indices)
+		values["in_use_by_data_streams"] = sortedNames(inUseBy.DataStreams)
+		values["in_use_by_composable_templates"] = sortedNames(inUseBy.ComposableTemplates)
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf(`unable to set "%s": %w`, key, err))
+		}
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func sortedNames(names []string) []string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,69 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIlmPolicy(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIlmPolicy(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_policy.test", "name", name),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_ilm_policy.test", "policy"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_ilm_policy.test", "modified_date"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_policy.test", "in_use_by_indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_policy.test", "in_use_by_indices.0", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_ilm_policy.test", "in_use_by_data_streams.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIlmPolicy(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%[1]s"
+
+  hot {
+    min_age = "1h"
+
+    set_priority {
+      priority = 10
+    }
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%[1]s"
+
+  settings {
+    setting {
+      name  = "index.lifecycle.name"
+      value = elasticstack_elasticsearch_index_lifecycle.test.name
+    }
+  }
+}
+
+data "elasticstack_elasticsearch_ilm_policy" "test" {
+  name = elasticstack_elasticsearch_index_lifecycle.test.name
+
+  depends_on = [elasticstack_elasticsearch_index.test]
+}
+	`, name)
+}
@@ -1,7 +1,9 @@
 package index_test
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -108,6 +110,70 @@ func TestAccResourceILMRolloverConditions(t *testing.T) {
 	})
 }
 
+func TestAccResourceILMInvalidPhases(t *testing.T) {
+	policyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceILMDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceILMDecreasingMinAge(policyName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the "min_age" of the delete phase \(1d\) must not be lower than the "min_age" of the warm phase`),
+			},
+			{
+				Config:      testAccResourceILMSearchableSnapshotWithoutRollover(policyName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`requires the "rollover" action to be configured in the hot phase`),
+			},
+			{
+				Config:      testAccResourceILMForcemergeAfterSearchableSnapshot(policyName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the "forcemerge" action of the warm phase is not allowed after the "searchable_snapshot" action of the hot phase`),
+			},
+		},
+	})
+}
+
+func TestResourceILMValidationServerVersion(t *testing.T) {
+	client, requests := newTestServerClient(t)
+	state := &terraform.InstanceState{
+		ID: "cluster-uuid/policy",
+		Attributes: map[string]string{
+			"id":            "cluster-uuid/policy",
+			"name":          "policy",
+			"hot.#":         "1",
+			"hot.0.min_age": "1h",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		minAge        string
+		lookupVersion bool
+	}{
+		{name: "unchanged phases", minAge: "1h"},
+		{name: "changed phases", minAge: "2h", lookupVersion: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name": "policy",
+				"hot":  []interface{}{map[string]interface{}{"min_age": tt.minAge}},
+			}
+			before := *requests
+			if _, err := index.ResourceIlm().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), client); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := *requests > before; got != tt.lookupVersion {
+				t.Errorf("server version looked up = %v, want %v", got, tt.lookupVersion)
+			}
+		})
+	}
+}
+
 func testAccResourceILMCreate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -249,6 +315,78 @@ resource "elasticstack_elasticsearch_index_lifecycle" "test_rollover" {
  `, name)
 }
 
+func testAccResourceILMDecreasingMinAge(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  warm {
+    min_age = "7d"
+
+    readonly {}
+  }
+
+  delete {
+    min_age = "1d"
+
+    delete {}
+  }
+}
+ `, name)
+}
+
+func testAccResourceILMSearchableSnapshotWithoutRollover(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    searchable_snapshot {
+      snapshot_repository = "found-snapshots"
+    }
+  }
+}
+ `, name)
+}
+
+func testAccResourceILMForcemergeAfterSearchableSnapshot(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+
+    searchable_snapshot {
+      snapshot_repository = "found-snapshots"
+    }
+  }
+
+  warm {
+    min_age = "7d"
+
+    forcemerge {
+      max_num_segments = 1
+    }
+  }
+}
+ `, name)
+}
+
 func checkResourceILMDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -0,0 +1,117 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var FreezeActionMaxSupportedVersion = version.Must(version.NewVersion("8.0.0"))
+
+// actionsNotAllowedAfterSearchableSnapshot can't run on the indices mounted as a searchable snapshot
+var actionsNotAllowedAfterSearchableSnapshot = []string{"forcemerge", "freeze", "shrink"}
+
+// validateIlmPolicy catches the policies Elasticsearch rejects at plan time, checking the phases against each other.
+func validateIlmPolicy(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// the checks are left to Elasticsearch when a phase is only known at apply time, and skipped when no phase changed
+	for _, ph := range supportedIlmPhases {
+		if !d.NewValueKnown(ph) {
+			return nil
+		}
+	}
+	if !d.HasChanges(supportedIlmPhases[:]...) {
+		return nil
+	}
+	phases := make(map[string]map[string]interface{})
+	for _, ph := range supportedIlmPhases {
+		if v, ok := d.GetOk(ph); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+			phases[ph] = v.([]interface{})[0].(map[string]interface{})
+		}
+	}
+	return validateIlmPhases(phases, planServerVersion(ctx, d, meta))
+}
+
+func validateIlmPhases(phases map[string]map[string]interface{}, serverVersion *version.Version) error {
+	var previousPhase string
+	var previousMinAge time.Duration
+	for _, ph := range supportedIlmPhases {
+		phase, ok := phases[ph]
+		if !ok {
+			continue
+		}
+		minAge, _ := phase["min_age"].(string)
+		if minAge == "" {
+			continue
+		}
+		age, err := utils.ParseTimeValue(minAge)
+		if err != nil {
+			return fmt.Errorf(`invalid "min_age" of the %s phase: %w`, ph, err)
+		}
+		// same as Elasticsearch, the phases without min_age (defaulting to 0ms) are not compared
+		if age == 0 {
+			continue
+		}
+		if previousPhase != "" && age < previousMinAge {
+			return fmt.Errorf(`the "min_age" of the %s phase (%s) must not be lower than the "min_age" of the %s phase`, ph, minAge, previousPhase)
+		}
+		previousPhase, previousMinAge = ph, age
+	}
+
+	if hot, ok := phases["hot"]; ok && hasAction(hot, "searchable_snapshot") && !hasAction(hot, "rollover") {
+		return fmt.Errorf(`the "searchable_snapshot" action of the hot phase requires the "rollover" action to be configured in the hot phase`)
+	}
+
+	mountedIn := ""
+	for _, ph := range supportedIlmPhases {
+		phase, ok := phases[ph]
+		if !ok {
+			continue
+		}
+		if mountedIn != "" {
+			for _, action := range actionsNotAllowedAfterSearchableSnapshot {
+				if hasAction(phase, action) {
+					return fmt.Errorf(`the "%s" action of the %s phase is not allowed after the "searchable_snapshot" action of the %s phase`, action, ph, mountedIn)
+				}
+			}
+		}
+		if mountedIn == "" && hasAction(phase, "searchable_snapshot") {
+			mountedIn = ph
+		}
+	}
+
+	if serverVersion != nil && serverVersion.GreaterThanOrEqual(FreezeActionMaxSupportedVersion) {
+		if cold, ok := phases["cold"]; ok && hasAction(cold, "freeze") {
+			return fmt.Errorf(`the "freeze" action is not supported by Elasticsearch %s, it's removed since %s`, serverVersion, FreezeActionMaxSupportedVersion)
+		}
+	}
+	return nil
+}
+
+func hasAction(phase map[string]interface{}, action string) bool {
+	v, ok := phase[action].([]interface{})
+	return ok && len(v) > 0
+}
+
+// planServerVersion returns the version of the cluster configured on the provider, nil if it's unknown at plan time,
+// e.g. when the resource defines its own connection or the cluster is not reachable.
+func planServerVersion(ctx context.Context, d *schema.ResourceDiff, meta interface{}) *version.Version {
+	if _, ok := d.GetOk("elasticsearch_connection"); ok {
+		return nil
+	}
+	client, ok := meta.(*clients.ApiClient)
+	if !ok || client == nil || client.GetESClient() == nil {
+		return nil
+	}
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		tflog.Debug(ctx, "Unable to get the version of the cluster, skipping the version checks of the policy")
+		return nil
+	}
+	return serverVersion
+}
@@ -6,9 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
@@ -23,17 +21,6 @@ import (
 )
 
 var (
-	staticSettingsKeys = map[string]schema.ValueType{
-		"number_of_shards":                  schema.TypeInt,
-		"number_of_routing_shards":          schema.TypeInt,
-		"codec":                             schema.TypeString,
-		"routing_partition_size":            schema.TypeInt,
-		"load_fixed_bitset_filters_eagerly": schema.TypeBool,
-		"shard.check_on_startup":            schema.TypeString,
-		"sort.field":                        schema.TypeSet,
-		"sort.order":                        schema.TypeSet,
-		"mapping.coerce":                    schema.TypeBool,
-	}
 	dynamicsSettingsKeys = map[string]schema.ValueType{
 		"number_of_replicas":                     schema.TypeInt,
 		"auto_expand_replicas":                   schema.TypeString,
@@ -56,12 +43,20 @@ var (
 		"highlight.max_analyzed_offset":          schema.TypeInt,
 		"max_terms_count":                        schema.TypeInt,
 		"max_regex_length":                       schema.TypeInt,
+		"mapping.total_fields.limit":             schema.TypeInt,
+		"mapping.depth.limit":                    schema.TypeInt,
+		"mapping.nested_fields.limit":            schema.TypeInt,
+		"mapping.nested_objects.limit":           schema.TypeInt,
 		"query.default_field":                    schema.TypeSet,
 		"routing.allocation.enable":              schema.TypeString,
 		"routing.rebalance.enable":               schema.TypeString,
 		"gc_deletes":                             schema.TypeString,
 		"default_pipeline":                       schema.TypeString,
 		"final_pipeline":                         schema.TypeString,
+		"lifecycle.name":                         schema.TypeString,
+		"lifecycle.rollover_alias":               schema.TypeString,
+		"lifecycle.origination_date":             schema.TypeInt,
+		"lifecycle.parse_origination_date":       schema.TypeBool,
 		"unassigned.node_left.delayed_timeout":   schema.TypeString,
 		"search.slowlog.threshold.query.warn":    schema.TypeString,
 		"search.slowlog.threshold.query.info":    schema.TypeString,
@@ -127,8 +122,7 @@ func ResourceIndex() *schema.Resource {
 		},
 		"codec": {
 			Type:         schema.TypeString,
-			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. This can be set only on creation.",
-			ForceNew:     true,
+			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. This can be set only on creation, or changed while the index is closed.",
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"best_compression"}, false),
 		},
@@ -140,36 +134,47 @@ func ResourceIndex() *schema.Resource {
 		},
 		"load_fixed_bitset_filters_eagerly": {
 			Type:        schema.TypeBool,
-			Description: "Indicates whether cached filters are pre-loaded for nested queries. This can be set only on creation.",
-			ForceNew:    true,
+			Description: "Indicates whether cached filters are pre-loaded for nested queries. This can be set only on creation, or changed while the index is closed.",
 			Optional:    true,
 		},
 		"shard_check_on_startup": {
 			Type:         schema.TypeString,
-			Description:  "Whether or not shards should be checked for corruption before opening. When corruption is detected, it will prevent the shard from being opened. Accepts `false`, `true`, `checksum`.",
-			ForceNew:     true,
+			Description:  "Whether or not shards should be checked for corruption before opening. When corruption is detected, it will prevent the shard from being opened. Accepts `false`, `true`, `checksum`. This can be set only on creation, or changed while the index is closed.",
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"false", "true", "checksum"}, false),
 		},
+		// the sort settings are lists, the order of the sort fields matters and the other settings have a value per sort field
 		"sort_field": {
-			Type:        schema.TypeSet,
+			Type:        schema.TypeList,
 			Elem:        &schema.Schema{Type: schema.TypeString},
-			Description: "The field to sort shards in this index by.",
+			Description: "The fields to sort shards in this index by, the fields must be defined in the `mappings`. The order of the fields is the sort precedence, changing it replaces the index.",
 			ForceNew:    true,
 			Optional:    true,
 		},
-		// sort_order can't be set type since it can have dup strings like ["asc", "asc"]
 		"sort_order": {
 			Type:        schema.TypeList,
-			Elem:        &schema.Schema{Type: schema.TypeString},
-			Description: "The direction to sort shards in. Accepts `asc`, `desc`.",
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(sortOrders, false)},
+			Description: "The direction to sort shards in, one per `sort_field`. Accepts `asc`, `desc`.",
+			ForceNew:    true,
+			Optional:    true,
+		},
+		"sort_mode": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(sortModes, false)},
+			Description: "The value of the multi-valued fields used to sort, one per `sort_field`. Accepts `min`, `max`.",
+			ForceNew:    true,
+			Optional:    true,
+		},
+		"sort_missing": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(sortMissings, false)},
+			Description: "Where the documents missing the field are sorted, one per `sort_field`. Accepts `_last`, `_first`.",
 			ForceNew:    true,
 			Optional:    true,
 		},
 		"mapping_coerce": {
 			Type:        schema.TypeBool,
-			Description: "Set index level coercion setting that is applied to all mapping types.",
-			ForceNew:    true,
+			Description: "Set index level coercion setting that is applied to all mapping types. This can be set only on creation, or changed while the index is closed.",
 			Optional:    true,
 		},
 		// Dynamic settings that can be changed at runtime
@@ -185,9 +190,11 @@ func ResourceIndex() *schema.Resource {
 			Optional:    true,
 		},
 		"search_idle_after": {
-			Type:        schema.TypeString,
-			Description: "How long a shard can not receive a search or get request until it’s considered search idle.",
-			Optional:    true,
+			Type:             schema.TypeString,
+			Description:      "How long a shard can not receive a search or get request until it’s considered search idle, e.g. `30s`. The search idle shards are not refreshed in the background.",
+			Optional:         true,
+			ValidateFunc:     utils.StringIsTimeValue,
+			DiffSuppressFunc: utils.DiffTimeValueSuppress,
 		},
 		"refresh_interval": {
 			Type:        schema.TypeString,
@@ -195,9 +202,10 @@ func ResourceIndex() *schema.Resource {
 			Optional:    true,
 		},
 		"max_result_window": {
-			Type:        schema.TypeInt,
-			Description: "The maximum value of `from + size` for searches to this index.",
-			Optional:    true,
+			Type:         schema.TypeInt,
+			Description:  "The maximum value of `from + size` for searches to this index.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"max_inner_result_window": {
 			Type:        schema.TypeInt,
@@ -205,9 +213,10 @@ func ResourceIndex() *schema.Resource {
 			Optional:    true,
 		},
 		"max_rescore_window": {
-			Type:        schema.TypeInt,
-			Description: "The maximum value of `window_size` for `rescore` requests in searches of this index.",
-			Optional:    true,
+			Type:         schema.TypeInt,
+			Description:  "The maximum value of `window_size` for `rescore` requests in searches of this index.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"max_docvalue_fields_search": {
 			Type:        schema.TypeInt,
@@ -240,9 +249,10 @@ func ResourceIndex() *schema.Resource {
 			Optional:    true,
 		},
 		"highlight_max_analyzed_offset": {
-			Type:        schema.TypeInt,
-			Description: "The maximum number of characters that will be analyzed for a highlight request.",
-			Optional:    true,
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of characters that will be analyzed for a highlight request.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"max_terms_count": {
 			Type:        schema.TypeInt,
@@ -254,6 +264,30 @@ func ResourceIndex() *schema.Resource {
 			Description: "The maximum length of regex that can be used in Regexp Query.",
 			Optional:    true,
 		},
+		"mapping_total_fields_limit": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of fields in the index, sets `index.mapping.total_fields.limit`. Defaults to `1000` on the server.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"mapping_depth_limit": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum depth of the fields, measured in the number of inner objects, sets `index.mapping.depth.limit`. Defaults to `20` on the server.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"mapping_nested_fields_limit": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of distinct `nested` mappings in the index, sets `index.mapping.nested_fields.limit`. Defaults to `50` on the server.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"mapping_nested_objects_limit": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of nested JSON objects that a single document can contain across all `nested` types, sets `index.mapping.nested_objects.limit`. Defaults to `10000` on the server.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
 		"query_default_field": {
 			Type:        schema.TypeSet,
 			Elem:        &schema.Schema{Type: schema.TypeString},
@@ -272,6 +306,7 @@ func ResourceIndex() *schema.Resource {
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"all", "primaries", "replicas", "none"}, false),
 		},
+		"routing_allocation": routingAllocationSchema(),
 		"gc_deletes": {
 			Type:        schema.TypeString,
 			Description: "The length of time that a deleted document's version number remains available for further versioned operations.",
@@ -312,6 +347,26 @@ func ResourceIndex() *schema.Resource {
 			Description: "Final ingest pipeline for the index. Indexing requests will fail if the final pipeline is set and the pipeline does not exist. The final pipeline always runs after the request pipeline (if specified) and the default pipeline (if it exists). The special pipeline name _none indicates no ingest pipeline will run.",
 			Optional:    true,
 		},
+		"lifecycle_name": {
+			Type:        schema.TypeString,
+			Description: "The name of the ILM policy managing the index, sets `index.lifecycle.name`.",
+			Optional:    true,
+		},
+		"lifecycle_rollover_alias": {
+			Type:        schema.TypeString,
+			Description: "The index alias to update when the index rolls over, sets `index.lifecycle.rollover_alias`. Required when the ILM policy has a rollover action.",
+			Optional:    true,
+		},
+		"lifecycle_origination_date": {
+			Type:        schema.TypeInt,
+			Description: "The timestamp, in milliseconds since the epoch, used to calculate the index age for the phase transitions instead of the creation date, sets `index.lifecycle.origination_date`.",
+			Optional:    true,
+		},
+		"lifecycle_parse_origination_date": {
+			Type:        schema.TypeBool,
+			Description: "Set to `true` to parse the origination date from the index name, which must match the pattern `^.*-{date_format}-\\d+`, sets `index.lifecycle.parse_origination_date`.",
+			Optional:    true,
+		},
 		"unassigned_node_left_delayed_timeout": {
 
 			Type:        schema.TypeString,
@@ -398,35 +453,41 @@ func ResourceIndex() *schema.Resource {
 		// To change analyzer setting, the index must be closed, updated, and then reopened but it can't be handled in terraform.
 		// We raise error when they are tried to be updated instead of setting ForceNew not to have unexpected deletion.
 		"analysis_analyzer": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the analyzers applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:          schema.TypeString,
+			Description:   "A JSON string describing the analyzers applied to the index.",
+			Optional:      true,
+			ValidateFunc:  validation.StringIsJSON,
+			ConflictsWith: []string{"analysis"},
 		},
 		"analysis_tokenizer": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the tokenizers applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:          schema.TypeString,
+			Description:   "A JSON string describing the tokenizers applied to the index.",
+			Optional:      true,
+			ValidateFunc:  validation.StringIsJSON,
+			ConflictsWith: []string{"analysis"},
 		},
 		"analysis_char_filter": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the char_filters applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:          schema.TypeString,
+			Description:   "A JSON string describing the char_filters applied to the index.",
+			Optional:      true,
+			ValidateFunc:  validation.StringIsJSON,
+			ConflictsWith: []string{"analysis"},
 		},
 		"analysis_filter": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the filters applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:          schema.TypeString,
+			Description:   "A JSON string describing the filters applied to the index.",
+			Optional:      true,
+			ValidateFunc:  validation.StringIsJSON,
+			ConflictsWith: []string{"analysis"},
 		},
 		"analysis_normalizer": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the normalizers applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:          schema.TypeString,
+			Description:   "A JSON string describing the normalizers applied to the index.",
+			Optional:      true,
+			ValidateFunc:  validation.StringIsJSON,
+			ConflictsWith: []string{"analysis"},
 		},
+		"analysis": analysisSchema("analysis_analyzer", "analysis_tokenizer", "analysis_char_filter", "analysis_filter", "analysis_normalizer"),
 		"alias": {
 			Description: "Aliases for the index.",
 			Type:        schema.TypeSet,
@@ -492,6 +553,8 @@ If specified, this mapping can include: field names, [field data types](https://
 			ValidateFunc:     validation.StringIsJSON,
 			Default:          "{}",
 		},
+		"mapping_dynamic": mappingDynamicSchema(),
+		"mapping_source":  mappingSourceSchema(true),
 		// Deprecated: individual setting field should be used instead
 		"settings": {
 			Description: `DEPRECATED: Please use dedicated setting field. Configuration options for the index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#index-modules-settings.
@@ -530,6 +593,12 @@ If specified, this mapping can include: field names, [field data types](https://
 			Type:        schema.TypeString,
 			Computed:    true,
 		},
+		"open": {
+			Type:        schema.TypeBool,
+			Description: "Whether the index is open. Set to `false` to close the index, blocking the read and write operations, e.g. for maintenance. The aliases and the mappings of a closed index can not be updated, while the `analysis` and the static settings which can be changed on a closed index, e.g. the `codec`, are updated in place as long as the index stays closed, their changes replacing the open index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-close.html",
+			Optional:    true,
+			Default:     true,
+		},
 		"include_type_name": {
 			Type:        schema.TypeBool,
 			Description: "If true, a mapping type is expected in the body of mappings. Defaults to false. Supported for Elasticsearch 7.x.",
@@ -542,23 +611,19 @@ If specified, this mapping can include: field names, [field data types](https://
 			Optional:    true,
 			Default:     "1",
 		},
-		"master_timeout": {
-			Type:         schema.TypeString,
-			Description:  "Period to wait for a connection to the master node. If no response is received before the timeout expires, the request fails and returns an error. Defaults to `30s`.",
-			Optional:     true,
-			Default:      "30s",
-			ValidateFunc: utils.StringIsDuration,
-		},
-		"timeout": {
-			Type:         schema.TypeString,
-			Description:  "Period to wait for a response. If no response is received before the timeout expires, the request fails and returns an error. Defaults to `30s`.",
-			Optional:     true,
-			Default:      "30s",
-			ValidateFunc: utils.StringIsDuration,
-		},
 	}
 
 	utils.AddConnectionSchema(indexSchema)
+	utils.AddTimeoutsSchema(indexSchema)
+
+	// the sort fields were a set up to the schema version 0
+	indexSchemaV0 := make(map[string]*schema.Schema, len(indexSchema))
+	for k, v := range indexSchema {
+		indexSchemaV0[k] = v
+	}
+	sortFieldV0 := *indexSchema["sort_field"]
+	sortFieldV0.Type = schema.TypeSet
+	indexSchemaV0["sort_field"] = &sortFieldV0
 
 	return &schema.Resource{
 		Description: "Creates Elasticsearch indices. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html",
@@ -570,6 +635,9 @@ If specified, this mapping can include: field names, [field data types](https://
 
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if _, err := clients.ImportStatePrefixedPassthroughContext(ctx, d, m); err != nil {
+					return nil, err
+				}
 				// first populate what we can with Read
 				diags := resourceIndexRead(ctx, d, m)
 				if diags.HasError() {
@@ -602,19 +670,9 @@ If specified, this mapping can include: field names, [field data types](https://
 							tflog.Warn(ctx, fmt.Sprintf("setting '%s' is not currently managed by terraform provider and has been ignored", key))
 							continue
 						}
-						switch typ {
-						case schema.TypeInt:
-							v, err := strconv.Atoi(value.(string))
-							if err != nil {
-								return nil, fmt.Errorf("failed to convert setting '%s' value %v to int: %w", key, value, err)
-							}
-							value = v
-						case schema.TypeBool:
-							v, err := strconv.ParseBool(value.(string))
-							if err != nil {
-								return nil, fmt.Errorf("failed to convert setting '%s' value %v to bool: %w", key, value, err)
-							}
-							value = v
+						value, err := convertSettingValue(key, typ, value)
+						if err != nil {
+							return nil, err
 						}
 						if err := d.Set(utils.ConvertSettingsKeyToTFFieldKey(key), value); err != nil {
 							return nil, err
@@ -625,30 +683,47 @@ If specified, this mapping can include: field names, [field data types](https://
 			},
 		},
 
-		CustomizeDiff: customdiff.ForceNewIfChange("mappings", func(ctx context.Context, old, new, meta interface{}) bool {
-			o := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(old.(string))).Decode(&o); err != nil {
-				return true
-			}
-			n := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(new.(string))).Decode(&n); err != nil {
-				return true
-			}
-			tflog.Trace(ctx, "mappings custom diff old = %+v new = %+v", o, n)
-
-			// if old defined we must check if the type of the existing fields were changed
-			if oldProps, ok := o["properties"]; ok {
-				newProps, ok := n["properties"]
-				// if the old has props but new one not, immediately force new resource
-				if !ok {
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAnalysis("analysis", d.Get("analysis").([]interface{}))
+			},
+			validateIndexSortDiff,
+			forceNewOnStaticSettingsChange,
+			forceNewOnClosedIndexSettingsChange,
+			customdiff.ForceNewIfChange("mappings", func(ctx context.Context, old, new, meta interface{}) bool {
+				o := make(map[string]interface{})
+				if err := json.NewDecoder(strings.NewReader(old.(string))).Decode(&o); err != nil {
 					return true
 				}
-				return IsMappingForceNewRequired(ctx, oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
-			}
+				n := make(map[string]interface{})
+				if err := json.NewDecoder(strings.NewReader(new.(string))).Decode(&n); err != nil {
+					return true
+				}
+				tflog.Trace(ctx, "mappings custom diff old = %+v new = %+v", o, n)
 
-			// if all check passed, we can update the map
-			return false
-		}),
+				// if old defined we must check if the type of the existing fields were changed
+				if oldProps, ok := o["properties"]; ok {
+					newProps, ok := n["properties"]
+					// if the old has props but new one not, immediately force new resource
+					if !ok {
+						return true
+					}
+					return IsMappingForceNewRequired(ctx, oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
+				}
+
+				// if all check passed, we can update the map
+				return false
+			}),
+		),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    (&schema.Resource{Schema: indexSchemaV0}).CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeSortFieldOrder,
+			},
+		},
 
 		Schema: indexSchema,
 	}
@@ -659,7 +734,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	indexName := d.Get("name").(string)
+	indexName := client.ResourceName(d.Get("name").(string))
 	id, diags := client.ID(ctx, indexName)
 	if diags.HasError() {
 		return diags
@@ -685,11 +760,20 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 		index.Mappings = maps
 	}
+	if index.Mappings == nil {
+		index.Mappings = make(map[string]interface{})
+	}
+	if diags := expandMappingOptions(index.Mappings, d.Get("mapping_dynamic").(string), d.Get("mapping_source").([]interface{})); diags.HasError() {
+		return diags
+	}
 
 	index.Settings = map[string]interface{}{}
 	if settings := utils.ExpandIndividuallyDefinedSettings(ctx, d, allSettingsKeys); len(settings) > 0 {
 		index.Settings = settings
 	}
+	for k, v := range expandRoutingAllocation(d.Get("routing_allocation").([]interface{})) {
+		index.Settings[k] = v
+	}
 
 	analysis := map[string]interface{}{}
 	if analyzerJSON, ok := d.GetOk("analysis_analyzer"); ok {
@@ -736,6 +820,15 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 		analysis["normalizer"] = normalizer
 	}
+	if v, ok := d.GetOk("analysis"); ok {
+		a, diags := expandAnalysis(v.([]interface{}))
+		if diags.HasError() {
+			return diags
+		}
+		for k, v := range a {
+			analysis[k] = v
+		}
+	}
 	if len(analysis) > 0 {
 		index.Settings["analysis"] = analysis
 	}
@@ -753,6 +846,14 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 	}
 
+	pipelineDiags := checkPipelineReferences(ctx, client, indexPipelines(d, false))
+	if pipelineDiags.HasError() {
+		return pipelineDiags
+	}
+	if diags := validateLifecycleRolloverAlias(ctx, client, index.Settings); diags.HasError() {
+		return diags
+	}
+
 	params := models.PutIndexParams{
 		WaitForActiveShards: d.Get("wait_for_active_shards").(string),
 		IncludeTypeName:     d.Get("include_type_name").(bool),
@@ -762,29 +863,29 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diags
 	}
 	if includeTypeName := d.Get("include_type_name").(bool); includeTypeName {
-		if serverVersion.GreaterThanOrEqual(includeTypeNameMinUnsupportedVersion) {
+		if serverVersion != nil && serverVersion.GreaterThanOrEqual(includeTypeNameMinUnsupportedVersion) {
 			return diag.FromErr(fmt.Errorf("'include_type_name' field is supported only for elasticsearch v7.x"))
 		}
 		params.IncludeTypeName = includeTypeName
 	}
-	masterTimeout, err := time.ParseDuration(d.Get("master_timeout").(string))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	params.MasterTimeout = masterTimeout
-
-	timeout, err := time.ParseDuration(d.Get("timeout").(string))
-	if err != nil {
-		return diag.FromErr(err)
+	// the configured timeouts of the index override the ones of the connection
+	params.MasterTimeout = client.MasterTimeout()
+	params.Timeout = client.Timeout()
+	params.IsStaticSetting = func(key string) bool {
+		return isStaticSetting(key, serverVersion)
 	}
-	params.Timeout = timeout
 
 	if diags := elasticsearch.PutIndex(ctx, client, &index, &params); diags.HasError() {
 		return diags
 	}
+	if !d.Get("open").(bool) {
+		if diags := elasticsearch.CloseIndex(ctx, client, indexName); diags.HasError() {
+			return diags
+		}
+	}
 
 	d.SetId(id.String())
-	return resourceIndexRead(ctx, d, meta)
+	return append(pipelineDiags, resourceIndexRead(ctx, d, meta)...)
 }
 
 // Because of limitation of ES API we must handle changes to aliases, mappings and settings separately
@@ -793,7 +894,26 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	indexName := d.Get("name").(string)
+	indexName, diags := client.TargetName(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the closed index only accepts the settings updates
+	open := d.Get("open").(bool)
+	if !open && d.HasChanges("alias", "mappings", "mapping_dynamic") {
+		return diag.Errorf(`the aliases and the mappings of the closed index "%s" can not be updated, set "open" to true to update them`, indexName)
+	}
+	if d.HasChange("open") {
+		if open {
+			diags = elasticsearch.OpenIndex(ctx, client, indexName, d.Get("wait_for_active_shards").(string))
+		} else {
+			diags = elasticsearch.CloseIndex(ctx, client, indexName)
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
 
 	// aliases
 	if d.HasChange("alias") {
@@ -831,10 +951,56 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 
 	// settings
 	updatedSettings := make(map[string]interface{})
-	for key := range dynamicsSettingsKeys {
+	for key, typ := range dynamicsSettingsKeys {
 		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
 		if d.HasChange(fieldKey) {
-			updatedSettings[key] = d.Get(fieldKey)
+			value := d.Get(fieldKey)
+			if isRemovedSetting(d.GetRawConfig(), fieldKey, typ, value) {
+				// the removed setting is reset to the default, the zero value is either not accepted or not meaningful
+				value = nil
+			}
+			updatedSettings[key] = value
+		}
+	}
+	if isKeptClosed(d) {
+		// the changed static settings of a closed index are updated in place, the others replace the index
+		for key, typ := range staticSettingsKeys {
+			fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+			if isClosedIndexSetting(key) && d.HasChange(fieldKey) {
+				value := d.Get(fieldKey)
+				if isRemovedSetting(d.GetRawConfig(), fieldKey, typ, value) {
+					value = nil
+				}
+				updatedSettings[key] = value
+			}
+		}
+		if d.HasChange("analysis") {
+			analysisChanges, diags := analysisSettingsChanges(d.GetChange("analysis"))
+			if diags.HasError() {
+				return diags
+			}
+			for k, v := range analysisChanges {
+				updatedSettings[k] = v
+			}
+		}
+	}
+	pipelineDiags := checkPipelineReferences(ctx, client, indexPipelines(d, true))
+	if pipelineDiags.HasError() {
+		return pipelineDiags
+	}
+	if d.HasChanges("lifecycle_name", "lifecycle_rollover_alias") {
+		lifecycle := map[string]interface{}{
+			"lifecycle.name":           d.Get("lifecycle_name"),
+			"lifecycle.rollover_alias": d.Get("lifecycle_rollover_alias"),
+		}
+		if diags := validateLifecycleRolloverAlias(ctx, client, lifecycle); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("routing_allocation") {
+		oldAllocation, newAllocation := d.GetChange("routing_allocation")
+		for k, v := range routingAllocationChanges(oldAllocation.([]interface{}), newAllocation.([]interface{})) {
+			updatedSettings[k] = v
 		}
 	}
 	if d.HasChange("settings") {
@@ -869,15 +1035,30 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	// mappings
-	if d.HasChange("mappings") {
+	if d.HasChange("mappings") || d.HasChange("mapping_dynamic") {
 		// at this point we know there are mappings defined and there is a change which we can apply
-		mappings := d.Get("mappings").(string)
-		if diags := elasticsearch.UpdateIndexMappings(ctx, client, indexName, mappings); diags.HasError() {
+		mappings := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(d.Get("mappings").(string)), &mappings); err != nil {
+			return diag.FromErr(err)
+		}
+		dynamic := d.Get("mapping_dynamic").(string)
+		if _, ok := mappings["dynamic"]; !ok && dynamic == "" && d.HasChange("mapping_dynamic") {
+			// the dynamic mode cannot be removed from the mappings, it's reset to the default instead
+			dynamic = "true"
+		}
+		if diags := expandMappingOptions(mappings, dynamic, nil); diags.HasError() {
+			return diags
+		}
+		m, err := json.Marshal(mappings)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if diags := elasticsearch.UpdateIndexMappings(ctx, client, indexName, string(m)); diags.HasError() {
 			return diags
 		}
 	}
 
-	return resourceIndexRead(ctx, d, meta)
+	return append(pipelineDiags, resourceIndexRead(ctx, d, meta)...)
 }
 
 func flattenIndexSettings(settings []interface{}) map[string]interface{} {
@@ -903,7 +1084,7 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	indexName := compId.ResourceId
 
-	if err := d.Set("name", indexName); err != nil {
+	if err := d.Set("name", client.ConfiguredName(indexName)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -918,6 +1099,20 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diags
 	}
 
+	resolved, diags := elasticsearch.ResolveIndices(ctx, client, []string{indexName}, "all")
+	if diags.HasError() {
+		return diags
+	}
+	open := true
+	for _, idx := range resolved {
+		if idx.Name == indexName && idx.HasAttribute("closed") {
+			open = false
+		}
+	}
+	if err := d.Set("open", open); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if index.Aliases != nil {
 		aliases, diags := FlattenIndexAliases(index.Aliases)
 		if diags.HasError() {
@@ -928,13 +1123,79 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		}
 	}
 	if index.Mappings != nil {
-		m, err := json.Marshal(index.Mappings)
+		// the typed mapping attributes are read back only when used, otherwise they're kept in the mappings
+		_, manageDynamic := d.GetOk("mapping_dynamic")
+		_, manageSource := d.GetOk("mapping_source")
+		mappings, dynamic, source := extractMappingOptions(index.Mappings, manageDynamic, manageSource)
+		if !manageDynamic {
+			removeDefaultDynamic(mappings, d.Get("mappings").(string))
+		}
+		m, err := json.Marshal(mappings)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		if err := d.Set("mappings", string(m)); err != nil {
 			return diag.FromErr(err)
 		}
+		if manageDynamic {
+			if err := d.Set("mapping_dynamic", dynamic); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if manageSource {
+			if err := d.Set("mapping_source", source); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if v, ok := d.GetOk("analysis"); ok && index.Settings != nil {
+		analysis, _ := extractAnalysisSettings(index.Settings)
+		a, diags := flattenAnalysis(analysis, v.([]interface{}))
+		if diags.HasError() {
+			return diags
+		}
+		if err := d.Set("analysis", a); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("routing_allocation"); ok && index.Settings != nil {
+		if err := d.Set("routing_allocation", flattenRoutingAllocation(index.Settings, v.([]interface{}))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// the unset pipelines are read as empty, the same as when they're not configured
+	for _, key := range pipelineSettingsKeys {
+		if _, ok := d.GetOk(key); !ok {
+			continue
+		}
+		pipeline, _ := index.Settings["index."+key].(string)
+		if err := d.Set(key, pipeline); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if diags := flattenConfiguredSettings(d, index.Settings, lifecycleSettingsKeys); diags.HasError() {
+		return diags
+	}
+	if diags := flattenConfiguredSettings(d, index.Settings, mappingLimitsSettingsKeys); diags.HasError() {
+		return diags
+	}
+	if diags := flattenConfiguredSettings(d, index.Settings, searchSettingsKeys); diags.HasError() {
+		return diags
+	}
+	// the static settings cannot be updated, read them back to replace the index if they drifted
+	for key, typ := range staticSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		value, ok := index.Settings["index."+key]
+		if _, configured := d.GetOk(fieldKey); !configured || !ok {
+			continue
+		}
+		v, err := convertSettingValue(key, typ, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set(fieldKey, v); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	// TODO: We ideally should set read settings to each field to detect changes
 	// But for now, setting it will cause unexpected diff for the existing clients which use `settings`
@@ -3,14 +3,19 @@ package index_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -126,6 +131,32 @@ func TestAccResourceIndexSettingsMigration(t *testing.T) {
 	})
 }
 
+func TestAccResourceIndexStaticSettingsReplace(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexStaticSettings(indexName, "1", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_static_settings", "settings.0.setting.#", "2"),
+				),
+			},
+			{
+				// the dynamic setting is updated in place
+				Config: testAccResourceIndexStaticSettings(indexName, "1", "0"),
+			},
+			{
+				// the static setting replaces the index instead of failing to update it
+				Config: testAccResourceIndexStaticSettings(indexName, "2", "0"),
+			},
+		},
+	})
+}
+
 func TestAccResourceIndexSettingsConflict(t *testing.T) {
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
@@ -142,6 +173,342 @@ func TestAccResourceIndexSettingsConflict(t *testing.T) {
 	})
 }
 
+func TestAccResourceIndexAnalysis(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexAnalysisUndefinedFilter(indexName),
+				ExpectError: regexp.MustCompile(`analysis.0.analyzer.0: filter "undefined_filter" is not defined`),
+			},
+			{
+				Config: testAccResourceIndexAnalysisCreate(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.analyzer.0.name", "autocomplete"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.analyzer.0.tokenizer", "autocomplete_tokenizer"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.analyzer.0.filter.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.analyzer.0.filter.0", "lowercase"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.analyzer.0.filter.1", "english_stop"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.tokenizer.0.name", "autocomplete_tokenizer"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.tokenizer.0.type", "edge_ngram"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_analysis", "analysis.0.filter.0.name", "english_stop"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexSort(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexSort(indexName, `["timestamp", "undefined_field"]`, `["desc", "asc"]`),
+				ExpectError: regexp.MustCompile(`the sort field "undefined_field" is not defined in the mappings`),
+			},
+			{
+				Config:      testAccResourceIndexSort(indexName, `["timestamp", "user.name"]`, `["desc"]`),
+				ExpectError: regexp.MustCompile(`"sort.order" must have a value for each of the 2 "sort.field", got 1`),
+			},
+			{
+				Config: testAccResourceIndexSort(indexName, `["timestamp", "user.name"]`, `["desc", "asc"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_sort", "sort_field.0", "timestamp"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_sort", "sort_field.1", "user.name"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_sort", "sort_order.0", "desc"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_sort", "sort_order.1", "asc"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_sort", "sort_mode.1", "min"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_sort", "sort_missing.0", "_first"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexMappingOptions(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexMappingOptions(indexName, "strict", `jsonencode({ dynamic = "false" })`),
+				ExpectError: regexp.MustCompile("the `dynamic` mode is already defined in the `mappings`"),
+			},
+			{
+				Config: testAccResourceIndexMappingOptions(indexName, "strict", `jsonencode({ properties = { field1 = { type = "text" } } })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mapping_dynamic", "strict"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mapping_source.0.enabled", "true"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mapping_source.0.excludes.0", "secret.*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mappings", `{"properties":{"field1":{"type":"text"}}}`),
+				),
+			},
+			{
+				Config: testAccResourceIndexMappingOptions(indexName, "runtime", `jsonencode({ properties = { field1 = { type = "text" } } })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mapping_dynamic", "runtime"),
+				),
+			},
+			{
+				// the removed mode is reset to the default, which is not read back in the mappings
+				Config: testAccResourceIndexMappingOptionsWithoutDynamic(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mapping_dynamic", ""),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_options", "mappings", `{"properties":{"field1":{"type":"text"}}}`),
+				),
+			},
+			{
+				Config:   testAccResourceIndexMappingOptionsWithoutDynamic(indexName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexRoutingAllocation(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexRoutingAllocation(indexName, `_name = "missing-node"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_allocation", "routing_allocation.0.exclude._name", "missing-node"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_allocation", "routing_allocation.0.include.%", "0"),
+				),
+			},
+			{
+				Config: testAccResourceIndexRoutingAllocation(indexName, `_ip = "192.0.2.1,192.0.2.2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_allocation", "routing_allocation.0.exclude.%", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_allocation", "routing_allocation.0.exclude._ip", "192.0.2.1,192.0.2.2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexPipelines(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexPipelines(indexName, `"missing-pipeline"`),
+				ExpectError: regexp.MustCompile(`the ingest pipeline "missing-pipeline" referenced by "default_pipeline" does not exist`),
+			},
+			{
+				Config: testAccResourceIndexPipelines(indexName, "elasticstack_elasticsearch_ingest_pipeline.test.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_pipelines", "default_pipeline", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_pipelines", "final_pipeline", "_none"),
+				),
+			},
+			{
+				// the removed pipeline is reset instead of being set to an empty value
+				Config: testAccResourceIndexPipelines(indexName, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_pipelines", "default_pipeline", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexLifecycle(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceIndexLifecycle(indexName, "null", "null"),
+				ExpectError: regexp.MustCompile(`has a rollover action in the "hot" phase, "lifecycle_rollover_alias" must be set`),
+			},
+			{
+				Config: testAccResourceIndexLifecycle(indexName, fmt.Sprintf(`"%s-alias"`, indexName), "1672531200000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_lifecycle", "lifecycle_name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_lifecycle", "lifecycle_rollover_alias", indexName+"-alias"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_lifecycle", "lifecycle_origination_date", "1672531200000"),
+				),
+			},
+			{
+				// the removed origination date is reset instead of being set to the epoch
+				Config: testAccResourceIndexLifecycle(indexName, fmt.Sprintf(`"%s-alias"`, indexName), "null"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_lifecycle", "lifecycle_origination_date", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexMappingLimits(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMappingLimits(indexName, "2000", "30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_limits", "mapping_total_fields_limit", "2000"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_limits", "mapping_depth_limit", "30"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_limits", "mapping_nested_fields_limit", "0"),
+				),
+			},
+			{
+				// the removed limit is reset to the default instead of being set to zero
+				Config: testAccResourceIndexMappingLimits(indexName, "3000", "null"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_limits", "mapping_total_fields_limit", "3000"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_mapping_limits", "mapping_depth_limit", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexSearchSettings(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexSearchSettings(indexName, `"30s"`, "20000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_search_settings", "search_idle_after", "30s"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_search_settings", "max_result_window", "20000"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_search_settings", "max_rescore_window", "5000"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_search_settings", "highlight_max_analyzed_offset", "500000"),
+				),
+			},
+			{
+				// the removed settings are reset to the defaults in place
+				Config: testAccResourceIndexSearchSettings(indexName, `"2m"`, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_search_settings", "search_idle_after", "2m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_search_settings", "max_result_window", "0"),
+				),
+			},
+			{
+				Config:      testAccResourceIndexSearchSettings(indexName, `"2 minutes"`, "null"),
+				ExpectError: regexp.MustCompile("invalid time value"),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexClosedSettings(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexClosedSettings(indexName, false, "lowercase"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_closed", "open", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_closed", "analysis.0.analyzer.0.filter.0", "lowercase"),
+				),
+			},
+			{
+				// the static settings of the closed index are updated in place
+				Config: testAccResourceIndexClosedSettings(indexName, true, "asciifolding"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_closed", "open", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_closed", "codec", "best_compression"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_closed", "analysis.0.analyzer.0.filter.0", "asciifolding"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexOpen(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexOpen(indexName, false, "30s", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_open", "open", "false"),
+				),
+			},
+			{
+				// the settings are still updated while the index is closed
+				Config: testAccResourceIndexOpen(indexName, false, "1m", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_open", "open", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_open", "refresh_interval", "1m"),
+				),
+			},
+			{
+				Config:      testAccResourceIndexOpen(indexName, false, "1m", true),
+				ExpectError: regexp.MustCompile(`the aliases and the mappings of the closed index "` + indexName + `" can not be updated`),
+			},
+			{
+				Config: testAccResourceIndexOpen(indexName, true, "1m", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_open", "open", "true"),
+				),
+			},
+			{
+				// the index closed outside of Terraform is reopened
+				PreConfig: func() {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						t.Fatalf("Failed to create testing client: %v", err)
+					}
+					res, err := client.GetESClient().Indices.Close([]string{indexName})
+					if err != nil {
+						t.Fatalf("Failed to close the index: %v", err)
+					}
+					defer res.Body.Close()
+					if res.IsError() {
+						t.Fatalf("Failed to close the index: %s", res.String())
+					}
+				},
+				Config: testAccResourceIndexOpen(indexName, true, "1m", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_open", "open", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceIndexRemovingField(t *testing.T) {
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
@@ -322,6 +689,29 @@ resource "elasticstack_elasticsearch_index" "test_settings_migration" {
 	`, name)
 }
 
+func testAccResourceIndexStaticSettings(name, shards, replicas string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_static_settings" {
+  name = "%s"
+
+  settings {
+    setting {
+      name  = "index.number_of_shards"
+      value = "%s"
+    }
+    setting {
+      name  = "index.number_of_replicas"
+      value = "%s"
+    }
+  }
+}
+	`, name, shards, replicas)
+}
+
 func testAccResourceIndexSettingsMigrationUpdate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -363,6 +753,285 @@ resource "elasticstack_elasticsearch_index" "test_settings_conflict" {
 	`, name)
 }
 
+func testAccResourceIndexAnalysisCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_analysis" {
+  name = "%s"
+
+  analysis {
+    analyzer {
+      name      = "autocomplete"
+      tokenizer = "autocomplete_tokenizer"
+      filter    = ["lowercase", "english_stop"]
+    }
+
+    tokenizer {
+      name = "autocomplete_tokenizer"
+      type = "edge_ngram"
+      settings = jsonencode({
+        min_gram    = 2
+        max_gram    = 10
+        token_chars = ["letter"]
+      })
+    }
+
+    filter {
+      name = "english_stop"
+      type = "stop"
+      settings = jsonencode({
+        stopwords = "_english_"
+      })
+    }
+  }
+
+  mappings = jsonencode({
+    properties = {
+      title = { type = "text", analyzer = "autocomplete" }
+    }
+  })
+}
+	`, name)
+}
+
+func testAccResourceIndexAnalysisUndefinedFilter(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_analysis" {
+  name = "%s"
+
+  analysis {
+    analyzer {
+      name      = "autocomplete"
+      tokenizer = "standard"
+      filter    = ["lowercase", "undefined_filter"]
+    }
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexSort(name, fields, orders string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_sort" {
+  name = "%s"
+
+  mappings = jsonencode({
+    properties = {
+      timestamp = { type = "date" }
+      user = {
+        properties = {
+          name = { type = "keyword" }
+        }
+      }
+    }
+  })
+
+  sort_field   = %s
+  sort_order   = %s
+  sort_mode    = ["max", "min"]
+  sort_missing = ["_first", "_last"]
+}
+	`, name, fields, orders)
+}
+
+func testAccResourceIndexMappingOptions(name, dynamic, mappings string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_mapping_options" {
+  name            = "%s"
+  mapping_dynamic = "%s"
+  mappings        = %s
+
+  mapping_source {
+    excludes = ["secret.*"]
+  }
+}
+	`, name, dynamic, mappings)
+}
+
+func testAccResourceIndexMappingOptionsWithoutDynamic(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_mapping_options" {
+  name     = "%s"
+  mappings = jsonencode({ properties = { field1 = { type = "text" } } })
+
+  mapping_source {
+    excludes = ["secret.*"]
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexRoutingAllocation(name, exclude string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_allocation" {
+  name = "%s"
+
+  routing_allocation {
+    exclude = {
+      %s
+    }
+  }
+}
+	`, name, exclude)
+}
+
+func testAccResourceIndexPipelines(name, defaultPipeline string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+  validate_pipeline_references = "error"
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "ingested"
+        value = true
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_index" "test_pipelines" {
+  name             = "%[1]s"
+  default_pipeline = %[2]s
+  final_pipeline   = "_none"
+}
+	`, name, defaultPipeline)
+}
+
+func testAccResourceIndexLifecycle(name, rolloverAlias, originationDate string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%[1]s"
+
+  hot {
+    rollover {
+      max_age = "1d"
+    }
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "test_lifecycle" {
+  name                       = "%[1]s-000001"
+  lifecycle_name             = elasticstack_elasticsearch_index_lifecycle.test.name
+  lifecycle_rollover_alias   = %[2]s
+  lifecycle_origination_date = %[3]s
+}
+	`, name, rolloverAlias, originationDate)
+}
+
+func testAccResourceIndexMappingLimits(name, totalFieldsLimit, depthLimit string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_mapping_limits" {
+  name                       = "%s"
+  mapping_total_fields_limit = %s
+  mapping_depth_limit        = %s
+}
+	`, name, totalFieldsLimit, depthLimit)
+}
+
+func testAccResourceIndexSearchSettings(name, searchIdleAfter, maxResultWindow string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_search_settings" {
+  name                          = "%s"
+  search_idle_after             = %s
+  max_result_window             = %s
+  max_rescore_window            = 5000
+  highlight_max_analyzed_offset = 500000
+}
+	`, name, searchIdleAfter, maxResultWindow)
+}
+
+func testAccResourceIndexClosedSettings(name string, bestCompression bool, filter string) string {
+	codec := ""
+	if bestCompression {
+		codec = `codec = "best_compression"`
+	}
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_closed" {
+  name = "%s"
+  open = false
+  %s
+
+  analysis {
+    analyzer {
+      name      = "folded"
+      tokenizer = "standard"
+      filter    = ["%s"]
+    }
+  }
+}
+	`, name, codec, filter)
+}
+
+func testAccResourceIndexOpen(name string, open bool, refreshInterval string, otherField bool) string {
+	properties := `field = { type = "text" }`
+	if otherField {
+		properties += `
+      other = { type = "keyword" }`
+	}
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_open" {
+  name             = "%s"
+  open             = %t
+  refresh_interval = "%s"
+
+  mappings = jsonencode({
+    properties = {
+      %s
+    }
+  })
+}
+	`, name, open, refreshInterval, properties)
+}
+
 func testAccResourceIndexRemovingFieldCreate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -547,3 +1216,201 @@ func Test_IsMappingForceNewRequired(t *testing.T) {
 		})
 	}
 }
+
+var testAnalysisConfig = []interface{}{
+	map[string]interface{}{
+		"analyzer": []interface{}{
+			map[string]interface{}{"name": "folding", "tokenizer": "standard", "filter": []interface{}{"asciifolding"}},
+		},
+	},
+}
+
+func TestResourceIndexClosedSettingsDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		open        string
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		{name: "closed index codec", open: "false", config: map[string]interface{}{"open": false, "codec": "best_compression"}},
+		{name: "open index codec", open: "true", config: map[string]interface{}{"codec": "best_compression"}, requiresNew: true},
+		{name: "reopened index codec", open: "false", config: map[string]interface{}{"open": true, "codec": "best_compression"}, requiresNew: true},
+		{name: "closed index number of shards", open: "false", config: map[string]interface{}{"open": false, "number_of_shards": 2}, requiresNew: true},
+		{name: "closed index analysis", open: "false", config: map[string]interface{}{"open": false, "analysis": testAnalysisConfig}},
+		{name: "open index analysis", open: "true", config: map[string]interface{}{"analysis": testAnalysisConfig}, requiresNew: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "cluster-uuid/closed",
+				Attributes: map[string]string{
+					"id":               "cluster-uuid/closed",
+					"name":             "closed",
+					"open":             tt.open,
+					"mappings":         "{}",
+					"number_of_shards": "1",
+				},
+			}
+			raw := map[string]interface{}{"name": "closed", "number_of_shards": 1}
+			for k, v := range tt.config {
+				raw[k] = v
+			}
+			diff, err := index.ResourceIndex().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := diff != nil && diff.RequiresNew(); got != tt.requiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", got, tt.requiresNew)
+			}
+		})
+	}
+}
+
+func TestResourceIndexStaticSettingsServerVersion(t *testing.T) {
+	client, requests := newTestServerClient(t)
+
+	tests := []struct {
+		name          string
+		setting       string
+		lookupVersion bool
+		requiresNew   bool
+	}{
+		{name: "dynamic setting", setting: "index.refresh_interval"},
+		{name: "version dependent static setting", setting: "index.mode", lookupVersion: true, requiresNew: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "cluster-uuid/settings",
+				Attributes: map[string]string{
+					"id":       "cluster-uuid/settings",
+					"name":     "settings",
+					"mappings": "{}",
+				},
+			}
+			raw := map[string]interface{}{
+				"name": "settings",
+				"settings": []interface{}{map[string]interface{}{
+					"setting": []interface{}{map[string]interface{}{"name": tt.setting, "value": "time_series"}},
+				}},
+			}
+			before := *requests
+			diff, err := index.ResourceIndex().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := *requests > before; got != tt.lookupVersion {
+				t.Errorf("server version looked up = %v, want %v", got, tt.lookupVersion)
+			}
+			if got := diff != nil && diff.RequiresNew(); got != tt.requiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", got, tt.requiresNew)
+			}
+		})
+	}
+}
+
+// newTestServerClient returns a client of a fake cluster only answering its info, along with the count of the requests it received
+func newTestServerClient(t *testing.T) (*clients.ApiClient, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"cluster_uuid": "cluster-uuid", "version": {"number": "8.5.0", "build_flavor": "default"}, "tagline": "You Know, for Search"}`)
+	}))
+	t.Cleanup(server.Close)
+	return newTestClient(t, server.URL), &requests
+}
+
+func newTestClient(t *testing.T, endpoint string) *clients.ApiClient {
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch":     providerSchema.GetConnectionSchema("elasticsearch", true),
+		"verify_connection": {Type: schema.TypeBool, Optional: true},
+	}
+	d := schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{
+		"elasticsearch": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{endpoint},
+		}},
+	})
+	client, diags := clients.NewApiClientFunc("test")(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+	return client.(*clients.ApiClient)
+}
+
+func TestResourceIndexSortFieldReorder(t *testing.T) {
+	mappings := `{"properties":{"host":{"type":"keyword"},"timestamp":{"type":"date"},"user":{"type":"keyword"}}}`
+	state := &terraform.InstanceState{
+		ID: "cluster-uuid/sorted",
+		Attributes: map[string]string{
+			"id":           "cluster-uuid/sorted",
+			"name":         "sorted",
+			"mappings":     mappings,
+			"sort_field.#": "2",
+			"sort_field.0": "timestamp",
+			"sort_field.1": "user",
+			"sort_order.#": "2",
+			"sort_order.0": "desc",
+			"sort_order.1": "asc",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		fields      []interface{}
+		orders      []interface{}
+		requiresNew bool
+	}{
+		{name: "same order", fields: []interface{}{"timestamp", "user"}, orders: []interface{}{"desc", "asc"}},
+		{name: "reordered fields", fields: []interface{}{"user", "timestamp"}, orders: []interface{}{"desc", "asc"}, requiresNew: true},
+		{name: "reordered fields and orders", fields: []interface{}{"user", "timestamp"}, orders: []interface{}{"asc", "desc"}, requiresNew: true},
+		{name: "other field", fields: []interface{}{"timestamp", "host"}, orders: []interface{}{"desc", "asc"}, requiresNew: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":       "sorted",
+				"mappings":   mappings,
+				"sort_field": tt.fields,
+				"sort_order": tt.orders,
+			})
+			diff, err := index.ResourceIndex().Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := diff != nil && diff.RequiresNew(); got != tt.requiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", got, tt.requiresNew)
+			}
+		})
+	}
+}
+
+func TestResourceIndexSortFieldStateUpgrade(t *testing.T) {
+	upgrader := index.ResourceIndex().StateUpgraders[0]
+	tests := []struct {
+		name     string
+		fields   []interface{}
+		settings string
+		want     []interface{}
+	}{
+		{name: "set order", fields: []interface{}{"user", "timestamp"}, settings: `{"index.sort.field":["timestamp","user"]}`, want: []interface{}{"timestamp", "user"}},
+		{name: "nested settings", fields: []interface{}{"user", "timestamp"}, settings: `{"index":{"sort":{"field":["timestamp","user"]}}}`, want: []interface{}{"timestamp", "user"}},
+		{name: "other fields", fields: []interface{}{"user", "timestamp"}, settings: `{"index.sort.field":["timestamp","host"]}`, want: []interface{}{"user", "timestamp"}},
+		{name: "no settings", fields: []interface{}{"user", "timestamp"}, want: []interface{}{"user", "timestamp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := upgrader.Upgrade(context.Background(), map[string]interface{}{
+				"sort_field":   tt.fields,
+				"settings_raw": tt.settings,
+			}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(state["sort_field"], tt.want) {
+				t.Errorf("sort_field = %v, want %v", state["sort_field"], tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIndices() *schema.Resource {
+	indicesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"target": {
+			Description: "Name of the indices, data streams or aliases to list. Supports wildcards and comma-separated lists, the missing indices are ignored.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"include_hidden": {
+			Description: "Whether the wildcards match the hidden indices, e.g. the backing indices of the data streams.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"include_system": {
+			Description: "Whether the system indices are listed. The system indices are hidden, so `include_hidden` must be enabled to match them with wildcards.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"include_closed": {
+			Description: "Whether the wildcards match the closed indices.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"include_defaults": {
+			Description: "Whether the `settings` and the `default_settings` of the indices are read. Off by default, as the defaults contain hundreds of settings.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"indices": {
+			Description: "The matching indices, sorted by the index name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the index.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"aliases": {
+						Description: "Aliases of the index.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"data_stream": {
+						Description: "Name of the data stream, if the index is a backing index of a data stream.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"hidden": {
+						Description: "Whether the index is hidden.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"system": {
+						Description: "Whether the index is a system index.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"closed": {
+						Description: "Whether the index is closed.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"settings": {
+						Description: "The flat settings set on the index, e.g. `index.number_of_shards`. Only read if `include_defaults` is enabled.",
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"default_settings": {
+						Description: "The flat default values of the settings which are not set on the index. Only read if `include_defaults` is enabled.",
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(indicesSchema)
+
+	return &schema.Resource{
+		Description: "Lists the indices matching the target, with their aliases and data streams. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-resolve-index-api.html",
+		ReadContext: dataSourceIndicesRead,
+		Schema:      indicesSchema,
+	}
+}
+
+func dataSourceIndicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	target := d.Get("target").(string)
+	id, diags := client.ID(ctx, target)
+	if diags.HasError() {
+		return diags
+	}
+
+	expandWildcards := []string{"open"}
+	if d.Get("include_closed").(bool) {
+		expandWildcards = append(expandWildcards, "closed")
+	}
+	if d.Get("include_hidden").(bool) {
+		expandWildcards = append(expandWildcards, "hidden")
+	}
+	resolved, diags := elasticsearch.ResolveIndices(ctx, client, strings.Split(target, ","), strings.Join(expandWildcards, ","))
+	if diags.HasError() {
+		return diags
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Name < resolved[j].Name })
+
+	var settings map[string]models.IndexSettings
+	if d.Get("include_defaults").(bool) {
+		settings, diags = elasticsearch.GetIndicesSettings(ctx, client, strings.Split(target, ","), strings.Join(expandWildcards, ","), true)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	includeSystem := d.Get("include_system").(bool)
+	indices := make([]interface{}, 0, len(resolved))
+	for _, idx := range resolved {
+		if idx.HasAttribute("system") && !includeSystem {
+			continue
+		}
+		aliases := idx.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+		index := map[string]interface{}{
+			"name":        idx.Name,
+			"aliases":     aliases,
+			"data_stream": idx.DataStream,
+			"hidden":      idx.HasAttribute("hidden"),
+			"system":      idx.HasAttribute("system"),
+			"closed":      idx.HasAttribute("closed"),
+		}
+		if s, ok := settings[idx.Name]; ok {
+			userSettings, err := flattenSettingValues(s.Settings)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			defaultSettings, err := flattenSettingValues(s.Defaults)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			index["settings"] = userSettings
+			index["default_settings"] = defaultSettings
+		}
+		indices = append(indices, index)
+	}
+	if err := d.Set("indices", indices); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// flattenSettingValues converts the flat settings into strings, the list settings are converted into JSON lists.
+func flattenSettingValues(settings map[string]interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		if s, ok := v.(string); ok {
+			values[k] = s
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		values[k] = string(b)
+	}
+	return values, nil
+}
@@ -0,0 +1,87 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIndices(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndices(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.visible", "indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.visible", "indices.0.name", name+"-visible"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.visible", "indices.0.aliases.0", name+"-alias"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.visible", "indices.0.hidden", "false"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.hidden", "indices.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.hidden", "indices.0.name", name+"-hidden"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.hidden", "indices.0.hidden", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.missing", "indices.#", "0"),
+					resource.TestCheckNoResourceAttr("data.elasticstack_elasticsearch_indices.visible", "indices.0.settings.index.number_of_shards"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.defaults", "indices.0.settings.index.hidden", "true"),
+					resource.TestCheckNoResourceAttr("data.elasticstack_elasticsearch_indices.defaults", "indices.0.default_settings.index.hidden"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.defaults", "indices.0.default_settings.index.refresh_interval", "1s"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIndices(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "visible" {
+  name = "%[1]s-visible"
+
+  alias {
+    name = "%[1]s-alias"
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "hidden" {
+  name = "%[1]s-hidden"
+
+  settings {
+    setting {
+      name  = "index.hidden"
+      value = "true"
+    }
+  }
+}
+
+data "elasticstack_elasticsearch_indices" "visible" {
+  target = "%[1]s-*"
+
+  depends_on = [elasticstack_elasticsearch_index.visible, elasticstack_elasticsearch_index.hidden]
+}
+
+data "elasticstack_elasticsearch_indices" "hidden" {
+  target         = "%[1]s-*"
+  include_hidden = true
+
+  depends_on = [elasticstack_elasticsearch_index.visible, elasticstack_elasticsearch_index.hidden]
+}
+
+data "elasticstack_elasticsearch_indices" "defaults" {
+  target           = elasticstack_elasticsearch_index.hidden.name
+  include_defaults = true
+}
+
+data "elasticstack_elasticsearch_indices" "missing" {
+  target = "%[1]s-missing"
+}
+`, name)
+}
@@ -0,0 +1,45 @@
+package index
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// lifecycleSettingsKeys are the index settings configuring the index lifecycle management.
+var lifecycleSettingsKeys = []string{
+	"lifecycle.name",
+	"lifecycle.rollover_alias",
+	"lifecycle.origination_date",
+	"lifecycle.parse_origination_date",
+}
+
+// validateLifecycleRolloverAlias checks that the rollover alias is set when the index uses an ILM policy with a rollover action,
+// as otherwise the rollover of the index fails only once ILM executes it.
+func validateLifecycleRolloverAlias(ctx context.Context, client *clients.ApiClient, settings map[string]interface{}) diag.Diagnostics {
+	settings = utils.NormalizeIndexSettings(settings)
+	policyName, _ := settings["index.lifecycle.name"].(string)
+	if policyName == "" {
+		return nil
+	}
+	if alias, _ := settings["index.lifecycle.rollover_alias"].(string); alias != "" {
+		return nil
+	}
+	policy, diags := elasticsearch.GetIlm(ctx, client, policyName)
+	if diags.HasError() {
+		return diags
+	}
+	if policy == nil {
+		// Elasticsearch accepts referencing the policy before it's created
+		return nil
+	}
+	for phaseName, phase := range policy.Policy.Phases {
+		if _, ok := phase.Actions["rollover"]; ok {
+			return diag.Errorf(`the ILM policy "%s" has a rollover action in the "%s" phase, "lifecycle_rollover_alias" must be set`, policyName, phaseName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceMapping() *schema.Resource {
+	mappingSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Name of the existing index to add the mappings to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"properties": {
+			Description: `JSON object containing the field mappings managed by this resource.
+**NOTE:**
+- Only the fields defined here are managed, the fields added to the index by other means (e.g. dynamic mapping) are ignored.
+- The data type of the existing fields cannot be changed, re-index the data into a new index instead.
+- Removing the field will be ignored by Elasticsearch. You need to recreate the index to remove the field completely.
+`,
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
+		},
+	}
+
+	utils.AddConnectionSchema(mappingSchema)
+	utils.AddTimeoutsSchema(mappingSchema)
+
+	return &schema.Resource{
+		Description: "Manages the field mappings of an existing Elasticsearch index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-put-mapping.html",
+
+		CreateContext: resourceMappingPut,
+		UpdateContext: resourceMappingPut,
+		ReadContext:   resourceMappingRead,
+		DeleteContext: resourceMappingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Id() == "" || !d.HasChange("properties") {
+				return nil
+			}
+			o, n := d.GetChange("properties")
+			oldProps := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(o.(string)), &oldProps); err != nil {
+				return nil
+			}
+			newProps := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(n.(string)), &newProps); err != nil {
+				return nil
+			}
+			if changed := mappingTypeChanges(oldProps, newProps, ""); len(changed) > 0 {
+				return fmt.Errorf("the data type of the existing fields cannot be changed: %s. Re-index the data into a new index instead", strings.Join(changed, ", "))
+			}
+			return nil
+		},
+
+		Schema: mappingSchema,
+	}
+}
+
+func resourceMappingPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	indexName := d.Get("index").(string)
+	id, diags := client.ID(ctx, indexName)
+	if diags.HasError() {
+		return diags
+	}
+
+	properties := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(d.Get("properties").(string)), &properties); err != nil {
+		return diag.FromErr(err)
+	}
+	mappings, err := json.Marshal(map[string]interface{}{"properties": properties})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := elasticsearch.UpdateIndexMappings(ctx, client, indexName, string(mappings)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceMappingRead(ctx, d, meta)
+}
+
+func resourceMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	indexName := compId.ResourceId
+
+	mappings, diags := elasticsearch.GetIndexMappings(ctx, client, indexName)
+	if mappings == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Index "%s" not found, removing mappings from state`, indexName))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	serverProps := make(map[string]interface{})
+	if props, ok := mappings["properties"].(map[string]interface{}); ok {
+		serverProps = props
+	}
+
+	// when importing there are no managed fields yet, so we take all of them
+	properties := serverProps
+	if v, ok := d.GetOk("properties"); ok {
+		managedProps := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v.(string)), &managedProps); err != nil {
+			return diag.FromErr(err)
+		}
+		properties = filterManagedProperties(serverProps, managedProps)
+	}
+
+	props, err := json.Marshal(properties)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("index", indexName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("properties", string(props)); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, fmt.Sprintf(`Mappings of the index "%s" cannot be removed, they are only removed from the state. Recreate the index to remove them completely.`, d.Get("index").(string)))
+	return nil
+}
+
+// filterManagedProperties returns only those fields of the server mappings, which are defined in the managed properties.
+func filterManagedProperties(server, managed map[string]interface{}) map[string]interface{} {
+	filtered := make(map[string]interface{})
+	for k, v := range managed {
+		serverField, ok := server[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		managedField, ok := v.(map[string]interface{})
+		if !ok {
+			filtered[k] = serverField
+			continue
+		}
+		field := make(map[string]interface{})
+		for fk, fv := range serverField {
+			field[fk] = fv
+		}
+		if managedSub, ok := managedField["properties"].(map[string]interface{}); ok {
+			if serverSub, ok := serverField["properties"].(map[string]interface{}); ok {
+				field["properties"] = filterManagedProperties(serverSub, managedSub)
+			}
+		}
+		filtered[k] = field
+	}
+	return filtered
+}
+
+// mappingTypeChanges returns the paths of the existing fields, which data types differ between the old and the new properties.
+func mappingTypeChanges(old, new map[string]interface{}, prefix string) []string {
+	var changed []string
+	for k, v := range old {
+		oldField, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		newField, ok := new[k].(map[string]interface{})
+		if !ok {
+			// removed fields are ignored by elasticsearch
+			continue
+		}
+		if !reflect.DeepEqual(fieldType(oldField), fieldType(newField)) {
+			changed = append(changed, prefix+k)
+			continue
+		}
+		if oldSub, ok := oldField["properties"].(map[string]interface{}); ok {
+			if newSub, ok := newField["properties"].(map[string]interface{}); ok {
+				changed = append(changed, mappingTypeChanges(oldSub, newSub, prefix+k+".")...)
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// fieldType returns the data type of the field, object fields don't need to define their type explicitly.
+func fieldType(field map[string]interface{}) interface{} {
+	if t, ok := field["type"]; ok {
+		return t
+	}
+	if _, ok := field["properties"]; ok {
+		return "object"
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package index
+
+// mappingLimitsSettingsKeys are the index settings limiting the size of the mappings, to prevent a mapping explosion.
+var mappingLimitsSettingsKeys = []string{
+	"mapping.total_fields.limit",
+	"mapping.depth.limit",
+	"mapping.nested_fields.limit",
+	"mapping.nested_objects.limit",
+}
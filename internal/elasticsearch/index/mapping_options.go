@@ -0,0 +1,174 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var dynamicMappingModes = []string{"true", "false", "strict", "runtime"}
+
+func mappingDynamicSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:  "Whether new fields are added to the mappings dynamically: `true`, `false` (new fields are ignored), `strict` (documents with new fields are rejected) or `runtime` (new fields are added as runtime fields). Can not be used together with `dynamic` in the `mappings`. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/dynamic.html",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(dynamicMappingModes, false),
+	}
+}
+
+func mappingSourceSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Description: "Configuration of the `_source` field storing the original documents. Can not be used together with `_source` in the `mappings`. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-source-field.html",
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    forceNew,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Description: "If false, the `_source` field is not stored.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					ForceNew:    forceNew,
+				},
+				"includes": {
+					Description: "Fields to include in the stored `_source`. Supports wildcards.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					ForceNew:    forceNew,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"excludes": {
+					Description: "Fields to exclude from the stored `_source`. Supports wildcards.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					ForceNew:    forceNew,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandMappingOptions merges the `mapping_dynamic` and `mapping_source` attributes into the mappings.
+func expandMappingOptions(mappings map[string]interface{}, dynamic string, source []interface{}) diag.Diagnostics {
+	if dynamic != "" {
+		if _, ok := mappings["dynamic"]; ok {
+			return diag.FromErr(fmt.Errorf("the `dynamic` mode is already defined in the `mappings`, please remove it from `mappings` to use `mapping_dynamic`"))
+		}
+		mappings["dynamic"] = dynamic
+	}
+	if len(source) > 0 && source[0] != nil {
+		if _, ok := mappings["_source"]; ok {
+			return diag.FromErr(fmt.Errorf("the `_source` is already defined in the `mappings`, please remove it from `mappings` to use `mapping_source`"))
+		}
+		s := source[0].(map[string]interface{})
+		src := map[string]interface{}{
+			"enabled": s["enabled"].(bool),
+		}
+		if includes := s["includes"].([]interface{}); len(includes) > 0 {
+			src["includes"] = includes
+		}
+		if excludes := s["excludes"].([]interface{}); len(excludes) > 0 {
+			src["excludes"] = excludes
+		}
+		mappings["_source"] = src
+	}
+	return nil
+}
+
+// expandTemplateMappingOptions merges the typed mapping attributes of the template into the template mappings.
+func expandTemplateMappingOptions(definedTempl map[string]interface{}, templ *models.Template) diag.Diagnostics {
+	dynamic, _ := definedTempl["mapping_dynamic"].(string)
+	source, _ := definedTempl["mapping_source"].([]interface{})
+	if dynamic == "" && len(source) == 0 {
+		return nil
+	}
+	if templ.Mappings == nil {
+		templ.Mappings = make(map[string]interface{})
+	}
+	return expandMappingOptions(templ.Mappings, dynamic, source)
+}
+
+// extractMappingOptions removes the `dynamic` mode and the `_source` from the mappings read from the cluster,
+// when they are managed using the typed attributes, and returns them flattened.
+func extractMappingOptions(mappings map[string]interface{}, manageDynamic, manageSource bool) (map[string]interface{}, interface{}, []interface{}) {
+	rest := make(map[string]interface{}, len(mappings))
+	for k, v := range mappings {
+		rest[k] = v
+	}
+
+	var dynamic interface{}
+	if manageDynamic {
+		if v, ok := rest["dynamic"]; ok {
+			// the mode is returned as a string or a boolean depending on how it was set
+			dynamic = fmt.Sprint(v)
+			delete(rest, "dynamic")
+		}
+	}
+
+	var source []interface{}
+	if manageSource {
+		if v, ok := rest["_source"].(map[string]interface{}); ok {
+			s := map[string]interface{}{
+				"enabled":  true,
+				"includes": v["includes"],
+				"excludes": v["excludes"],
+			}
+			if enabled, ok := v["enabled"].(bool); ok {
+				s["enabled"] = enabled
+			}
+			source = []interface{}{s}
+			delete(rest, "_source")
+		}
+	}
+	return rest, dynamic, source
+}
+
+// removeDefaultDynamic removes the default `dynamic` mode from the mappings read from the cluster when it's not set in
+// the configured mappings, as the mode is reset to the default when `mapping_dynamic` is removed.
+func removeDefaultDynamic(mappings map[string]interface{}, configuredMappings string) {
+	if v, ok := mappings["dynamic"]; !ok || fmt.Sprint(v) != "true" {
+		return
+	}
+	configured := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(configuredMappings), &configured); err == nil {
+		if _, ok := configured["dynamic"]; ok {
+			return
+		}
+	}
+	delete(mappings, "dynamic")
+}
+
+// flattenTemplateMappings converts the template mappings read from the cluster into the `mappings` JSON
+// and the typed mapping attributes currently used by the template.
+func flattenTemplateMappings(mappings map[string]interface{}, d *schema.ResourceData, tmpl map[string]interface{}) diag.Diagnostics {
+	_, manageDynamic := d.GetOk("template.0.mapping_dynamic")
+	_, manageSource := d.GetOk("template.0.mapping_source")
+	rest, dynamic, source := extractMappingOptions(mappings, manageDynamic, manageSource)
+	if dynamic != nil {
+		tmpl["mapping_dynamic"] = dynamic
+	}
+	if source != nil {
+		tmpl["mapping_source"] = source
+	}
+	if len(rest) == 0 && (manageDynamic || manageSource) {
+		return nil
+	}
+	m, err := json.Marshal(rest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tmpl["mappings"] = string(m)
+	return nil
+}
@@ -0,0 +1,123 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceIndexMapping(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMappingCreate(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_mapping.test", "index", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_mapping.test", "properties", `{"field1":{"type":"keyword"}}`),
+				),
+			},
+			{
+				Config: testAccResourceIndexMappingUpdate(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_mapping.test", "index", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_mapping.test", "properties", `{"field1":{"type":"keyword"},"field2":{"properties":{"child":{"type":"long"}}}}`),
+				),
+			},
+			{
+				Config:      testAccResourceIndexMappingTypeChange(indexName),
+				ExpectError: regexp.MustCompile(`the data type of the existing fields cannot be changed: field1`),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexMappingCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  // the mappings are managed by the dedicated resource below
+  lifecycle {
+    ignore_changes = [mappings]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_mapping" "test" {
+  index = elasticstack_elasticsearch_index.test.name
+  properties = jsonencode({
+    field1 = { type = "keyword" }
+  })
+}
+	`, name)
+}
+
+func testAccResourceIndexMappingUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  // the mappings are managed by the dedicated resource below
+  lifecycle {
+    ignore_changes = [mappings]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_mapping" "test" {
+  index = elasticstack_elasticsearch_index.test.name
+  properties = jsonencode({
+    field1 = { type = "keyword" }
+    field2 = {
+      properties = {
+        child = { type = "long" }
+      }
+    }
+  })
+}
+	`, name)
+}
+
+func testAccResourceIndexMappingTypeChange(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%s"
+
+  // the mappings are managed by the dedicated resource below
+  lifecycle {
+    ignore_changes = [mappings]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_mapping" "test" {
+  index = elasticstack_elasticsearch_index.test.name
+  properties = jsonencode({
+    field1 = { type = "text" }
+    field2 = {
+      properties = {
+        child = { type = "long" }
+      }
+    }
+  })
+}
+	`, name)
+}
@@ -0,0 +1,179 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pipelineSettingsKeys are the index settings referencing the ingest pipelines.
+var pipelineSettingsKeys = []string{"default_pipeline", "final_pipeline"}
+
+// noPipeline is the special pipeline name disabling the default or final pipeline.
+const noPipeline = "_none"
+
+// templatePipelineSchema returns the schema of the `default_pipeline` or `final_pipeline` attribute of the templates.
+func templatePipelineSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("The %s ingest pipeline of the indices created from the template, sets `index.%s_pipeline`. The pipeline must exist. Can not be used together with the same setting in the `settings`.", kind, kind),
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+}
+
+// validatePipelinesExist checks that the referenced ingest pipelines exist, as Elasticsearch only fails when indexing into the index.
+func validatePipelinesExist(ctx context.Context, client *clients.ApiClient, pipelines map[string]string) diag.Diagnostics {
+	for key, name := range pipelines {
+		if name == "" || name == noPipeline {
+			continue
+		}
+		pipeline, diags := elasticsearch.GetIngestPipeline(ctx, client, &name)
+		if diags.HasError() {
+			return diags
+		}
+		if pipeline == nil {
+			return diag.Errorf(`the ingest pipeline "%s" referenced by "%s" does not exist`, name, key)
+		}
+	}
+	return nil
+}
+
+// checkPipelineReferences checks at apply time that the referenced ingest pipelines exist, as configured with the
+// `validate_pipeline_references` of the provider. The missing pipelines only fail the apply in the `error` mode, and
+// are returned as warning diagnostics in the `warn` mode.
+func checkPipelineReferences(ctx context.Context, client *clients.ApiClient, pipelines map[string]string) diag.Diagnostics {
+	mode := client.PipelineReferencesValidation()
+	if mode == "off" {
+		return nil
+	}
+	diags := validatePipelinesExist(ctx, client, pipelines)
+	if !diags.HasError() || mode == "error" {
+		return diags
+	}
+	for i := range diags {
+		diags[i].Severity = diag.Warning
+	}
+	return diags
+}
+
+// indexPipelines returns the pipelines configured for the index, only the changed ones if requested.
+func indexPipelines(d *schema.ResourceData, onlyChanged bool) map[string]string {
+	pipelines := make(map[string]string)
+	for _, key := range pipelineSettingsKeys {
+		if onlyChanged && !d.HasChange(key) {
+			continue
+		}
+		pipelines[key] = d.Get(key).(string)
+	}
+	return pipelines
+}
+
+// expandTemplatePipelines merges the pipeline attributes of the template into the template settings.
+func expandTemplatePipelines(definedTempl map[string]interface{}, templ *models.Template) diag.Diagnostics {
+	for _, key := range pipelineSettingsKeys {
+		pipeline, _ := definedTempl[key].(string)
+		if pipeline == "" {
+			continue
+		}
+		if templ.Settings == nil {
+			templ.Settings = make(map[string]interface{})
+		}
+		if _, ok := utils.NormalizeIndexSettings(utils.FlattenMap(templ.Settings))["index."+key]; ok {
+			return diag.FromErr(fmt.Errorf("the `%s` is already defined in the `settings`, please remove it from `settings` to use the `%s` attribute", key, key))
+		}
+		templ.Settings["index."+key] = pipeline
+	}
+	return nil
+}
+
+// templatePipelines returns the pipelines set by the pipeline attributes of the template.
+func templatePipelines(definedTempl map[string]interface{}) map[string]string {
+	pipelines := make(map[string]string)
+	for _, key := range pipelineSettingsKeys {
+		if pipeline, _ := definedTempl[key].(string); pipeline != "" {
+			pipelines[key] = pipeline
+		}
+	}
+	return pipelines
+}
+
+// validateTemplatePipelineReferences checks at plan time that the pipelines set by the template exist, in the `error`
+// mode of the `validate_pipeline_references` of the provider, as the plan can not report warnings. The values unknown
+// at plan time are skipped, as well as the pipelines planned by an ingest pipeline resource. The latter are only
+// recorded when the pipeline is planned before the template, i.e. when the template depends on the pipeline resource.
+func validateTemplatePipelineReferences(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*clients.ApiClient)
+	if !ok || client == nil || client.GetESClient() == nil {
+		return nil
+	}
+	if client.PipelineReferencesValidation() != "error" {
+		return nil
+	}
+	if _, ok := d.GetOk("elasticsearch_connection"); ok {
+		// the resource connection is not known at plan time
+		return nil
+	}
+
+	pipelines := make(map[string]string)
+	for _, key := range pipelineSettingsKeys {
+		fieldKey := "template.0." + key
+		if !d.NewValueKnown(fieldKey) {
+			continue
+		}
+		if pipeline, _ := d.Get(fieldKey).(string); pipeline != "" && !client.IsPlannedPipeline(pipeline) {
+			pipelines[key] = pipeline
+		}
+	}
+	if d.NewValueKnown("template.0.settings") {
+		if v, _ := d.Get("template.0.settings").(string); v != "" {
+			settings := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(v), &settings); err != nil {
+				return err
+			}
+			s := utils.NormalizeIndexSettings(utils.FlattenMap(settings))
+			for _, key := range pipelineSettingsKeys {
+				if pipeline, _ := s["index."+key].(string); pipeline != "" && !client.IsPlannedPipeline(pipeline) {
+					pipelines[key] = pipeline
+				}
+			}
+		}
+	}
+	if len(pipelines) == 0 {
+		return nil
+	}
+
+	if diags := validatePipelinesExist(ctx, client, pipelines); diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
+	return nil
+}
+
+// extractPipelineSettings removes the managed pipeline settings from the settings read from the cluster,
+// an absent setting is returned as an empty pipeline.
+func extractPipelineSettings(settings map[string]interface{}, managed []string) (map[string]string, map[string]interface{}) {
+	pipelines := make(map[string]string, len(managed))
+	for _, key := range managed {
+		pipelines[key] = ""
+	}
+	if len(managed) == 0 {
+		return pipelines, settings
+	}
+	rest := make(map[string]interface{})
+	for k, v := range utils.FlattenMap(settings) {
+		key := strings.TrimPrefix(k, "index.")
+		if _, ok := pipelines[key]; ok {
+			pipelines[key] = fmt.Sprintf("%v", v)
+			continue
+		}
+		rest[k] = v
+	}
+	return pipelines, rest
+}
@@ -0,0 +1,9 @@
+package index
+
+// searchSettingsKeys are the index settings tuning the cost of the searches, e.g. skipping the refresh of the search idle shards.
+var searchSettingsKeys = []string{
+	"search.idle.after",
+	"max_result_window",
+	"max_rescore_window",
+	"highlight.max_analyzed_offset",
+}
@@ -0,0 +1,226 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Static index settings with a dedicated field in the index resource, the field being the setting key with `_` instead of `.`.
+// Together with `untypedStaticSettingsKeys` they drive both the fields replacing the index and `isStaticSetting`.
+var staticSettingsKeys = map[string]schema.ValueType{
+	"number_of_shards":                  schema.TypeInt,
+	"number_of_routing_shards":          schema.TypeInt,
+	"codec":                             schema.TypeString,
+	"routing_partition_size":            schema.TypeInt,
+	"load_fixed_bitset_filters_eagerly": schema.TypeBool,
+	"shard.check_on_startup":            schema.TypeString,
+	"sort.field":                        schema.TypeList,
+	"sort.order":                        schema.TypeList,
+	"sort.mode":                         schema.TypeList,
+	"sort.missing":                      schema.TypeList,
+	"mapping.coerce":                    schema.TypeBool,
+}
+
+// Static index settings, which do not have a dedicated field in the index resource, but can still be set using the
+// deprecated `settings` block. The keys ending with `.` match all the settings with the given prefix.
+// See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#_static_index_settings
+var untypedStaticSettingsKeys = []string{
+	"analysis.",
+	"similarity.",
+	"soft_deletes.enabled",
+	"store.type",
+	"store.preload",
+}
+
+// staticSettingsMinVersion lists the static settings, which are only known starting with the given Elasticsearch version.
+// On the older versions they are passed to Elasticsearch as any other unknown setting.
+var staticSettingsMinVersion = map[string]*version.Version{
+	"mode":         version.Must(version.NewVersion("8.1.0")),
+	"routing_path": version.Must(version.NewVersion("8.1.0")),
+}
+
+// closedIndexSettingsKeys are the static settings, which can still be updated while the index is closed. The keys ending
+// with `.` match all the settings with the given prefix.
+// See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-update-settings.html#update-settings-analysis
+var closedIndexSettingsKeys = []string{
+	"codec",
+	"load_fixed_bitset_filters_eagerly",
+	"shard.check_on_startup",
+	"mapping.coerce",
+	"analysis.",
+	"similarity.",
+}
+
+func isClosedIndexSetting(key string) bool {
+	key = strings.TrimPrefix(key, "index.")
+	for _, k := range closedIndexSettingsKeys {
+		if key == k || (strings.HasSuffix(k, ".") && strings.HasPrefix(key, k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isKeptClosed reports whether the existing index is closed and stays closed, in which case its closed index settings
+// are updated in place.
+func isKeptClosed(d interface {
+	Id() string
+	GetChange(string) (interface{}, interface{})
+}) bool {
+	o, n := d.GetChange("open")
+	return d.Id() != "" && !o.(bool) && !n.(bool)
+}
+
+// forceNewOnClosedIndexSettingsChange replaces the index when a static setting with a dedicated field, or the
+// `analysis` block, which can be updated on a closed index, is changed while the index is open.
+func forceNewOnClosedIndexSettingsChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || isKeptClosed(d) {
+		return nil
+	}
+	if d.HasChange("analysis") {
+		if err := d.ForceNew("analysis"); err != nil {
+			return err
+		}
+	}
+	for key := range staticSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if isClosedIndexSetting(key) && d.HasChange(fieldKey) {
+			if err := d.ForceNew(fieldKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isStaticSetting checks whether the index setting can only be set on the index creation.
+// The server version may be nil when it's not known, in which case all the version dependent settings are considered static.
+func isStaticSetting(key string, serverVersion *version.Version) bool {
+	key = strings.TrimPrefix(key, "index.")
+	if _, ok := staticSettingsKeys[key]; ok {
+		return true
+	}
+	if minVersion, ok := staticSettingsMinVersion[key]; ok {
+		return serverVersion == nil || serverVersion.GreaterThanOrEqual(minVersion)
+	}
+	for _, k := range untypedStaticSettingsKeys {
+		if key == k || (strings.HasSuffix(k, ".") && strings.HasPrefix(key, k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// forceNewOnStaticSettingsChange replaces the index when a static setting is changed in the deprecated `settings` block,
+// the dynamic settings, and the closed index settings of an index kept closed, are still updated in place.
+func forceNewOnStaticSettingsChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("settings") {
+		return nil
+	}
+	o, n := d.GetChange("settings")
+	oldSettings := flattenIndexSettings(o.([]interface{}))
+	newSettings := flattenIndexSettings(n.([]interface{}))
+	keptClosed := isKeptClosed(d)
+	changed := func(key string) bool {
+		ov, inOld := oldSettings[key]
+		nv, inNew := newSettings[key]
+		return inOld != inNew || ov != nv
+	}
+	var changedKeys []string
+	var serverVersion *version.Version
+	versionLookedUp := false
+	for _, settings := range []map[string]interface{}{oldSettings, newSettings} {
+		for key := range settings {
+			if !changed(key) {
+				continue
+			}
+			changedKeys = append(changedKeys, key)
+			// the server version is only looked up for the settings which are static since a version, e.g. `mode`
+			if _, ok := staticSettingsMinVersion[strings.TrimPrefix(key, "index.")]; ok && !versionLookedUp {
+				serverVersion = planServerVersion(ctx, d, meta)
+				versionLookedUp = true
+			}
+		}
+	}
+	for _, key := range changedKeys {
+		if isStaticSetting(key, serverVersion) && !(keptClosed && isClosedIndexSetting(key)) {
+			return d.ForceNew("settings")
+		}
+	}
+	return nil
+}
+
+// convertSettingValue converts the setting value read using the flat settings to the type of the resource field.
+func convertSettingValue(key string, typ schema.ValueType, value interface{}) (interface{}, error) {
+	switch typ {
+	case schema.TypeList, schema.TypeSet:
+		// the single values of the array settings are stored as a string
+		if v, ok := value.(string); ok {
+			return []interface{}{v}, nil
+		}
+	case schema.TypeInt:
+		v, err := strconv.Atoi(fmt.Sprintf("%v", value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert setting '%s' value %v to int: %w", key, value, err)
+		}
+		return v, nil
+	case schema.TypeBool:
+		v, err := strconv.ParseBool(fmt.Sprintf("%v", value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert setting '%s' value %v to bool: %w", key, value, err)
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+// flattenConfiguredSettings reads back the given dynamic settings when they're configured, the settings unset on the server
+// are read as their zero value, the same as when they're not configured, so that only the configured settings are compared.
+func flattenConfiguredSettings(d *schema.ResourceData, settings map[string]interface{}, keys []string) diag.Diagnostics {
+	for _, key := range keys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if _, ok := d.GetOk(fieldKey); !ok {
+			continue
+		}
+		typ := dynamicsSettingsKeys[key]
+		value, ok := settings["index."+key]
+		if !ok {
+			value = zeroSettingValue(typ)
+		}
+		v, err := convertSettingValue(key, typ, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set(fieldKey, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// isRemovedSetting reports whether the field of the setting is not set in the configuration, falling back to the zero
+// value when the configuration is not known.
+func isRemovedSetting(config cty.Value, fieldKey string, typ schema.ValueType, value interface{}) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return value == zeroSettingValue(typ)
+	}
+	return config.GetAttr(fieldKey).IsNull()
+}
+
+func zeroSettingValue(typ schema.ValueType) interface{} {
+	switch typ {
+	case schema.TypeInt:
+		return 0
+	case schema.TypeBool:
+		return false
+	}
+	return ""
+}
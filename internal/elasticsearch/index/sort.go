@@ -0,0 +1,174 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	sortOrders   = []string{"asc", "desc"}
+	sortModes    = []string{"min", "max"}
+	sortMissings = []string{"_last", "_first"}
+)
+
+// validateIndexSort checks that the index sort settings have a value per sort field, and that the sort fields are mapped when the mappings are given.
+func validateIndexSort(fields, orders, modes, missings []string, mappings map[string]interface{}) error {
+	for _, setting := range []struct {
+		name    string
+		values  []string
+		allowed []string
+	}{
+		{"order", orders, sortOrders},
+		{"mode", modes, sortModes},
+		{"missing", missings, sortMissings},
+	} {
+		if len(setting.values) > 0 && len(setting.values) != len(fields) {
+			return fmt.Errorf(`"sort.%s" must have a value for each of the %d "sort.field", got %d`, setting.name, len(fields), len(setting.values))
+		}
+		for _, v := range setting.values {
+			if !isAllowedSortValue(v, setting.allowed) {
+				return fmt.Errorf(`"sort.%s" must be one of %s, got "%s"`, setting.name, strings.Join(setting.allowed, ", "), v)
+			}
+		}
+	}
+	if mappings == nil {
+		return nil
+	}
+	for _, f := range fields {
+		if !isMappedField(mappings, f) {
+			return fmt.Errorf(`the sort field "%s" is not defined in the mappings`, f)
+		}
+	}
+	return nil
+}
+
+func isAllowedSortValue(v string, allowed []string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+// isMappedField looks up the field with the dotted path in the properties, including the multi-fields.
+func isMappedField(mappings map[string]interface{}, path string) bool {
+	current := mappings
+	parts := strings.Split(path, ".")
+	for i, p := range parts {
+		var field map[string]interface{}
+		if props, ok := current["properties"].(map[string]interface{}); ok {
+			field, _ = props[p].(map[string]interface{})
+		}
+		if field == nil && i > 0 {
+			if fields, ok := current["fields"].(map[string]interface{}); ok {
+				field, _ = fields[p].(map[string]interface{})
+			}
+		}
+		if field == nil {
+			// the dotted field names are also allowed in the mappings, e.g. "a.b": {"type": "keyword"}
+			if props, ok := current["properties"].(map[string]interface{}); ok {
+				if f, ok := props[strings.Join(parts[i:], ".")].(map[string]interface{}); ok {
+					return f != nil
+				}
+			}
+			return false
+		}
+		current = field
+	}
+	return true
+}
+
+// sortSettingValues returns the values of the sort setting, the setting can be a single value or an array.
+func sortSettingValues(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return values
+	}
+	return nil
+}
+
+func listOfStrings(v interface{}) []string {
+	list, _ := v.([]interface{})
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		s, _ := item.(string)
+		values = append(values, s)
+	}
+	return values
+}
+
+// upgradeSortFieldOrder puts the sort fields of the state created while they were a set, in the order of the hash of
+// their values, in the order of the index sort read back in `settings_raw`.
+func upgradeSortFieldOrder(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	fields := sortSettingValues(rawState["sort_field"])
+	raw, _ := rawState["settings_raw"].(string)
+	if len(fields) < 2 || raw == "" {
+		return rawState, nil
+	}
+	settings := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return nil, err
+	}
+	indexFields := sortSettingValues(flattenIndexSettingKeys(settings)["index.sort.field"])
+	if len(indexFields) != len(fields) {
+		return rawState, nil
+	}
+	configured := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		configured[f] = true
+	}
+	ordered := make([]interface{}, 0, len(indexFields))
+	for _, f := range indexFields {
+		if !configured[f] {
+			return rawState, nil
+		}
+		ordered = append(ordered, f)
+	}
+	rawState["sort_field"] = ordered
+	return rawState, nil
+}
+
+func validateIndexSortDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	fields := listOfStrings(d.Get("sort_field"))
+	if len(fields) == 0 {
+		return nil
+	}
+	var mappings map[string]interface{}
+	if v, ok := d.GetOk("mappings"); ok && d.NewValueKnown("mappings") {
+		if err := json.Unmarshal([]byte(v.(string)), &mappings); err != nil {
+			return err
+		}
+	}
+	return validateIndexSort(fields, listOfStrings(d.Get("sort_order")), listOfStrings(d.Get("sort_mode")), listOfStrings(d.Get("sort_missing")), mappings)
+}
+
+// validateTemplateSortDiff checks the sort settings defined in the template settings. The sort fields may be mapped by other templates, so only the values are checked.
+func validateTemplateSortDiff(key string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		v, ok := d.GetOk(key)
+		if !ok || !d.NewValueKnown(key) {
+			return nil
+		}
+		settings := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v.(string)), &settings); err != nil {
+			return err
+		}
+		flattened := flattenIndexSettingKeys(settings)
+		fields := sortSettingValues(flattened["index.sort.field"])
+		if err := validateIndexSort(fields, sortSettingValues(flattened["index.sort.order"]), sortSettingValues(flattened["index.sort.mode"]), sortSettingValues(flattened["index.sort.missing"]), nil); err != nil {
+			return fmt.Errorf("invalid sort settings in %s: %w", key, err)
+		}
+		return nil
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -29,8 +32,14 @@ func ResourceTemplate() *schema.Resource {
 			Required:    true,
 			ForceNew:    true,
 		},
+		"allow_auto_create": {
+			Description: "Whether the indices or data streams matching the template can be created automatically by indexing a document, regardless of the `action.auto_create_index` cluster setting. If not set, the cluster setting applies. The templates without a `data_stream` block can not set it for the index patterns starting with `logs-`, `metrics-` or `synthetics-`, the prefixes of the built-in data stream templates, which is checked at plan time without looking up the templates defined in the cluster.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		"ignore_settings": ignoreSettingsSchema(),
 		"composed_of": {
-			Description: "An ordered list of component template names.",
+			Description: "An ordered list of component template names, merged in the order they are listed, the later component templates take precedence. Reordering them updates the template.",
 			Type:        schema.TypeList,
 			Optional:    true,
 			Computed:    true,
@@ -38,6 +47,14 @@ func ResourceTemplate() *schema.Resource {
 				Type: schema.TypeString,
 			},
 		},
+		"ignore_missing_component_templates": {
+			Description: "A list of component template names that are allowed to be absent when the template is created, they must be part of `composed_of`. Available since Elasticsearch **8.7**.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"data_stream": {
 			Description: "If this object is included, the template is used to create data streams and their backing indices. Supports an empty object.",
 			Type:        schema.TypeList,
@@ -68,14 +85,14 @@ func ResourceTemplate() *schema.Resource {
 			},
 		},
 		"metadata": {
-			Description:      "Optional user metadata about the index template.",
+			Description:      "Optional user metadata about the index template. The metadata marking the index template as managed by another system (`managed` is `true`, e.g. set by Fleet) is kept when not configured.",
 			Type:             schema.TypeString,
 			Optional:         true,
 			ValidateFunc:     validation.StringIsJSON,
-			DiffSuppressFunc: utils.DiffJsonSuppress,
+			DiffSuppressFunc: utils.DiffManagedMetadataSuppress,
 		},
 		"priority": {
-			Description:  "Priority to determine index template precedence when a new data stream or index is created.",
+			Description:  "Priority to determine index template precedence when a new data stream or index is created. Elasticsearch treats templates without priority as priority `0`.",
 			Type:         schema.TypeInt,
 			ValidateFunc: validation.IntAtLeast(0),
 			Optional:     true,
@@ -139,6 +156,7 @@ func ResourceTemplate() *schema.Resource {
 							},
 						},
 					},
+					"analysis": analysisSchema(),
 					"mappings": {
 						Description:      "Mapping for fields in the index.",
 						Type:             schema.TypeString,
@@ -146,6 +164,10 @@ func ResourceTemplate() *schema.Resource {
 						DiffSuppressFunc: utils.DiffJsonSuppress,
 						ValidateFunc:     validation.StringIsJSON,
 					},
+					"mapping_dynamic":  mappingDynamicSchema(),
+					"mapping_source":   mappingSourceSchema(false),
+					"default_pipeline": templatePipelineSchema("default"),
+					"final_pipeline":   templatePipelineSchema("final"),
 					"settings": {
 						Description:      "Configuration options for the index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#index-modules-settings",
 						Type:             schema.TypeString,
@@ -153,17 +175,21 @@ func ResourceTemplate() *schema.Resource {
 						DiffSuppressFunc: utils.DiffIndexSettingSuppress,
 						ValidateFunc:     validation.StringIsJSON,
 					},
+					"index_settings": templateIndexSettingsSchema(),
 				},
 			},
 		},
 		"version": {
-			Description: "Version number used to manage index templates externally.",
-			Type:        schema.TypeInt,
-			Optional:    true,
+			Description:      "Version number used to manage index templates externally. The version of the index template marked as managed by another system is kept when not configured.",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffManagedVersionSuppress,
 		},
 	}
 
 	utils.AddConnectionSchema(templateSchema)
+	utils.AddTimeoutsSchema(templateSchema)
+	utils.AddIgnoreManagedSchema(templateSchema)
 
 	return &schema.Resource{
 		Description: "Creates or updates an index template. Index templates define settings, mappings, and aliases that can be applied automatically to new indices. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-put-template.html",
@@ -174,9 +200,19 @@ func ResourceTemplate() *schema.Resource {
 		DeleteContext: resourceIndexTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importTemplate(indexTemplateResourceType),
 		},
 
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAnalysis("template.0.analysis", d.Get("template.0.analysis").([]interface{}))
+			},
+			validateTemplateSortDiff("template.0.settings"),
+			validateAllowAutoCreate,
+			validateIgnoreMissingComponentTemplates,
+			validateTemplatePipelineReferences,
+		),
+
 		Schema: templateSchema,
 	}
 }
@@ -186,14 +222,35 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 	if diags.HasError() {
 		return diags
 	}
-	templateId := d.Get("name").(string)
+	templateId, diags := client.TargetName(d)
+	if diags.HasError() {
+		return diags
+	}
 	id, diags := client.ID(ctx, templateId)
 	if diags.HasError() {
 		return diags
 	}
+	if d.Get("ignore_managed").(bool) {
+		existing, diags := elasticsearch.GetIndexTemplate(ctx, client, templateId)
+		if diags.HasError() {
+			return diags
+		}
+		if existing != nil {
+			if diags := utils.CheckManaged(d, "index template", templateId, existing.IndexTemplate.Meta); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
 	var indexTemplate models.IndexTemplate
 	indexTemplate.Name = templateId
 
+	// the false value must be sent explicitly only to override the previously enabled auto creation
+	if v, ok := d.GetOk("allow_auto_create"); ok || d.HasChange("allow_auto_create") {
+		allow := v.(bool)
+		indexTemplate.AllowAutoCreate = &allow
+	}
+
 	compsOf := make([]string, 0)
 	if v, ok := d.GetOk("composed_of"); ok {
 		for _, c := range v.([]interface{}) {
@@ -202,6 +259,19 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 	}
 	indexTemplate.ComposedOf = compsOf
 
+	if v, ok := d.GetOk("ignore_missing_component_templates"); ok {
+		serverVersion, diags := client.ServerVersion(ctx)
+		if diags.HasError() {
+			return diags
+		}
+		if serverVersion != nil && serverVersion.LessThan(IgnoreMissingComponentTemplatesMinSupportedVersion) {
+			return diag.Errorf("'ignore_missing_component_templates' is supported only for Elasticsearch v%s and above", IgnoreMissingComponentTemplatesMinSupportedVersion)
+		}
+		for _, c := range v.([]interface{}) {
+			indexTemplate.IgnoreMissingComponentTemplates = append(indexTemplate.IgnoreMissingComponentTemplates, c.(string))
+		}
+	}
+
 	if v, ok := d.GetOk("data_stream"); ok {
 		// 8.x workaround
 		hasAllowCustomRouting := false
@@ -256,6 +326,7 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 		indexTemplate.Priority = &definedPr
 	}
 
+	var pipelineDiags diag.Diagnostics
 	if v, ok := d.GetOk("template"); ok {
 		templ := models.Template{}
 		// only one template block allowed to be declared
@@ -286,6 +357,22 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 				templ.Settings = sets
 			}
 		}
+		if diags := expandTemplateAnalysis(definedTempl, &templ); diags.HasError() {
+			return diags
+		}
+		if diags := expandTemplateMappingOptions(definedTempl, &templ); diags.HasError() {
+			return diags
+		}
+		if diags := expandTemplatePipelines(definedTempl, &templ); diags.HasError() {
+			return diags
+		}
+		if diags := expandTemplateIndexSettings(d, &templ); diags.HasError() {
+			return diags
+		}
+		pipelineDiags = checkPipelineReferences(ctx, client, templatePipelines(definedTempl))
+		if pipelineDiags.HasError() {
+			return pipelineDiags
+		}
 
 		indexTemplate.Template = &templ
 	}
@@ -300,7 +387,7 @@ func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	d.SetId(id.String())
-	return resourceIndexTemplateRead(ctx, d, meta)
+	return append(pipelineDiags, resourceIndexTemplateRead(ctx, d, meta)...)
 }
 
 func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -324,13 +411,24 @@ func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta
 		return diags
 	}
 
+	// the object marked as managed since it was created is not refreshed, so that it can still be destroyed
+	if diags := utils.WarnManaged(d, "index template", tpl.Name, tpl.IndexTemplate.Meta); len(diags) > 0 {
+		return diags
+	}
+
 	// set the fields
-	if err := d.Set("name", tpl.Name); err != nil {
+	if err := d.Set("name", client.ConfiguredName(tpl.Name)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("allow_auto_create", tpl.IndexTemplate.AllowAutoCreate); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("composed_of", tpl.IndexTemplate.ComposedOf); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("ignore_missing_component_templates", tpl.IndexTemplate.IgnoreMissingComponentTemplates); err != nil {
+		return diag.FromErr(err)
+	}
 	if stream := tpl.IndexTemplate.DataStream; stream != nil {
 		ds := make([]interface{}, 1)
 		dSettings := make(map[string]interface{})
@@ -362,7 +460,7 @@ func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if tpl.IndexTemplate.Template != nil {
-		template, diags := flattenTemplateData(tpl.IndexTemplate.Template)
+		template, diags := flattenTemplateData(tpl.IndexTemplate.Template, d)
 		if diags.HasError() {
 			return diags
 		}
@@ -378,18 +476,123 @@ func resourceIndexTemplateRead(ctx context.Context, d *schema.ResourceData, meta
 	return diags
 }
 
-func flattenTemplateData(template *models.Template) ([]interface{}, diag.Diagnostics) {
+// builtinDataStreamPatternPrefixes are the prefixes of the index patterns used by the built-in data stream templates.
+// The check is a heuristic on the configured patterns, as the templates of the cluster matching a pattern can't be
+// simulated, the simulate index API only taking a concrete index name.
+var builtinDataStreamPatternPrefixes = []string{"logs-", "metrics-", "synthetics-"}
+
+// validateAllowAutoCreate rejects the templates auto creating regular indices for the names reserved for the data streams.
+func validateAllowAutoCreate(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("allow_auto_create").(bool) {
+		return nil
+	}
+	if ds, ok := d.GetOk("data_stream"); ok && len(ds.([]interface{})) > 0 {
+		return nil
+	}
+	var reserved []string
+	for _, p := range d.Get("index_patterns").(*schema.Set).List() {
+		for _, prefix := range builtinDataStreamPatternPrefixes {
+			if strings.HasPrefix(p.(string), prefix) {
+				reserved = append(reserved, p.(string))
+			}
+		}
+	}
+	if len(reserved) > 0 {
+		sort.Strings(reserved)
+		return fmt.Errorf("the index patterns %s are used by data streams, add the `data_stream` block to the template to auto create data streams instead of the regular indices", strings.Join(reserved, ", "))
+	}
+	return nil
+}
+
+var IgnoreMissingComponentTemplatesMinSupportedVersion = version.Must(version.NewVersion("8.7.0"))
+
+// validateIgnoreMissingComponentTemplates ensures only the component templates the template is composed of are ignored.
+func validateIgnoreMissingComponentTemplates(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// the unknown values are checked once they are known
+	if !d.NewValueKnown("ignore_missing_component_templates") || !d.NewValueKnown("composed_of") {
+		return nil
+	}
+	composedOf := make(map[string]bool)
+	for _, c := range d.Get("composed_of").([]interface{}) {
+		composedOf[c.(string)] = true
+	}
+	var unknown []string
+	for _, c := range d.Get("ignore_missing_component_templates").([]interface{}) {
+		if !composedOf[c.(string)] {
+			unknown = append(unknown, c.(string))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("the component templates %s are listed in `ignore_missing_component_templates` but not in `composed_of`", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func flattenTemplateData(template *models.Template, d *schema.ResourceData) ([]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	tmpl := make(map[string]interface{})
 	if template.Mappings != nil {
-		m, err := json.Marshal(template.Mappings)
+		if diags := flattenTemplateMappings(template.Mappings, d, tmpl); diags.HasError() {
+			return nil, diags
+		}
+	}
+	settings := template.Settings
+	// the analysis is kept in the settings unless it's managed using the analysis block
+	if currentAnalysis := d.Get("template.0.analysis").([]interface{}); settings != nil && len(currentAnalysis) > 0 {
+		analysis, rest := extractAnalysisSettings(settings)
+		flattenedAnalysis, diags := flattenAnalysis(analysis, currentAnalysis)
+		if diags.HasError() {
+			return nil, diags
+		}
+		tmpl["analysis"] = flattenedAnalysis
+		settings = nil
+		if len(rest) > 0 {
+			settings = rest
+		}
+	}
+	// the pipelines are kept in the settings unless they're managed using the dedicated attributes
+	var managedPipelines []string
+	for _, key := range pipelineSettingsKeys {
+		if _, ok := d.GetOk("template.0." + key); ok {
+			managedPipelines = append(managedPipelines, key)
+		}
+	}
+	if settings != nil && len(managedPipelines) > 0 {
+		pipelines, rest := extractPipelineSettings(settings, managedPipelines)
+		for key, pipeline := range pipelines {
+			tmpl[key] = pipeline
+		}
+		settings = nil
+		if len(rest) > 0 {
+			settings = rest
+		}
+	}
+	// the typed settings are read back into the index_settings block only when it's used
+	if currentSettings := d.Get("template.0.index_settings").([]interface{}); len(currentSettings) > 0 {
+		values, rest, err := extractTemplateIndexSettings(settings)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
-		tmpl["mappings"] = string(m)
+		tmpl["index_settings"] = []interface{}{values}
+		settings = nil
+		if len(rest) > 0 {
+			settings = rest
+		}
+	}
+	if ignored := utils.ExpandStringSet(d.Get("ignore_settings").(*schema.Set)); settings != nil && len(ignored) > 0 {
+		currentSettings := make(map[string]interface{})
+		if v := d.Get("template.0.settings").(string); v != "" {
+			if err := json.Unmarshal([]byte(v), &currentSettings); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+		settings = filterIgnoredSettings(settings, currentSettings, ignored)
+		if len(settings) == 0 {
+			settings = nil
+		}
 	}
-	if template.Settings != nil {
-		s, err := json.Marshal(template.Settings)
+	if settings != nil {
+		s, err := json.Marshal(settings)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -0,0 +1,208 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// templateSettingsKeys are the most common index settings, which can be set with the typed `index_settings` block of the templates.
+var templateSettingsKeys = map[string]schema.ValueType{
+	"number_of_shards":     schema.TypeInt,
+	"number_of_replicas":   schema.TypeInt,
+	"auto_expand_replicas": schema.TypeString,
+	"refresh_interval":     schema.TypeString,
+	"codec":                schema.TypeString,
+	"max_result_window":    schema.TypeInt,
+
+	"search.idle.after":             schema.TypeString,
+	"max_rescore_window":            schema.TypeInt,
+	"highlight.max_analyzed_offset": schema.TypeInt,
+
+	"mapping.total_fields.limit":   schema.TypeInt,
+	"mapping.depth.limit":          schema.TypeInt,
+	"mapping.nested_fields.limit":  schema.TypeInt,
+	"mapping.nested_objects.limit": schema.TypeInt,
+}
+
+func templateIndexSettingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:   "The most common index settings of the indices created from the template, as an alternative to the `settings` JSON. Can not be used together with `settings`.",
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"template.0.settings"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"number_of_shards": {
+					Description:  "Number of shards of the index, sets `index.number_of_shards`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"number_of_replicas": {
+					Description:  "Number of replicas of each primary shard, sets `index.number_of_replicas`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"auto_expand_replicas": {
+					Description: "Set the number of replicas to the node count in the cluster, sets `index.auto_expand_replicas`. Set to a dash delimited lower and upper bound (e.g. 0-5) or use all for the upper bound (e.g. 0-all).",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"refresh_interval": {
+					Description: "How often to perform a refresh operation, sets `index.refresh_interval`. Can be set to `-1` to disable refresh.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"codec": {
+					Description:  "The compression used to store the data, `default` or `best_compression`, sets `index.codec`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"default", "best_compression"}, false),
+				},
+				"max_result_window": {
+					Description:  "The maximum value of `from + size` for searches to the index, sets `index.max_result_window`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"search_idle_after": {
+					Description:      "How long a shard can not receive a search or get request until it’s considered search idle, sets `index.search.idle.after`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     utils.StringIsTimeValue,
+					DiffSuppressFunc: utils.DiffTimeValueSuppress,
+				},
+				"max_rescore_window": {
+					Description:  "The maximum value of `window_size` for `rescore` requests in searches of the index, sets `index.max_rescore_window`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"highlight_max_analyzed_offset": {
+					Description:  "The maximum number of characters that will be analyzed for a highlight request, sets `index.highlight.max_analyzed_offset`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"mapping_total_fields_limit": {
+					Description:  "The maximum number of fields in the index, sets `index.mapping.total_fields.limit`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"mapping_depth_limit": {
+					Description:  "The maximum depth of the fields, measured in the number of inner objects, sets `index.mapping.depth.limit`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"mapping_nested_fields_limit": {
+					Description:  "The maximum number of distinct `nested` mappings in the index, sets `index.mapping.nested_fields.limit`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"mapping_nested_objects_limit": {
+					Description:  "The maximum number of nested JSON objects that a single document can contain across all `nested` types, sets `index.mapping.nested_objects.limit`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// expandTemplateIndexSettings merges the `index_settings` block of the template into the template settings.
+// Only the configured settings are set, so that e.g. `number_of_replicas = 0` can be told apart from an unset value.
+func expandTemplateIndexSettings(d *schema.ResourceData, templ *models.Template) diag.Diagnostics {
+	block, _ := d.Get("template.0.index_settings").([]interface{})
+	if len(block) == 0 || block[0] == nil {
+		return nil
+	}
+	values := block[0].(map[string]interface{})
+	configured := configuredTemplateIndexSettings(d.GetRawConfig())
+	for key := range templateSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if configured != nil && !configured[fieldKey] {
+			continue
+		}
+		value := values[fieldKey]
+		if configured == nil && (value == 0 || value == "") {
+			continue
+		}
+		if templ.Settings == nil {
+			templ.Settings = make(map[string]interface{})
+		}
+		if _, ok := utils.NormalizeIndexSettings(utils.FlattenMap(templ.Settings))["index."+key]; ok {
+			return diag.FromErr(fmt.Errorf("the `%s` is already set by the other attributes of the template", key))
+		}
+		templ.Settings["index."+key] = value
+	}
+	return nil
+}
+
+// configuredTemplateIndexSettings returns the attributes of the `index_settings` block set in the configuration,
+// nil if the configuration is not known.
+func configuredTemplateIndexSettings(config cty.Value) map[string]bool {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	configured := make(map[string]bool)
+	block := nestedBlock(nestedBlock(config, "template"), "index_settings")
+	if block.IsNull() || !block.IsKnown() {
+		return configured
+	}
+	for key := range templateSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if v := block.GetAttr(fieldKey); !v.IsNull() {
+			configured[fieldKey] = true
+		}
+	}
+	return configured
+}
+
+// nestedBlock returns the first element of the block with at most one element, or a null value.
+func nestedBlock(v cty.Value, name string) cty.Value {
+	if v.IsNull() || !v.IsKnown() || !v.Type().IsObjectType() || !v.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	list := v.GetAttr(name)
+	if list.IsNull() || !list.IsKnown() || list.LengthInt() == 0 {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return list.Index(cty.NumberIntVal(0))
+}
+
+// extractTemplateIndexSettings removes the settings of the `index_settings` block from the flat template settings
+// read from the cluster, the absent settings are returned as their zero value.
+func extractTemplateIndexSettings(settings map[string]interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(templateSettingsKeys))
+	for key, typ := range templateSettingsKeys {
+		values[utils.ConvertSettingsKeyToTFFieldKey(key)] = zeroSettingValue(typ)
+	}
+	rest := make(map[string]interface{})
+	for k, v := range utils.FlattenMap(settings) {
+		key := strings.TrimPrefix(k, "index.")
+		typ, ok := templateSettingsKeys[key]
+		if !ok {
+			rest[k] = v
+			continue
+		}
+		value, err := convertSettingValue(key, typ, v)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[utils.ConvertSettingsKeyToTFFieldKey(key)] = value
+	}
+	return values, rest, nil
+}
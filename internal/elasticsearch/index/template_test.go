@@ -1,11 +1,17 @@
 package index_test
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -41,6 +47,176 @@ func TestAccResourceIndexTemplate(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "data_stream.0.hidden", "false"),
 				),
 			},
+			{
+				// the import by the plain template name
+				ResourceName:            "elasticstack_elasticsearch_index_template.test",
+				ImportState:             true,
+				ImportStateId:           templateName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_managed"},
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexTemplateAnalysis(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateAnalysis(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_analysis", "template.0.analysis.0.analyzer.0.name", "folding"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_analysis", "template.0.analysis.0.analyzer.0.filter.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_analysis", "template.0.analysis.0.char_filter.0.name", "strip_html"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_analysis", "template.0.analysis.0.char_filter.0.type", "html_strip"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexTemplateMappingOptions(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateMappingOptions(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_mapping_options", "template.0.mapping_dynamic", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_mapping_options", "template.0.mapping_source.0.enabled", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_mapping_options", "template.0.default_pipeline", templateName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_mapping_options", "template.0.mappings", `{"properties":{"message":{"type":"text"}}}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexTemplateIndexSettings(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateIndexSettings(templateName, "30s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.number_of_shards", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.number_of_replicas", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.refresh_interval", "30s"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.codec", ""),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.mapping_total_fields_limit", "2000"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.search_idle_after", "1m"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.max_rescore_window", "0"),
+					resource.TestCheckNoResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.settings"),
+				),
+			},
+			{
+				Config: testAccResourceIndexTemplateIndexSettings(templateName, "1m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_index_settings", "template.0.index_settings.0.refresh_interval", "1m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexTemplateAllowAutoCreate(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateAllowAutoCreate(templateName, fmt.Sprintf("%s-auto-*", templateName), true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_auto_create", "allow_auto_create", "true"),
+				),
+			},
+			{
+				Config: testAccResourceIndexTemplateAllowAutoCreate(templateName, fmt.Sprintf("%s-auto-*", templateName), false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_auto_create", "allow_auto_create", "false"),
+				),
+			},
+			{
+				Config:      testAccResourceIndexTemplateAllowAutoCreate(templateName, fmt.Sprintf("logs-%s-*", templateName), true),
+				ExpectError: regexp.MustCompile("add the `data_stream` block to the template"),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexTemplateIgnoreMissingComponentTemplates(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(index.IgnoreMissingComponentTemplatesMinSupportedVersion),
+				Config:   testAccResourceIndexTemplateIgnoreMissing(templateName, fmt.Sprintf("%s-missing", templateName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_ignore_missing", "composed_of.0", fmt.Sprintf("%s-missing", templateName)),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_ignore_missing", "ignore_missing_component_templates.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_ignore_missing", "ignore_missing_component_templates.0", fmt.Sprintf("%s-missing", templateName)),
+				),
+			},
+			{
+				Config:      testAccResourceIndexTemplateIgnoreMissing(templateName, "not-composed"),
+				ExpectError: regexp.MustCompile("listed in `ignore_missing_component_templates` but not in `composed_of`"),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexTemplateComposedOfOrder(t *testing.T) {
+	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	first, second := fmt.Sprintf("%s-first", templateName), fmt.Sprintf("%s-second", templateName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexTemplateComposedOf(templateName, first, second),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_composed_of", "composed_of.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_composed_of", "composed_of.0", first),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_composed_of", "composed_of.1", second),
+					checkIndexTemplateComposedOf(templateName, first, second),
+				),
+			},
+			{
+				// swapping the component templates changes their precedence, it must not be seen as the same set
+				Config:             testAccResourceIndexTemplateComposedOf(templateName, second, first),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccResourceIndexTemplateComposedOf(templateName, second, first),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_composed_of", "composed_of.0", second),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test_composed_of", "composed_of.1", first),
+					checkIndexTemplateComposedOf(templateName, second, first),
+				),
+			},
 		},
 	})
 }
@@ -115,6 +291,84 @@ resource "elasticstack_elasticsearch_index_template" "test2" {
 	`, name, name, name)
 }
 
+func testAccResourceIndexTemplateIgnoreMissing(name, ignored string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_ignore_missing" {
+  name = "%s"
+
+  index_patterns = ["%s-missing-*"]
+  composed_of    = ["%s-missing"]
+
+  ignore_missing_component_templates = ["%s"]
+}
+	`, name, name, name, ignored)
+}
+
+func testAccResourceIndexTemplateComposedOf(name, composedOf1, composedOf2 string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_component_template" "first" {
+  name = "%s-first"
+
+  template {
+    settings = jsonencode({
+      "index.refresh_interval" = "10s"
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_component_template" "second" {
+  name = "%s-second"
+
+  template {
+    settings = jsonencode({
+      "index.refresh_interval" = "20s"
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_composed_of" {
+  name = "%s"
+
+  index_patterns = ["%s-logs-*"]
+  composed_of    = ["%s", "%s"]
+
+  depends_on = [
+    elasticstack_elasticsearch_component_template.first,
+    elasticstack_elasticsearch_component_template.second,
+  ]
+}
+	`, name, name, name, name, composedOf1, composedOf2)
+}
+
+// checkIndexTemplateComposedOf checks the order of the component templates stored in the cluster.
+func checkIndexTemplateComposedOf(name string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		tpl, diags := elasticsearch.GetIndexTemplate(context.Background(), client, name)
+		if diags.HasError() {
+			return fmt.Errorf("unable to get the index template: %v", diags)
+		}
+		if tpl == nil {
+			return fmt.Errorf("index template (%s) not found", name)
+		}
+		if !reflect.DeepEqual(tpl.IndexTemplate.ComposedOf, expected) {
+			return fmt.Errorf("expected the index template to be composed of %v, got %v", expected, tpl.IndexTemplate.ComposedOf)
+		}
+		return nil
+	}
+}
+
 func checkResourceIndexTemplateDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -139,3 +393,117 @@ func checkResourceIndexTemplateDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func testAccResourceIndexTemplateAnalysis(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_analysis" {
+  name = "%s"
+
+  index_patterns = ["%s-analysis-*"]
+
+  template {
+    settings = jsonencode({
+      number_of_shards = "1"
+    })
+
+    analysis {
+      analyzer {
+        name        = "folding"
+        tokenizer   = "standard"
+        filter      = ["lowercase", "asciifolding"]
+        char_filter = ["strip_html"]
+      }
+
+      char_filter {
+        name = "strip_html"
+        type = "html_strip"
+      }
+    }
+  }
+}
+	`, name, name)
+}
+
+func testAccResourceIndexTemplateIndexSettings(name, refreshInterval string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_index_settings" {
+  name = "%[1]s"
+
+  index_patterns = ["%[1]s-settings-*"]
+
+  template {
+    index_settings {
+      number_of_shards           = 2
+      number_of_replicas         = 0
+      refresh_interval           = "%[2]s"
+      mapping_total_fields_limit = 2000
+      search_idle_after          = "1m"
+    }
+  }
+}
+	`, name, refreshInterval)
+}
+
+func testAccResourceIndexTemplateMappingOptions(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "ingested"
+        value = true
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_mapping_options" {
+  name = "%[1]s"
+
+  index_patterns = ["%[1]s-mapping-*"]
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        message = { type = "text" }
+      }
+    })
+    mapping_dynamic  = "false"
+    default_pipeline = elasticstack_elasticsearch_ingest_pipeline.test.name
+
+    mapping_source {
+      enabled = false
+    }
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexTemplateAllowAutoCreate(name, pattern string, allow bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_template" "test_auto_create" {
+  name = "%s"
+
+  index_patterns    = ["%s"]
+  allow_auto_create = %t
+}
+	`, name, pattern, allow)
+}
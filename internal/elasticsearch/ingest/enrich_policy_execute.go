@@ -0,0 +1,119 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceEnrichPolicyExecute() *schema.Resource {
+	enrichPolicyExecuteSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the existing enrich policy to execute.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"trigger": {
+			Description: "Arbitrary map of values that, when changed, execute the policy again, e.g. the version of the data in the source indices.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"status": {
+			Description: "The phase of the policy execution, `COMPLETE` once the enrich index is created. Only set when the execution is awaited.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(enrichPolicyExecuteSchema)
+	utils.AddTaskSchema(enrichPolicyExecuteSchema)
+
+	return &schema.Resource{
+		Description: "Executes an existing enrich policy, creating the enrich index from the current data of the source indices, and waits for the execution to complete unless `wait_for_completion` is `false`. The policy itself is not managed. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/execute-enrich-policy-api.html",
+
+		CreateContext: resourceEnrichPolicyExecuteCreate,
+		// only the connection can be updated, the policy is executed again only when the trigger changes
+		UpdateContext: resourceEnrichPolicyExecuteRead,
+		ReadContext:   resourceEnrichPolicyExecuteRead,
+		DeleteContext: resourceEnrichPolicyExecuteDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: enrichPolicyExecuteSchema,
+	}
+}
+
+func resourceEnrichPolicyExecuteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+
+	taskId, diags := elasticsearch.ExecuteEnrichPolicy(ctx, client, name)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("task_id", taskId); err != nil {
+		return diag.FromErr(err)
+	}
+	if !d.Get("wait_for_completion").(bool) {
+		d.SetId(id.String())
+		return resourceEnrichPolicyExecuteRead(ctx, d, meta)
+	}
+
+	task, diags := elasticsearch.WaitForTask(ctx, client, taskId)
+	if diags.HasError() {
+		return diags
+	}
+	if task.Error != nil {
+		return diag.Errorf(`The execution of the enrich policy "%s" failed: %v: %v`, name, task.Error["type"], task.Error["reason"])
+	}
+
+	status := "COMPLETE"
+	if phase, ok := task.Task.Status["phase"].(string); ok {
+		status = phase
+	}
+	if err := d.Set("status", status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return resourceEnrichPolicyExecuteRead(ctx, d, meta)
+}
+
+func resourceEnrichPolicyExecuteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the execution can't be read back, the enrich index is replaced by the next execution of the policy
+	if _, diags := clients.CompositeIdFromStr(d.Id()); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+func resourceEnrichPolicyExecuteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, fmt.Sprintf(`Removing the execution of the enrich policy "%s" from the state`, d.Get("name").(string)))
+	return nil
+}
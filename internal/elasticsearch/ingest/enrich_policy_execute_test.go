@@ -0,0 +1,145 @@
+package ingest_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEnrichPolicyExecute(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var taskId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { putTestEnrichPolicy(t, name) },
+				Config:    testAccResourceEnrichPolicyExecute(name, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_enrich_policy_execute.test", "name", name),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_enrich_policy_execute.test", "status", "COMPLETE"),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_enrich_policy_execute.test", "task_id", func(value string) error {
+						taskId = value
+						return nil
+					}),
+				),
+			},
+			{
+				// changing the trigger executes the policy again
+				Config: testAccResourceEnrichPolicyExecute(name, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_enrich_policy_execute.test", "status", "COMPLETE"),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_enrich_policy_execute.test", "task_id", func(value string) error {
+						if value == taskId {
+							return fmt.Errorf("expected the policy to be executed again")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// the execution is left running in its task
+				Config: testAccResourceEnrichPolicyExecuteInTask(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_enrich_policy_execute.test", "wait_for_completion", "false"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_enrich_policy_execute.test", "status", ""),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_enrich_policy_execute.test", "task_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceEnrichPolicyExecute(name, version string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_enrich_policy_execute" "test" {
+  name = "%s"
+
+  trigger = {
+    version = "%s"
+  }
+}
+	`, name, version)
+}
+
+func testAccResourceEnrichPolicyExecuteInTask(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_enrich_policy_execute" "test" {
+  name                = "%s"
+  wait_for_completion = false
+
+  trigger = {
+    version = "2"
+  }
+}
+	`, name)
+}
+
+func putTestEnrichPolicy(t *testing.T, name string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetESClient().Indices.Create(name, client.GetESClient().Indices.Create.WithBody(strings.NewReader(`{
+  "mappings": { "properties": { "email": { "type": "keyword" }, "name": { "type": "keyword" } } }
+}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to create the source index: %s", res.String())
+	}
+	t.Cleanup(func() { deleteTestEnrichPolicy(t, name) })
+
+	policy := fmt.Sprintf(`{ "match": { "indices": "%s", "match_field": "email", "enrich_fields": ["name"] } }`, name)
+	res, err = client.GetESClient().EnrichPutPolicy(name, strings.NewReader(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to create the enrich policy: %s", res.String())
+	}
+}
+
+// deleteTestEnrichPolicy removes the enrich policy created by putTestEnrichPolicy, then its source index
+func deleteTestEnrichPolicy(t *testing.T, name string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetESClient().EnrichDeletePolicy(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		t.Errorf("unable to delete the enrich policy: %s", res.String())
+	}
+
+	res, err = client.GetESClient().Indices.Delete([]string{name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		t.Errorf("unable to delete the source index: %s", res.String())
+	}
+}
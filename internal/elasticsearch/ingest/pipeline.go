@@ -46,7 +46,7 @@ func ResourceIngestPipeline() *schema.Resource {
 			},
 		},
 		"processors": {
-			Description: "Processors used to perform transformations on documents before indexing. Processors run sequentially in the order specified. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/processors.html. Each record must be a valid JSON document.",
+			Description: "Processors used to perform transformations on documents before indexing. Processors run sequentially in the order specified. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/processors.html. Each record must be a valid JSON document, use the `elasticstack_elasticsearch_ingest_processor_*` data sources to define the processors in a typed way.",
 			Type:        schema.TypeList,
 			Required:    true,
 			MinItems:    1,
@@ -57,15 +57,22 @@ func ResourceIngestPipeline() *schema.Resource {
 			},
 		},
 		"metadata": {
-			Description:      "Optional user metadata about the index template.",
+			Description:      "Optional user metadata about the ingest pipeline. The metadata marking the ingest pipeline as managed by another system (`managed` is `true`, e.g. set by Fleet) is kept when not configured.",
 			Type:             schema.TypeString,
 			Optional:         true,
 			ValidateFunc:     validation.StringIsJSON,
-			DiffSuppressFunc: utils.DiffJsonSuppress,
+			DiffSuppressFunc: utils.DiffManagedMetadataSuppress,
+		},
+		"version": {
+			Description:      "Version number used to manage ingest pipelines externally. The version of the ingest pipeline marked as managed by another system is kept when not configured.",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffManagedVersionSuppress,
 		},
 	}
 
 	utils.AddConnectionSchema(pipelineSchema)
+	utils.AddIgnoreManagedSchema(pipelineSchema)
 
 	return &schema.Resource{
 		Description: "Manages tasks and resources related to ingest pipelines and processors. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-apis.html",
@@ -76,23 +83,51 @@ func ResourceIngestPipeline() *schema.Resource {
 		DeleteContext: resourceIngestPipelineTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStatePrefixedPassthroughContext,
 		},
 
+		CustomizeDiff: recordPlannedPipeline,
+
 		Schema: pipelineSchema,
 	}
 }
 
+// recordPlannedPipeline records the planned pipeline in the provider, so that the templates referencing it in the same
+// plan are not reported as referencing a missing pipeline.
+func recordPlannedPipeline(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*clients.ApiClient)
+	if !ok || client == nil || !d.NewValueKnown("name") {
+		return nil
+	}
+	client.AddPlannedPipeline(client.ResourceName(d.Get("name").(string)))
+	return nil
+}
+
 func resourceIngestPipelineTemplatePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
 		return diags
 	}
-	pipelineId := d.Get("name").(string)
+	pipelineId, diags := client.TargetName(d)
+	if diags.HasError() {
+		return diags
+	}
 	id, diags := client.ID(ctx, pipelineId)
 	if diags.HasError() {
 		return diags
 	}
+	if d.Get("ignore_managed").(bool) {
+		existing, diags := elasticsearch.GetIngestPipeline(ctx, client, &pipelineId)
+		if diags.HasError() {
+			return diags
+		}
+		if existing != nil {
+			if diags := utils.CheckManaged(d, "ingest pipeline", pipelineId, existing.Metadata); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
 	var pipeline models.IngestPipeline
 	pipeline.Name = pipelineId
 	if v, ok := d.GetOk("description"); ok {
@@ -129,6 +164,11 @@ func resourceIngestPipelineTemplatePut(ctx context.Context, d *schema.ResourceDa
 		pipeline.Metadata = metadata
 	}
 
+	if v, ok := d.GetOk("version"); ok {
+		definedVer := v.(int)
+		pipeline.Version = &definedVer
+	}
+
 	if diags := elasticsearch.PutIngestPipeline(ctx, client, &pipeline); diags.HasError() {
 		return diags
 	}
@@ -157,7 +197,11 @@ func resourceIngestPipelineTemplateRead(ctx context.Context, d *schema.ResourceD
 	if diags.HasError() {
 		return diags
 	}
-	if err := d.Set("name", pipeline.Name); err != nil {
+	// the object marked as managed since it was created is not refreshed, so that it can still be destroyed
+	if diags := utils.WarnManaged(d, "ingest pipeline", pipeline.Name, pipeline.Metadata); len(diags) > 0 {
+		return diags
+	}
+	if err := d.Set("name", client.ConfiguredName(pipeline.Name)); err != nil {
 		return diag.FromErr(err)
 	}
 	if desc := pipeline.Description; desc != nil {
@@ -165,19 +209,17 @@ func resourceIngestPipelineTemplateRead(ctx context.Context, d *schema.ResourceD
 			return diag.FromErr(err)
 		}
 	}
-	if onFailure := pipeline.OnFailure; onFailure != nil {
-		fProcs := make([]string, len(onFailure))
-		for i, v := range onFailure {
-			res, err := json.Marshal(v)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			fProcs[i] = string(res)
-		}
-
-		if err := d.Set("on_failure", fProcs); err != nil {
+	// the handlers must be reset when they are removed from the pipeline
+	fProcs := make([]string, len(pipeline.OnFailure))
+	for i, v := range pipeline.OnFailure {
+		res, err := json.Marshal(v)
+		if err != nil {
 			return diag.FromErr(err)
 		}
+		fProcs[i] = string(res)
+	}
+	if err := d.Set("on_failure", fProcs); err != nil {
+		return diag.FromErr(err)
 	}
 	procs := make([]string, len(pipeline.Processors))
 	for i, v := range pipeline.Processors {
@@ -192,6 +234,10 @@ func resourceIngestPipelineTemplateRead(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("version", pipeline.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if meta := pipeline.Metadata; meta != nil {
 		meta, err := json.Marshal(meta)
 		if err != nil {
@@ -1,7 +1,10 @@
 package ingest_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -39,6 +42,207 @@ func TestAccResourceIngestPipeline(t *testing.T) {
 	})
 }
 
+func TestAccResourceIngestPipelineMetadata(t *testing.T) {
+	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIngestPipelineDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIngestPipelineMetadata(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "version", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "metadata", `{"owner":"terraform"}`),
+				),
+			},
+			{
+				// not configured anymore, the metadata and the version are removed from the pipeline
+				Config: testAccResourceIngestPipelineUpdate(pipelineName),
+				Check:  checkIngestPipelineWithoutMetadata(pipelineName),
+			},
+			{
+				// the metadata and the version set on the pipeline marked as managed are kept
+				PreConfig: func() { markPipelineManaged(t, pipelineName) },
+				Config:    testAccResourceIngestPipelineUpdate(pipelineName),
+				PlanOnly:  true,
+			},
+		},
+	})
+}
+
+func TestAccResourceIngestPipelineTypedProcessors(t *testing.T) {
+	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIngestPipelineDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIngestPipelineTypedProcessors(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.#", "5"),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.0", regexp.MustCompile(`^{"rename":`)),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.1", regexp.MustCompile(`^{"date":`)),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.2", regexp.MustCompile(`^{"geoip":`)),
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.3", regexp.MustCompile(`^{"user_agent":`)),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.4", `{"lowercase":{"field":"event.action"}}`),
+				),
+			},
+			{
+				// the processors read back from the server are equivalent to the configured ones
+				Config:   testAccResourceIngestPipelineTypedProcessors(pipelineName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceIngestPipelineOnFailure(t *testing.T) {
+	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIngestPipelineDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIngestPipelineOnFailure(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "on_failure.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "on_failure.0", `{"set":{"field":"error.message","value":"{{ _ingest.on_failure_message }}"}}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "on_failure.1", `{"set":{"field":"_index","value":"failed-{{ _index }}"}}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.0", `{"rename":{"field":"provider","on_failure":[{"set":{"field":"error.rename","value":"first"}},{"set":{"field":"error.rename","on_failure":[{"remove":{"field":"error"}}],"override":false,"value":"second"}}],"target_field":"cloud.provider"}}`),
+				),
+			},
+			{
+				Config: testAccResourceIngestPipelineUpdate(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "on_failure.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIngestPipelineManaged(t *testing.T) {
+	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIngestPipelineDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { putManagedPipeline(t, pipelineName) },
+				Config:      testAccResourceIngestPipelineIgnoreManaged(pipelineName),
+				ExpectError: regexp.MustCompile("The ingest pipeline is managed by another system"),
+			},
+		},
+	})
+}
+
+func TestAccResourceIngestPipelineManagedAfterCreation(t *testing.T) {
+	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIngestPipelineDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIngestPipelineIgnoreManaged(pipelineName),
+				Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "name", pipelineName),
+			},
+			{
+				// the pipeline marked as managed since is not refreshed, the plan stays empty
+				PreConfig: func() { putManagedPipeline(t, pipelineName) },
+				Config:    testAccResourceIngestPipelineIgnoreManaged(pipelineName),
+				PlanOnly:  true,
+			},
+			{
+				// its changes are refused, while it's still destroyed at the end of the test
+				Config:      testAccResourceIngestPipelineIgnoreManagedUpdate(pipelineName),
+				ExpectError: regexp.MustCompile("The ingest pipeline is managed by another system"),
+			},
+		},
+	})
+}
+
+func putManagedPipeline(t *testing.T, name string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := client.GetESClient()
+	res, err := es.Ingest.PutPipeline(name, strings.NewReader(`{"processors": [{"set": {"field": "managed", "value": true}}], "_meta": {"managed": true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to create the managed pipeline: %s", res.String())
+	}
+	t.Cleanup(func() {
+		res, err := es.Ingest.DeletePipeline(name)
+		if err == nil {
+			res.Body.Close()
+		}
+	})
+}
+
+// markPipelineManaged updates the pipeline of testAccResourceIngestPipelineUpdate as done by Fleet, setting the
+// metadata marking it as managed and a version.
+func markPipelineManaged(t *testing.T, name string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `{
+  "description": "Test Pipeline",
+  "processors": [{"set": {"description": "My set processor description", "field": "_meta", "value": "indexed"}}],
+  "version": 3,
+  "_meta": {"managed": true, "managed_by": "fleet"}
+}`
+	res, err := client.GetESClient().Ingest.PutPipeline(name, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to mark the pipeline as managed: %s", res.String())
+	}
+}
+
+func checkIngestPipelineWithoutMetadata(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		res, err := client.GetESClient().Ingest.GetPipeline(client.GetESClient().Ingest.GetPipeline.WithPipelineID(name))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.IsError() {
+			return fmt.Errorf("unable to get the ingest pipeline: %s", res.String())
+		}
+		pipelines := make(map[string]map[string]interface{})
+		if err := json.NewDecoder(res.Body).Decode(&pipelines); err != nil {
+			return err
+		}
+		for _, key := range []string{"_meta", "version"} {
+			if v, ok := pipelines[name][key]; ok {
+				return fmt.Errorf("expected no %s for the ingest pipeline %s, got %v", key, name, v)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccResourceIngestPipelineCreate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -92,6 +296,182 @@ resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
 	`, name)
 }
 
+func testAccResourceIngestPipelineMetadata(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name        = "%s"
+  description = "Test Pipeline"
+  version     = 2
+
+  metadata = jsonencode({
+    owner = "terraform"
+  })
+
+  processors = [
+    jsonencode({
+      set = {
+        description = "My set processor description"
+        field       = "_meta"
+        value       = "indexed"
+      }
+    })
+  ]
+}
+	`, name)
+}
+
+func testAccResourceIngestPipelineTypedProcessors(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_ingest_processor_rename" "rename" {
+  field        = "client_ip"
+  target_field = "source.ip"
+}
+
+data "elasticstack_elasticsearch_ingest_processor_date" "date" {
+  field        = "timestamp"
+  target_field = "@timestamp"
+  formats      = ["ISO8601"]
+}
+
+data "elasticstack_elasticsearch_ingest_processor_geoip" "geoip" {
+  field        = "source.ip"
+  target_field = "source.geo"
+}
+
+data "elasticstack_elasticsearch_ingest_processor_user_agent" "user_agent" {
+  field = "user_agent.original"
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name = "%s"
+
+  processors = [
+    data.elasticstack_elasticsearch_ingest_processor_rename.rename.json,
+    data.elasticstack_elasticsearch_ingest_processor_date.date.json,
+    data.elasticstack_elasticsearch_ingest_processor_geoip.geoip.json,
+    data.elasticstack_elasticsearch_ingest_processor_user_agent.user_agent.json,
+    // the processors without a data source are provided as JSON
+    jsonencode({
+      lowercase = {
+        field = "event.action"
+      }
+    }),
+  ]
+}
+	`, name)
+}
+
+func testAccResourceIngestPipelineOnFailure(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name        = "%s"
+  description = "Test Pipeline"
+
+  processors = [
+    jsonencode({
+      rename = {
+        field        = "provider"
+        target_field = "cloud.provider"
+        on_failure = [
+          {
+            set = {
+              field = "error.rename"
+              value = "first"
+            }
+          },
+          {
+            set = {
+              field    = "error.rename"
+              value    = "second"
+              override = false
+              on_failure = [
+                {
+                  remove = {
+                    field = "error"
+                  }
+                }
+              ]
+            }
+          }
+        ]
+      }
+    })
+  ]
+
+  on_failure = [
+    jsonencode({
+      set = {
+        field = "error.message"
+        value = "{{ _ingest.on_failure_message }}"
+      }
+    }),
+    jsonencode({
+      set = {
+        field = "_index"
+        value = "failed-{{ _index }}"
+      }
+    })
+  ]
+}
+	`, name)
+}
+
+func testAccResourceIngestPipelineIgnoreManaged(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name           = "%s"
+  ignore_managed = true
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "_meta"
+        value = "indexed"
+      }
+    })
+  ]
+}
+	`, name)
+}
+
+func testAccResourceIngestPipelineIgnoreManagedUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test_pipeline" {
+  name           = "%s"
+  ignore_managed = true
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "_meta"
+        value = "updated"
+      }
+    })
+  ]
+}
+	`, name)
+}
+
 func checkResourceIngestPipelineDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -53,6 +53,11 @@ func TestResourceLogstashPipeline(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_logstash_pipeline.test", "queue_type", "memory"),
 				),
 			},
+			{
+				ResourceName:      "elasticstack_elasticsearch_logstash_pipeline.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,230 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maxSearchSize is the default `index.max_result_window` of Elasticsearch
+const maxSearchSize = 10000
+
+func DataSourceSearch() *schema.Resource {
+	searchSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Name of the indices, data streams or aliases to search. Supports wildcards and comma-separated lists.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"query": {
+			Description:      "JSON object with the query DSL of the search, all the documents match by default.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"size": {
+			Description:  "The maximum number of the hits to return, the cost of the search and the size of the state grow with it.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntBetween(0, maxSearchSize),
+		},
+		"track_total_hits": {
+			Description:  "Whether the total number of the matching documents is counted: `true` counts all of them, `false` disables the count, a number counts them accurately up to that number. Defaults to the Elasticsearch default (`10000`).",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateTrackTotalHits,
+		},
+		"source_includes": {
+			Description: "The fields of the `_source` to return, supports wildcards. All the fields are returned by default.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"source_excludes": {
+			Description: "The fields to leave out of the returned `_source`, supports wildcards.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"total": {
+			Description: "The number of the matching documents, independent from `size`. It's a lower bound when `total_relation` is `gte`, and 0 when `track_total_hits` is `false`.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"total_relation": {
+			Description: "`eq` if `total` is accurate, `gte` if it's a lower bound.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"hits": {
+			Description: "The returned hits, up to `size`.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Description: "Name of the index containing the document.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"id": {
+						Description: "ID of the document.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"score": {
+						Description: "Relevance score of the document.",
+						Type:        schema.TypeFloat,
+						Computed:    true,
+					},
+					"source": {
+						Description: "JSON object with the filtered `_source` of the document.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"truncated": {
+			Description: "Whether more documents match than the returned hits.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(searchSchema)
+
+	return &schema.Resource{
+		Description: "Searches the documents of the indices and returns a bounded sample of the hits. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/search-search.html",
+
+		ReadContext: dataSourceSearchRead,
+
+		Schema: searchSchema,
+	}
+}
+
+func validateTrackTotalHits(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "true" || v == "false" {
+		return nil, nil
+	}
+	if n, err := strconv.Atoi(v); err != nil || n < 0 {
+		return nil, []error{fmt.Errorf(`expected %s to be "true", "false" or a positive number, got %s`, k, v)}
+	}
+	return nil, nil
+}
+
+func dataSourceSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	index := d.Get("index").(string)
+
+	search := models.SearchRequest{
+		Size: d.Get("size").(int),
+	}
+	if v, ok := d.GetOk("query"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &search.Query); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	switch v := d.Get("track_total_hits").(string); v {
+	case "":
+	case "true", "false":
+		search.TrackTotalHits = v == "true"
+	default:
+		// the value is validated by the schema
+		search.TrackTotalHits, _ = strconv.Atoi(v)
+	}
+	var includes, excludes []string
+	for _, f := range d.Get("source_includes").([]interface{}) {
+		includes = append(includes, f.(string))
+	}
+	for _, f := range d.Get("source_excludes").([]interface{}) {
+		excludes = append(excludes, f.(string))
+	}
+	if len(includes) > 0 || len(excludes) > 0 {
+		search.Source = &models.SearchSourceFilter{Includes: includes, Excludes: excludes}
+	}
+
+	response, diags := elasticsearch.Search(ctx, client, index, &search)
+	if diags.HasError() {
+		return diags
+	}
+
+	hits := make([]interface{}, len(response.Hits.Hits))
+	for i, h := range response.Hits.Hits {
+		hit := map[string]interface{}{
+			"index": h.Index,
+			"id":    h.Id,
+		}
+		if h.Score != nil {
+			hit["score"] = *h.Score
+		}
+		if h.Source != nil {
+			source, err := json.Marshal(h.Source)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			hit["source"] = string(source)
+		}
+		hits[i] = hit
+	}
+	if err := d.Set("hits", hits); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var total int
+	var relation string
+	if response.Hits.Total != nil {
+		total, relation = response.Hits.Total.Value, response.Hits.Total.Relation
+	}
+	if err := d.Set("total", total); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("total_relation", relation); err != nil {
+		return diag.FromErr(err)
+	}
+	truncated := total > len(hits)
+	if err := d.Set("truncated", truncated); err != nil {
+		return diag.FromErr(err)
+	}
+	if truncated {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The search results are truncated",
+			Detail:   fmt.Sprintf("%d documents match the search, only the first %d hits are returned. Increase `size` or narrow down the query to get all the hits.", total, len(hits)),
+		})
+	}
+
+	hash, err := utils.StringToHash(fmt.Sprintf("%s/%s/%d", index, d.Get("query").(string), search.Size))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(*hash)
+	return diags
+}
@@ -0,0 +1,86 @@
+package search_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSearch(t *testing.T) {
+	index := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					var body strings.Builder
+					for i := 1; i <= 3; i++ {
+						body.WriteString(fmt.Sprintf("{\"index\":{\"_index\":\"%s\",\"_id\":\"%d\"}}\n{\"rank\":%d,\"name\":\"doc-%d\",\"large\":\"ignored\"}\n", index, i, i, i))
+					}
+					res, err := client.GetESClient().Bulk(strings.NewReader(body.String()), client.GetESClient().Bulk.WithRefresh("true"))
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer res.Body.Close()
+					if res.IsError() {
+						t.Fatalf("unable to index the documents: %s", res.String())
+					}
+					t.Cleanup(func() {
+						res, err := client.GetESClient().Indices.Delete([]string{index})
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						res.Body.Close()
+					})
+				},
+				Config: testAccDataSourceSearch(index),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.test", "total", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.test", "total_relation", "eq"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.test", "hits.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.test", "hits.0.index", index),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.test", "hits.0.source", `{"name":"doc-2"}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.test", "truncated", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.all", "total", "3"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.all", "hits.#", "3"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_search.all", "truncated", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSearch(index string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_search" "test" {
+  index = "%s"
+  query = jsonencode({
+    range = { rank = { gte = 2 } }
+  })
+  size             = 1
+  track_total_hits = "true"
+  source_includes  = ["name"]
+}
+
+data "elasticstack_elasticsearch_search" "all" {
+  index           = "%s"
+  source_excludes = ["large"]
+}
+`, index, index)
+}
@@ -0,0 +1,271 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sqlTextFormatHeaderLines is the number of the header lines on the first page of the results in the text formats.
+var sqlTextFormatHeaderLines = map[string]int{
+	"csv": 1,
+	"tsv": 1,
+	"txt": 2,
+}
+
+func DataSourceSqlQuery() *schema.Resource {
+	sqlSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"query": {
+			Description: "The SQL query to run.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"fetch_size": {
+			Description:  "The maximum number of rows fetched per page of the results, the pages are fetched until all the rows are returned.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"format": {
+			Description:  "The format of the results. With `json`, the results are returned in `columns` and `rows`, otherwise in `text`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "json",
+			ValidateFunc: validation.StringInSlice([]string{"json", "csv", "tsv", "txt"}, false),
+		},
+		"max_rows": {
+			Description:  "The maximum number of rows to return, the remaining rows are discarded to avoid storing large results in the state.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1000,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"columns": {
+			Description: "The columns of the results, with the `json` format.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the column.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": {
+						Description: "Elasticsearch data type of the column, e.g. `keyword` or `long`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"rows": {
+			Description: "The rows of the results, with the `json` format.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"values": {
+						Description: "The values of the row in the order of the columns, converted to strings. The null values are empty strings.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"rows_json": {
+			Description: "The rows of the results as a JSON array of arrays keeping the types of the values, with the `json` format. Use `jsondecode` to get the typed values.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"text": {
+			Description: "The results in the `csv`, `tsv` or `txt` format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"truncated": {
+			Description: "Whether the results have more rows than `max_rows`.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(sqlSchema)
+
+	return &schema.Resource{
+		Description: "Runs a SQL query and returns its results. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/sql-search-api.html",
+
+		ReadContext: dataSourceSqlQueryRead,
+
+		Schema: sqlSchema,
+	}
+}
+
+func dataSourceSqlQueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	query := models.SqlQuery{
+		Query:     d.Get("query").(string),
+		FetchSize: d.Get("fetch_size").(int),
+	}
+	format := d.Get("format").(string)
+	maxRows := d.Get("max_rows").(int)
+
+	var truncated bool
+	var cursor string
+	if format == "json" {
+		truncated, cursor, diags = readSqlJson(ctx, client, d, query, maxRows)
+	} else {
+		truncated, cursor, diags = readSqlText(ctx, client, d, query, format, maxRows)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	// release the resources of the discarded pages
+	if cursor != "" {
+		if diags := elasticsearch.ClearSqlCursor(ctx, client, cursor); diags.HasError() {
+			return diags
+		}
+	}
+	if err := d.Set("truncated", truncated); err != nil {
+		return diag.FromErr(err)
+	}
+	if truncated {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The SQL query results are truncated",
+			Detail:   fmt.Sprintf("The query returned more than %d rows, only the first %d rows are kept. Increase `max_rows` or narrow down the query to get all the rows.", maxRows, maxRows),
+		})
+	}
+
+	hash, err := utils.StringToHash(fmt.Sprintf("%s/%s", format, query.Query))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(*hash)
+	return diags
+}
+
+// readSqlJson fetches the pages of the results up to maxRows rows, and returns the cursor of the remaining results if they are truncated.
+func readSqlJson(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData, query models.SqlQuery, maxRows int) (bool, string, diag.Diagnostics) {
+	response, diags := elasticsearch.QuerySql(ctx, client, &query)
+	if diags.HasError() {
+		return false, "", diags
+	}
+	columns := make([]interface{}, len(response.Columns))
+	for i, c := range response.Columns {
+		columns[i] = map[string]interface{}{
+			"name": c.Name,
+			"type": c.Type,
+		}
+	}
+
+	rows := response.Rows
+	cursor := response.Cursor
+	for cursor != "" && len(rows) < maxRows {
+		page, diags := elasticsearch.QuerySql(ctx, client, &models.SqlQuery{Cursor: cursor})
+		if diags.HasError() {
+			return false, "", diags
+		}
+		rows = append(rows, page.Rows...)
+		cursor = page.Cursor
+	}
+	truncated := len(rows) > maxRows || cursor != ""
+	if len(rows) > maxRows {
+		rows = rows[:maxRows]
+	}
+
+	flattenedRows := make([]interface{}, len(rows))
+	for i, row := range rows {
+		values := make([]string, len(row))
+		for j, v := range row {
+			value, err := sqlValueToString(v)
+			if err != nil {
+				return false, "", diag.FromErr(err)
+			}
+			values[j] = value
+		}
+		flattenedRows[i] = map[string]interface{}{"values": values}
+	}
+	rowsJson, err := json.Marshal(rows)
+	if err != nil {
+		return false, "", diag.FromErr(err)
+	}
+
+	if err := d.Set("columns", columns); err != nil {
+		return false, "", diag.FromErr(err)
+	}
+	if err := d.Set("rows", flattenedRows); err != nil {
+		return false, "", diag.FromErr(err)
+	}
+	if err := d.Set("rows_json", string(rowsJson)); err != nil {
+		return false, "", diag.FromErr(err)
+	}
+	return truncated, cursor, diags
+}
+
+// readSqlText fetches the pages of the results in the text format up to maxRows rows, and returns the cursor of the remaining results if they are truncated.
+func readSqlText(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData, query models.SqlQuery, format string, maxRows int) (bool, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var lines []string
+	header := sqlTextFormatHeaderLines[format]
+	for {
+		page, cursor, diags := elasticsearch.QuerySqlText(ctx, client, &query, format)
+		if diags.HasError() {
+			return false, "", diags
+		}
+		lines = append(lines, strings.Split(strings.TrimRight(page, "\n"), "\n")...)
+		query = models.SqlQuery{Cursor: cursor}
+		if cursor == "" || len(lines)-header >= maxRows {
+			break
+		}
+	}
+	truncated := len(lines)-header > maxRows || query.Cursor != ""
+	if len(lines)-header > maxRows {
+		lines = lines[:header+maxRows]
+	}
+
+	if err := d.Set("text", strings.Join(lines, "\n")+"\n"); err != nil {
+		return false, "", diag.FromErr(err)
+	}
+	return truncated, query.Cursor, diags
+}
+
+func sqlValueToString(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return fmt.Sprintf("%t", value), nil
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
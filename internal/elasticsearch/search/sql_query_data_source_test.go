@@ -0,0 +1,54 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSqlQuery(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSqlQuery,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "columns.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "columns.0.name", "two"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "columns.1.type", "keyword"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "rows.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "rows.0.values.0", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "rows.0.values.1", "a"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "rows_json", `[[2,"a"]]`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.test", "truncated", "false"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.paged", "rows.#", "5"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.paged", "truncated", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_sql_query.csv", "text", "two,letter\n2,a\n"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSqlQuery = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_sql_query" "test" {
+  query = "SELECT 1 + 1 AS two, 'a' AS letter"
+}
+
+data "elasticstack_elasticsearch_sql_query" "paged" {
+  query      = "SHOW FUNCTIONS"
+  fetch_size = 2
+  max_rows   = 5
+}
+
+data "elasticstack_elasticsearch_sql_query" "csv" {
+  query  = "SELECT 1 + 1 AS two, 'a' AS letter"
+  format = "csv"
+}
+`
@@ -16,7 +16,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var APIKeyMinVersion = version.Must(version.NewVersion("8.0.0")) // Enabled in 8.0
+var (
+	APIKeyMinVersion       = version.Must(version.NewVersion("8.0.0")) // Enabled in 8.0
+	APIKeyUpdateMinVersion = version.Must(version.NewVersion("8.4.0"))
+)
 
 func ResourceApiKey() *schema.Resource {
 	apikeySchema := map[string]*schema.Schema{
@@ -36,10 +39,9 @@ func ResourceApiKey() *schema.Resource {
 			),
 		},
 		"role_descriptors": {
-			Description:      "Role descriptors for this API key.",
+			Description:      "Role descriptors for this API key. Updated in place on Elasticsearch v8.4 and above, the API key is replaced on the older versions.",
 			Type:             schema.TypeString,
 			Optional:         true,
-			ForceNew:         true,
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
@@ -55,11 +57,10 @@ func ResourceApiKey() *schema.Resource {
 			Computed:    true,
 		},
 		"metadata": {
-			Description:      "Arbitrary metadata that you want to associate with the API key.",
+			Description:      "Arbitrary metadata that you want to associate with the API key. Updated in place on Elasticsearch v8.4 and above, the API key is replaced on the older versions.",
 			Type:             schema.TypeString,
 			Optional:         true,
 			Computed:         true,
-			ForceNew:         true,
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
@@ -87,10 +88,43 @@ func ResourceApiKey() *schema.Resource {
 		ReadContext:   resourceSecurityApiKeyRead,
 		DeleteContext: resourceSecurityApiKeyDelete,
 
+		CustomizeDiff: forceNewApiKeyUpdateBeforeMinVersion,
+
 		Schema: apikeySchema,
 	}
 }
 
+// forceNewApiKeyUpdateBeforeMinVersion replaces the API key when its `role_descriptors` or `metadata` are changed on a
+// cluster not supporting the API key updates. The version of the cluster of a resource level connection is not known
+// at plan time, in which case the unsupported update fails on apply.
+func forceNewApiKeyUpdateBeforeMinVersion(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChanges("role_descriptors", "metadata") {
+		return nil
+	}
+	if _, ok := d.GetOk("elasticsearch_connection"); ok {
+		return nil
+	}
+	client, ok := meta.(*clients.ApiClient)
+	if !ok || client == nil || client.GetESClient() == nil {
+		return nil
+	}
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return utils.DiagsAsError(diags)
+	}
+	if serverVersion == nil || !serverVersion.LessThan(APIKeyUpdateMinVersion) {
+		return nil
+	}
+	for _, key := range []string{"role_descriptors", "metadata"} {
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -155,14 +189,47 @@ func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceSecurityApiKeyRead(ctx, d, meta)
 }
 
+// resourceSecurityApiKeyUpdate updates the role descriptors and the metadata of the key, the secret of the key can't be read back,
+// so the other changes replace the key.
 func resourceSecurityApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	diags := diag.Diagnostics{diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  `Cannot update API Key`,
-		Detail:   `update not currently supported.`,
-	}}
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
 
-	return diags
+	if d.HasChanges("role_descriptors", "metadata") {
+		serverVersion, diags := client.ServerVersion(ctx)
+		if diags.HasError() {
+			return diags
+		}
+		if serverVersion != nil && serverVersion.LessThan(APIKeyUpdateMinVersion) {
+			return diag.Errorf("updating the 'role_descriptors' or the 'metadata' of an API key is supported only for Elasticsearch v%s and above, recreate the API key instead", APIKeyUpdateMinVersion)
+		}
+
+		apikey := models.ApiKeyUpdate{
+			RolesDescriptors: map[string]models.Role{},
+			Metadata:         map[string]interface{}{},
+		}
+		if v, ok := d.GetOk("role_descriptors"); ok {
+			if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&apikey.RolesDescriptors); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if v, ok := d.GetOk("metadata"); ok {
+			if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&apikey.Metadata); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if diags := elasticsearch.UpdateApiKey(ctx, client, compId.ResourceId, &apikey); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceSecurityApiKeyRead(ctx, d, meta)
 }
 
 func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -198,7 +265,8 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
-	if apikey.RolesDescriptors != nil {
+	// the role descriptors are only returned since 8.5, the key without the descriptors returns them empty
+	if len(apikey.RolesDescriptors) > 0 {
 		rolesDescriptors, err := json.Marshal(apikey.RolesDescriptors)
 		if err != nil {
 			return diag.FromErr(err)
@@ -206,6 +274,10 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 		if err := d.Set("role_descriptors", string(rolesDescriptors)); err != nil {
 			return diag.FromErr(err)
 		}
+	} else if apikey.RolesDescriptors != nil {
+		if err := d.Set("role_descriptors", ""); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if err := d.Set("metadata", string(metadata)); err != nil {
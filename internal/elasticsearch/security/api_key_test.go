@@ -1,8 +1,11 @@
 package security_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -11,9 +14,11 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -64,6 +69,102 @@ func TestAccResourceSecuritApiKey(t *testing.T) {
 	})
 }
 
+func TestAccResourceSecuritApiKeyUpdate(t *testing.T) {
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var keyId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyUpdateMinVersion),
+				Config:   testAccResourceSecuritApiKeyMetadata(apiKeyName, "index-a*", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "metadata", `{"team":"a"}`),
+					func(s *terraform.State) error {
+						keyId = s.RootModule().Resources["elasticstack_elasticsearch_security_api_key.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyUpdateMinVersion),
+				Config:   testAccResourceSecuritApiKeyMetadata(apiKeyName, "index-b*", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "metadata", `{"team":"b"}`),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(id string) error {
+						if id != keyId {
+							return fmt.Errorf("expected the API key %s to be updated in place, got %s", keyId, id)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceApiKeyUpdateBeforeMinVersion(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "cluster-uuid/key-id",
+		Attributes: map[string]string{
+			"id":               "cluster-uuid/key-id",
+			"name":             "test",
+			"role_descriptors": `{"role-a":{"indices":[{"names":["index-a*"],"privileges":["read"]}]}}`,
+			"metadata":         `{"team":"a"}`,
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":             "test",
+		"role_descriptors": `{"role-a":{"indices":[{"names":["index-a*"],"privileges":["read"]}]}}`,
+		"metadata":         `{"team":"b"}`,
+	})
+
+	for _, tc := range []struct {
+		version     string
+		requiresNew bool
+	}{
+		{version: "8.2.0", requiresNew: true},
+		{version: "8.4.0"},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"version": {"number": "%s", "build_flavor": "default"}, "tagline": "You Know, for Search"}`, tc.version)
+			}))
+			defer server.Close()
+
+			diff, err := security.ResourceApiKey().Diff(context.Background(), state, config, newTestClient(t, server.URL))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := diff != nil && diff.RequiresNew(); got != tc.requiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", got, tc.requiresNew)
+			}
+		})
+	}
+}
+
+func newTestClient(t *testing.T, endpoint string) *clients.ApiClient {
+	providerSchemaMap := map[string]*schema.Schema{
+		"elasticsearch":     providerSchema.GetConnectionSchema("elasticsearch", true),
+		"verify_connection": {Type: schema.TypeBool, Optional: true},
+	}
+	d := schema.TestResourceDataRaw(t, providerSchemaMap, map[string]interface{}{
+		"elasticsearch": []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{endpoint},
+		}},
+	})
+	client, diags := clients.NewApiClientFunc("test")(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating client: %v", diags)
+	}
+	return client.(*clients.ApiClient)
+}
+
 func testAccResourceSecuritApiKeyCreate(apiKeyName string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -89,6 +190,31 @@ resource "elasticstack_elasticsearch_security_api_key" "test" {
 	`, apiKeyName)
 }
 
+func testAccResourceSecuritApiKeyMetadata(apiKeyName, indices, team string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  role_descriptors = jsonencode({
+    role-a = {
+      indices = [{
+        names = ["%s"]
+        privileges = ["read"]
+      }]
+    }
+  })
+
+  metadata = jsonencode({
+    team = "%s"
+  })
+}
+	`, apiKeyName, indices, team)
+}
+
 func checkResourceSecurityApiKeyDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -0,0 +1,218 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceApplicationPrivilege() *schema.Resource {
+	privilegeSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"application": {
+			Description: "The name of the application to which the privilege belongs.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the privilege.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"actions": {
+			Description: "A list of the actions granted by the privilege, e.g. `data:read/*` or `action:login`.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"metadata": {
+			Description:      "Optional meta-data. Keys beginning with `_` are reserved for system usage.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+	}
+
+	utils.AddConnectionSchema(privilegeSchema)
+
+	return &schema.Resource{
+		Description: "Adds and updates application privileges, which can be granted to the roles using their `applications` block. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-privileges.html",
+
+		CreateContext: resourceSecurityApplicationPrivilegePut,
+		UpdateContext: resourceSecurityApplicationPrivilegePut,
+		ReadContext:   resourceSecurityApplicationPrivilegeRead,
+		DeleteContext: resourceSecurityApplicationPrivilegeDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: privilegeSchema,
+	}
+}
+
+// the application and the privilege names cannot contain colons
+const applicationPrivilegeIdSeparator = ":"
+
+func applicationPrivilegeFromId(id string) (string, string, diag.Diagnostics) {
+	compId, diags := clients.CompositeIdFromStr(id)
+	if diags.HasError() {
+		return "", "", diags
+	}
+	application, name, ok := strings.Cut(compId.ResourceId, applicationPrivilegeIdSeparator)
+	if !ok {
+		return "", "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Wrong resource ID.",
+			Detail:   "Resource ID must have following format: <cluster_uuid>/<application>:<privilege name>",
+		}}
+	}
+	return application, name, nil
+}
+
+func resourceSecurityApplicationPrivilegePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	application := d.Get("application").(string)
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, application+applicationPrivilegeIdSeparator+name)
+	if diags.HasError() {
+		return diags
+	}
+
+	privilege := models.ApplicationPrivilege{
+		Application: application,
+		Name:        name,
+		Actions:     utils.ExpandStringSet(d.Get("actions").(*schema.Set)),
+	}
+	if v, ok := d.GetOk("metadata"); ok {
+		metadata := make(map[string]interface{})
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+			return diag.FromErr(err)
+		}
+		privilege.Metadata = metadata
+	}
+
+	if diags := elasticsearch.PutApplicationPrivilege(ctx, client, &privilege); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceSecurityApplicationPrivilegeRead(ctx, d, meta)
+}
+
+func resourceSecurityApplicationPrivilegeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	application, name, diags := applicationPrivilegeFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	privilege, diags := elasticsearch.GetApplicationPrivilege(ctx, client, application, name)
+	if privilege == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Application privilege "%s" of "%s" not found, removing from state`, name, application))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("application", privilege.Application); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", privilege.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("actions", privilege.Actions); err != nil {
+		return diag.FromErr(err)
+	}
+	if privilege.Metadata != nil {
+		metadata, err := json.Marshal(privilege.Metadata)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("metadata", string(metadata)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceSecurityApplicationPrivilegeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	application, name, diags := applicationPrivilegeFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	roles, diags := elasticsearch.GetRoles(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := elasticsearch.DeleteApplicationPrivilege(ctx, client, application, name); diags.HasError() {
+		return diags
+	}
+
+	if referencing := rolesReferencingPrivilege(roles, application, name); len(referencing) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The deleted application privilege is still referenced by roles",
+			Detail:   fmt.Sprintf(`The privilege "%s" of the application "%s" is granted by the roles: %s. The roles do not grant the privilege anymore.`, name, application, strings.Join(referencing, ", ")),
+		})
+	}
+	return diags
+}
+
+// rolesReferencingPrivilege returns the names of the roles granting the given privilege, the application names of the roles may be wildcards.
+func rolesReferencingPrivilege(roles map[string]models.Role, application, name string) []string {
+	var referencing []string
+	for roleName, role := range roles {
+	apps:
+		for _, app := range role.Applications {
+			if matched, _ := path.Match(app.Name, application); !matched {
+				continue
+			}
+			for _, p := range app.Privileges {
+				if p == name || p == "*" {
+					referencing = append(referencing, roleName)
+					break apps
+				}
+			}
+		}
+	}
+	sort.Strings(referencing)
+	return referencing
+}
@@ -0,0 +1,123 @@
+package security_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSecurityApplicationPrivilege(t *testing.T) {
+	appName := fmt.Sprintf("app-%s", sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApplicationPrivilegeDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityApplicationPrivilegeCreate(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.read", "application", appName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.read", "name", "read"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_application_privilege.read", "actions.*", "data:read/*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.read", "metadata", `{"description":"Read access"}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_role.test", "applications.#", "1"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "applications.*.privileges.*", "read"),
+				),
+			},
+			{
+				Config: testAccResourceSecurityApplicationPrivilegeUpdate(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.read", "actions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_application_privilege.read", "actions.*", "action:login"),
+				),
+			},
+			{
+				ResourceName:      "elasticstack_elasticsearch_security_application_privilege.read",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityApplicationPrivilegeCreate(appName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_application_privilege" "read" {
+  application = "%s"
+  name        = "read"
+  actions     = ["data:read/*"]
+
+  metadata = jsonencode({
+    description = "Read access"
+  })
+}
+
+resource "elasticstack_elasticsearch_security_role" "test" {
+  name = "%s-reader"
+
+  applications {
+    application = elasticstack_elasticsearch_security_application_privilege.read.application
+    privileges  = [elasticstack_elasticsearch_security_application_privilege.read.name]
+    resources   = ["*"]
+  }
+}
+	`, appName, appName)
+}
+
+func testAccResourceSecurityApplicationPrivilegeUpdate(appName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_application_privilege" "read" {
+  application = "%s"
+  name        = "read"
+  actions     = ["data:read/*", "action:login"]
+
+  metadata = jsonencode({
+    description = "Read access"
+  })
+}
+	`, appName)
+}
+
+func checkResourceSecurityApplicationPrivilegeDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_security_application_privilege" {
+			continue
+		}
+
+		req := client.GetESClient().Security.GetPrivileges
+		res, err := req(req.WithApplication(rs.Primary.Attributes["application"]), req.WithName(rs.Primary.Attributes["name"]))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		privileges := make(map[string]interface{})
+		if err := json.NewDecoder(res.Body).Decode(&privileges); err != nil {
+			return err
+		}
+		if res.StatusCode != 404 && len(privileges) > 0 {
+			return fmt.Errorf("Application privilege (%s) still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
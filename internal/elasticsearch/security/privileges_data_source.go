@@ -0,0 +1,88 @@
+package security
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourcePrivileges() *schema.Resource {
+	privilegesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"cluster": {
+			Description: "The cluster privileges available in the cluster.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"index": {
+			Description: "The index privileges available in the cluster.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"builtin_roles": {
+			Description: "The names of the built-in (reserved) roles of the cluster.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(privilegesSchema)
+
+	return &schema.Resource{
+		Description: "Gets the cluster and index privileges and the built-in roles available in the cluster. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-builtin-privileges.html",
+
+		ReadContext: dataSourceSecurityPrivilegesRead,
+
+		Schema: privilegesSchema,
+	}
+}
+
+func dataSourceSecurityPrivilegesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	clusterId, diags := client.ClusterID(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	privileges, diags := elasticsearch.GetBuiltinPrivileges(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("cluster", privileges.Cluster); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("index", privileges.Index); err != nil {
+		return diag.FromErr(err)
+	}
+
+	roles, diags := elasticsearch.GetBuiltinRoles(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("builtin_roles", roles); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return diags
+}
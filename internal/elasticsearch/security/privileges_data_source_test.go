@@ -0,0 +1,33 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityPrivileges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityPrivileges,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_privileges.test", "cluster.*", "monitor"),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_privileges.test", "index.*", "read"),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_privileges.test", "builtin_roles.*", "superuser"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSecurityPrivileges = `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_privileges" "test" {}
+`
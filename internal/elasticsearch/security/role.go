@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
@@ -130,7 +131,7 @@ func ResourceRole() *schema.Resource {
 						Optional:         true,
 					},
 					"allow_restricted_indices": {
-						Description: "Include matching restricted indices in names parameter. Usage is strongly discouraged as it can grant unrestricted operations on critical data, make the entire system unstable or leak sensitive information.",
+						Description: "Include matching restricted indices in names parameter. Defaults to `false`. Usage is strongly discouraged as it can grant unrestricted operations on critical data, make the entire system unstable or leak sensitive information, a warning is emitted when it is enabled.",
 						Type:        schema.TypeBool,
 						Optional:    true,
 					},
@@ -155,6 +156,8 @@ func ResourceRole() *schema.Resource {
 		},
 	}
 
+	roleSchema["indices"].Set = hashRoleIndices(roleSchema["indices"].Elem.(*schema.Resource))
+
 	utils.AddConnectionSchema(roleSchema)
 
 	return &schema.Resource{
@@ -169,6 +172,8 @@ func ResourceRole() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: validateRoleIndices,
+
 		Schema: roleSchema,
 	}
 }
@@ -185,6 +190,7 @@ func resourceSecurityRolePut(ctx context.Context, d *schema.ResourceData, meta i
 	}
 	var role models.Role
 	role.Name = roleId
+	var warnings diag.Diagnostics
 	if v, ok := d.GetOk("applications"); ok {
 		definedApps := v.(*schema.Set)
 		applications := make([]models.Application, definedApps.Len())
@@ -280,6 +286,13 @@ func resourceSecurityRolePut(ctx context.Context, d *schema.ResourceData, meta i
 
 			allowRestrictedIndices := index["allow_restricted_indices"].(bool)
 			newIndex.AllowRestrictedIndices = &allowRestrictedIndices
+			if allowRestrictedIndices {
+				warnings = append(warnings, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "The role grants access to restricted indices",
+					Detail:   fmt.Sprintf(`The indices privileges of the role "%s" on %s allow access to the restricted indices (e.g. ".security"). It can grant unrestricted operations on critical data, make the entire system unstable or leak sensitive information.`, roleId, strings.Join(names, ", ")),
+				})
+			}
 
 			indices[i] = newIndex
 		}
@@ -308,7 +321,7 @@ func resourceSecurityRolePut(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	d.SetId(id.String())
-	return resourceSecurityRoleRead(ctx, d, meta)
+	return append(resourceSecurityRoleRead(ctx, d, meta), warnings...)
 }
 
 func resourceSecurityRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -403,7 +416,9 @@ func flattenIndicesData(indices *[]models.IndexPerms) []interface{} {
 			oi := make(map[string]interface{})
 			oi["names"] = index.Names
 			oi["privileges"] = index.Privileges
-			oi["query"] = index.Query
+			if index.Query != nil {
+				oi["query"] = normalizeRoleQuery(*index.Query)
+			}
 			oi["allow_restricted_indices"] = index.AllowRestrictedIndices
 
 			if index.FieldSecurity != nil {
@@ -419,6 +434,71 @@ func flattenIndicesData(indices *[]models.IndexPerms) []interface{} {
 	return make([]interface{}, 0)
 }
 
+// normalizeRoleQuery returns the canonical JSON of the document level security query, e.g. of the queries written by hand with the
+// Elasticsearch API, as the indices entries are compared by their hash rather than with the JSON diff suppression.
+// The numbers and the Mustache templates of the query are kept verbatim.
+func normalizeRoleQuery(query string) string {
+	var q interface{}
+	dec := json.NewDecoder(strings.NewReader(query))
+	dec.UseNumber()
+	if err := dec.Decode(&q); err != nil {
+		return query
+	}
+	normalized, err := utils.MarshalJSON(q)
+	if err != nil {
+		return query
+	}
+	return string(normalized)
+}
+
+// hashRoleIndices hashes the indices entries using the canonical query, so the same query written differently, e.g. with
+// the HTML characters escaped by `jsonencode`, doesn't change the entry.
+func hashRoleIndices(indicesResource *schema.Resource) schema.SchemaSetFunc {
+	hash := schema.HashResource(indicesResource)
+	return func(v interface{}) int {
+		index, ok := v.(map[string]interface{})
+		if !ok {
+			return hash(v)
+		}
+		query, _ := index["query"].(string)
+		if query == "" {
+			return hash(v)
+		}
+		normalized := make(map[string]interface{}, len(index))
+		for k, v := range index {
+			normalized[k] = v
+		}
+		normalized["query"] = normalizeRoleQuery(query)
+		return hash(normalized)
+	}
+}
+
+// validateRoleIndices rejects at plan time the indices entries without privileges. The entries are identified by their
+// names, as the set has no index. The entries with the same names are allowed, e.g. with a different document or field
+// level security.
+func validateRoleIndices(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("indices") {
+		return nil
+	}
+	for _, v := range d.Get("indices").(*schema.Set).List() {
+		index, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		names := make([]string, 0)
+		if n, ok := index["names"].(*schema.Set); ok {
+			for _, name := range n.List() {
+				names = append(names, name.(string))
+			}
+		}
+		sort.Strings(names)
+		if privileges, ok := index["privileges"].(*schema.Set); !ok || privileges.Len() == 0 {
+			return fmt.Errorf(`indices[names = %q].privileges: the indices entry must grant at least one privilege`, names)
+		}
+	}
+	return nil
+}
+
 func resourceSecurityRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -160,7 +160,7 @@ func resourceSecurityRoleMappingRead(ctx context.Context, d *schema.ResourceData
 		}
 	}
 	if len(roleMapping.RoleTemplates) > 0 {
-		roleTemplates, err := json.Marshal(roleMapping.RoleTemplates)
+		roleTemplates, err := utils.MarshalJSON(roleMapping.RoleTemplates)
 		if err != nil {
 			diag.FromErr(err)
 		}
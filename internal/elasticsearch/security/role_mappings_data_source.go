@@ -0,0 +1,137 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRoleMappings() *schema.Resource {
+	roleMappingsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"role_mappings": {
+			Description: "All the role mappings of the cluster, sorted by the name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The distinct name that identifies the role mapping.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"enabled": {
+						Description: "Mappings that have `enabled` set to `false` are ignored when role mapping is performed.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"roles": {
+						Description: "A list of role names that are granted to the users that match the role mapping rules.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"role_templates": {
+						Description: "JSON list of mustache templates that will be evaluated to determine the roles names that should granted to the users that match the role mapping rules.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"rules": {
+						Description: "JSON object with the rules that determine which users should be matched by the mapping, with the keys sorted.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"metadata": {
+						Description: "JSON object with the additional metadata of the role mapping.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(roleMappingsSchema)
+
+	return &schema.Resource{
+		Description: "Retrieves all the role mappings of the cluster, e.g. to audit which roles are granted to the users. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-role-mapping.html",
+		ReadContext: dataSourceSecurityRoleMappingsRead,
+		Schema:      roleMappingsSchema,
+	}
+}
+
+func dataSourceSecurityRoleMappingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	clusterId, diags := client.ClusterID(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	roleMappings, diags := elasticsearch.GetRoleMappings(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	names := make([]string, 0, len(roleMappings))
+	for name := range roleMappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mappings := make([]interface{}, len(names))
+	for i, name := range names {
+		m, err := flattenRoleMapping(roleMappings[name])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		mappings[i] = m
+	}
+	if err := d.Set("role_mappings", mappings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*clusterId)
+	return diags
+}
+
+func flattenRoleMapping(roleMapping models.RoleMapping) (map[string]interface{}, error) {
+	// the maps are marshalled with the keys sorted, which gives the canonical JSON
+	rules, err := json.Marshal(roleMapping.Rules)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := json.Marshal(roleMapping.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{
+		"name":     roleMapping.Name,
+		"enabled":  roleMapping.Enabled,
+		"roles":    roleMapping.Roles,
+		"rules":    string(rules),
+		"metadata": string(metadata),
+	}
+	if len(roleMapping.RoleTemplates) > 0 {
+		roleTemplates, err := utils.MarshalJSON(roleMapping.RoleTemplates)
+		if err != nil {
+			return nil, err
+		}
+		m["role_templates"] = string(roleTemplates)
+	}
+	return m, nil
+}
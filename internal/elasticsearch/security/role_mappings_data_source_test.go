@@ -0,0 +1,72 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceSecurityRoleMappings(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityRoleMappings(name),
+				Check:  checkRoleMappingListed("data.elasticstack_elasticsearch_security_role_mappings.all", name),
+			},
+		},
+	})
+}
+
+// checkRoleMappingListed checks that the role mapping is part of the list, other tests may create role mappings at the same time.
+func checkRoleMappingListed(resourceName, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found", resourceName)
+		}
+		attrs := rs.Primary.Attributes
+		for i := 0; attrs[fmt.Sprintf("role_mappings.%d.name", i)] != ""; i++ {
+			prefix := fmt.Sprintf("role_mappings.%d.", i)
+			if attrs[prefix+"name"] != name {
+				continue
+			}
+			if attrs[prefix+"enabled"] != "false" || attrs[prefix+"roles.0"] != "viewer" {
+				return fmt.Errorf("unexpected role mapping attributes: %v", attrs)
+			}
+			if expected := `{"field":{"username":"*"}}`; attrs[prefix+"rules"] != expected {
+				return fmt.Errorf("expected the rules %s, got %s", expected, attrs[prefix+"rules"])
+			}
+			return nil
+		}
+		return fmt.Errorf("role mapping %s not found in %s", name, resourceName)
+	}
+}
+
+func testAccDataSourceSecurityRoleMappings(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role_mapping" "test" {
+  name    = "%s"
+  enabled = false
+  roles   = ["viewer"]
+  rules = jsonencode({
+    field = { username = "*" }
+  })
+}
+
+data "elasticstack_elasticsearch_security_role_mappings" "all" {
+  depends_on = [elasticstack_elasticsearch_security_role_mapping.test]
+}
+	`, name)
+}
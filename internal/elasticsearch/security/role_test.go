@@ -1,11 +1,16 @@
 package security_test
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -49,6 +54,209 @@ func TestAccResourceSecurityRole(t *testing.T) {
 	})
 }
 
+func TestAccResourceSecurityRoleImportDocumentAndFieldLevelSecurity(t *testing.T) {
+	roleName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityRoleDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:          func() { putTestRole(t, roleName) },
+				Config:             testAccResourceSecurityRoleSecurity(roleName),
+				ResourceName:       "elasticstack_elasticsearch_security_role.test",
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						return "", err
+					}
+					clusterId, diags := client.ClusterID(context.Background())
+					if diags.HasError() {
+						return "", fmt.Errorf("failed to get cluster uuid: %s", diags[0].Summary)
+					}
+					return fmt.Sprintf("%s/%s", *clusterId, roleName), nil
+				},
+				ImportStateCheck: func(is []*terraform.InstanceState) error {
+					attrs := is[0].Attributes
+					if attrs["indices.#"] != "2" {
+						return fmt.Errorf("expected 2 indices entries, got %s", attrs["indices.#"])
+					}
+					for i := 0; i < 2; i++ {
+						if attrs[fmt.Sprintf("indices.%d.names.0", i)] != "public" {
+							continue
+						}
+						if query := attrs[fmt.Sprintf("indices.%d.query", i)]; query != `{"term":{"visibility":"public"}}` {
+							return fmt.Errorf("expected the canonical query, got %s", query)
+						}
+						if except := attrs[fmt.Sprintf("indices.%d.field_security.0.except.0", i)]; except != "secret" {
+							return fmt.Errorf("expected the field security exceptions to be imported, got %s", except)
+						}
+					}
+					return nil
+				},
+			},
+			{
+				// the imported role matches the configuration
+				Config:   testAccResourceSecurityRoleSecurity(roleName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSecurityRoleTemplatedQuery(t *testing.T) {
+	roleName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	source := strconv.Quote(`{"bool":{"filter":[{"term":{"owner":"{{_user.username}}"}},{"terms":{"team":{{#toJson}}_user.metadata.teams{{/toJson}}}}]}}`)
+	query := fmt.Sprintf(`{"template":{"source":%s}}`, source)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityRoleDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityRoleTemplatedQuery(roleName, fmt.Sprintf("<<EOT\n%s\nEOT", query)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_security_role.test", "indices.*", map[string]string{
+						"query": query,
+					}),
+				),
+			},
+			{
+				// the same query encoded with jsonencode is the same indices entry
+				Config:   testAccResourceSecurityRoleTemplatedQuery(roleName, fmt.Sprintf("jsonencode({ template = { source = %s } })", source)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityRoleTemplatedQuery(roleName, query string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role" "test" {
+  name = "%s"
+
+  indices {
+    names      = ["documents"]
+    privileges = ["read"]
+    query      = %s
+  }
+}
+	`, roleName, query)
+}
+
+func TestResourceRoleIndicesValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		indices     []interface{}
+		expectError string
+	}{
+		{
+			name: "no privileges",
+			indices: []interface{}{
+				map[string]interface{}{"names": []interface{}{"index2", "index1"}, "privileges": []interface{}{}},
+			},
+			expectError: `indices\[names = \["index1" "index2"\]\]\.privileges: the indices entry must grant at least one privilege`,
+		},
+		{
+			name: "same names with a different document level security",
+			indices: []interface{}{
+				map[string]interface{}{"names": []interface{}{"index1", "index2"}, "privileges": []interface{}{"read"}},
+				map[string]interface{}{"names": []interface{}{"index2", "index1"}, "privileges": []interface{}{"read"}, "query": `{"match":{"team":"a"}}`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":    "test",
+				"indices": tt.indices,
+			})
+			_, err := security.ResourceRole().Diff(context.Background(), nil, config, nil)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !regexp.MustCompile(tt.expectError).MatchString(err.Error()) {
+				t.Fatalf("expected error matching %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func putTestRole(t *testing.T, roleName string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	role := `{
+  "indices": [
+    {
+      "names": ["public"],
+      "privileges": ["read"],
+      "query": "{\n  \"term\": { \"visibility\": \"public\" }\n}",
+      "field_security": { "grant": ["*"], "except": ["secret"] }
+    },
+    {
+      "names": ["users"],
+      "privileges": ["read", "view_index_metadata"],
+      "field_security": { "grant": ["name", "email"] }
+    }
+  ]
+}`
+	res, err := client.GetESClient().Security.PutRole(roleName, strings.NewReader(role))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to create the role: %s", res.String())
+	}
+}
+
+func testAccResourceSecurityRoleSecurity(roleName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role" "test" {
+  name = "%s"
+
+  indices {
+    names      = ["public"]
+    privileges = ["read"]
+    query = jsonencode({
+      term = { visibility = "public" }
+    })
+
+    field_security {
+      grant  = ["*"]
+      except = ["secret"]
+    }
+  }
+
+  indices {
+    names      = ["users"]
+    privileges = ["read", "view_index_metadata"]
+
+    field_security {
+      grant = ["name", "email"]
+    }
+  }
+}
+	`, roleName)
+}
+
 func testAccResourceSecurityRoleCreate(roleName string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
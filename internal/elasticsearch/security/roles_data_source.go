@@ -0,0 +1,140 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRoles() *schema.Resource {
+	rolesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name_prefix": {
+			Description: "Only returns the roles with the name starting with the prefix.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"include_reserved": {
+			Description: "Whether the reserved roles built into Elasticsearch, e.g. `superuser`, are returned.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"roles": {
+			Description: "The matching roles, sorted by the name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: roleListElemSchema(),
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(rolesSchema)
+
+	return &schema.Resource{
+		Description: "Retrieves all the roles of the cluster with their privileges, e.g. to review the access granted by the roles. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-role.html",
+		ReadContext: dataSourceSecurityRolesRead,
+		Schema:      rolesSchema,
+	}
+}
+
+// roleListElemSchema returns the computed attributes of the role data source, describing a single role of the list.
+func roleListElemSchema() map[string]*schema.Schema {
+	elemSchema := map[string]*schema.Schema{
+		"reserved": {
+			Description: "Whether the role is a reserved role built into Elasticsearch.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+	for k, v := range DataSourceRole().Schema {
+		if k == "id" || k == "elasticsearch_connection" {
+			continue
+		}
+		s := *v
+		s.Required, s.Optional, s.Computed = false, false, true
+		elemSchema[k] = &s
+	}
+	return elemSchema
+}
+
+func dataSourceSecurityRolesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	namePrefix := d.Get("name_prefix").(string)
+	id, diags := client.ID(ctx, namePrefix+"*")
+	if diags.HasError() {
+		return diags
+	}
+
+	// the roles API doesn't paginate, all the roles are returned at once
+	roles, diags := elasticsearch.GetRoles(ctx, client)
+	if diags.HasError() {
+		return diags
+	}
+	includeReserved := d.Get("include_reserved").(bool)
+	names := make([]string, 0, len(roles))
+	for name, role := range roles {
+		if !strings.HasPrefix(name, namePrefix) || (role.IsReserved() && !includeReserved) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]interface{}, len(names))
+	for i, name := range names {
+		r, err := flattenRole(name, roles[name])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result[i] = r
+	}
+	if err := d.Set("roles", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+func flattenRole(name string, role models.Role) (map[string]interface{}, error) {
+	r := map[string]interface{}{
+		"name":         name,
+		"reserved":     role.IsReserved(),
+		"applications": flattenApplicationsData(&role.Applications),
+		"cluster":      role.Cluster,
+		"indices":      flattenIndicesData(&role.Indices),
+		"run_as":       role.RusAs,
+	}
+	if role.Global != nil {
+		global, err := json.Marshal(role.Global)
+		if err != nil {
+			return nil, err
+		}
+		r["global"] = string(global)
+	}
+	if role.Metadata != nil {
+		metadata, err := json.Marshal(role.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		r["metadata"] = string(metadata)
+	}
+	return r, nil
+}
@@ -0,0 +1,78 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityRoles(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecurityRoles(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.prefixed", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.prefixed", "roles.0.name", name+"-reader"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.prefixed", "roles.0.reserved", "false"),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_roles.prefixed", "roles.0.indices.*.names.*", "logs-*"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.prefixed", "roles.1.name", name+"-writer"),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_roles.prefixed", "roles.1.cluster.*", "monitor"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.reserved", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.reserved", "roles.0.name", "superuser"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.reserved", "roles.0.reserved", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_roles.custom", "roles.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSecurityRoles(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role" "reader" {
+  name = "%[1]s-reader"
+
+  indices {
+    names      = ["logs-*"]
+    privileges = ["read"]
+  }
+}
+
+resource "elasticstack_elasticsearch_security_role" "writer" {
+  name    = "%[1]s-writer"
+  cluster = ["monitor"]
+
+  indices {
+    names      = ["logs-*"]
+    privileges = ["write"]
+  }
+}
+
+data "elasticstack_elasticsearch_security_roles" "prefixed" {
+  name_prefix = "%[1]s-"
+
+  depends_on = [elasticstack_elasticsearch_security_role.reader, elasticstack_elasticsearch_security_role.writer]
+}
+
+data "elasticstack_elasticsearch_security_roles" "reserved" {
+  name_prefix = "superuser"
+}
+
+data "elasticstack_elasticsearch_security_roles" "custom" {
+  name_prefix      = "superuser"
+  include_reserved = false
+}
+	`, name)
+}
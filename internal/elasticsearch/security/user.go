@@ -50,6 +50,11 @@ func ResourceUser() *schema.Resource {
 			ValidateFunc:  validation.StringLenBetween(6, 128),
 			ConflictsWith: []string{"password"},
 		},
+		"password_version": {
+			Description: "Changing the value sets the configured `password` or `password_hash` again with the change password API, without updating the other attributes of the user, e.g. to rotate a password managed outside of Terraform. Any identifier works, e.g. a rotation counter or a timestamp.",
+			Type:        schema.TypeInt,
+			Optional:    true,
+		},
 		"full_name": {
 			Description: "The full name of the user.",
 			Type:        schema.TypeString,
@@ -80,7 +85,7 @@ func ResourceUser() *schema.Resource {
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
 		"enabled": {
-			Description: "Specifies whether the user is enabled. The default value is true.",
+			Description: "Specifies whether the user is enabled. The default value is true. A disabled user is kept, but cannot authenticate, e.g. when offboarding. When only `enabled` changes the user is enabled or disabled without updating it. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-disable-user.html",
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     true,
@@ -152,8 +157,38 @@ func resourceSecurityUserPut(ctx context.Context, d *schema.ResourceData, meta i
 		user.Metadata = metadata
 	}
 
-	if diags := elasticsearch.PutUser(ctx, client, &user); diags.HasError() {
-		return diags
+	rotatePassword := !d.IsNewResource() && d.HasChange("password_version") && !d.HasChanges("password", "password_hash")
+	if d.IsNewResource() || d.HasChangesExcept("password_version", "enabled") {
+		if diags := elasticsearch.PutUser(ctx, client, &user); diags.HasError() {
+			return diags
+		}
+	} else if d.HasChange("enabled") {
+		// only the enabled state changed, toggle it without resending the user
+		if user.Enabled {
+			diags = elasticsearch.EnableUser(ctx, client, usernameId)
+		} else {
+			diags = elasticsearch.DisableUser(ctx, client, usernameId)
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+	if rotatePassword {
+		var userPassword models.UserPassword
+		if v, ok := d.GetOk("password"); ok {
+			password := v.(string)
+			userPassword.Password = &password
+		}
+		if v, ok := d.GetOk("password_hash"); ok {
+			pass_hash := v.(string)
+			userPassword.PasswordHash = &pass_hash
+		}
+		if userPassword.Password == nil && userPassword.PasswordHash == nil {
+			return diag.Errorf(`"password_version" of the user "%s" changed, but neither "password" nor "password_hash" is configured`, usernameId)
+		}
+		if diags := elasticsearch.ChangeUserPassword(ctx, client, usernameId, &userPassword); diags.HasError() {
+			return diags
+		}
 	}
 
 	d.SetId(id.String())
@@ -153,6 +153,98 @@ func TestAccImportedUserDoesNotResetPassword(t *testing.T) {
 	})
 }
 
+func TestAccResourceSecurityUserPasswordVersion(t *testing.T) {
+	username := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityUserDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityUserPasswordVersion(username, 1),
+				Check:  checkUserCanAuthenticate(username, "qwerty123"),
+			},
+			{
+				// the password is changed outside of Terraform, and set again when bumping the version
+				PreConfig: func() {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						t.Fatalf("Failed to create testing client: %v", err)
+					}
+					res, err := client.GetESClient().Security.ChangePassword(strings.NewReader(`{"password": "changed123"}`), client.GetESClient().Security.ChangePassword.WithUsername(username))
+					if err != nil {
+						t.Fatalf("Failed to change the password of the user: %v", err)
+					}
+					defer res.Body.Close()
+					if res.IsError() {
+						t.Fatalf("Failed to change the password of the user: %s", res.String())
+					}
+				},
+				Config: testAccResourceSecurityUserPasswordVersion(username, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "password_version", "2"),
+					checkUserCanAuthenticate(username, "qwerty123"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSecurityUserEnabled(t *testing.T) {
+	username := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityUserDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityUserEnabled(username, true),
+				Check:  checkUserCanAuthenticate(username, "qwerty123"),
+			},
+			{
+				Config: testAccResourceSecurityUserEnabled(username, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "enabled", "false"),
+					checkUserCannotAuthenticate(username, "qwerty123"),
+				),
+			},
+			{
+				// the user enabled outside of Terraform is disabled again
+				PreConfig: func() {
+					client, err := clients.NewAcceptanceTestingClient()
+					if err != nil {
+						t.Fatalf("Failed to create testing client: %v", err)
+					}
+					res, err := client.GetESClient().Security.EnableUser(username)
+					if err != nil {
+						t.Fatalf("Failed to enable the user: %v", err)
+					}
+					defer res.Body.Close()
+					if res.IsError() {
+						t.Fatalf("Failed to enable the user: %s", res.String())
+					}
+				},
+				Config: testAccResourceSecurityUserEnabled(username, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "enabled", "false"),
+					checkUserCannotAuthenticate(username, "qwerty123"),
+				),
+			},
+		},
+	})
+}
+
+func checkUserCannotAuthenticate(username string, password string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		if err := checkUserCanAuthenticate(username, password)(s); err == nil {
+			return fmt.Errorf("the disabled user [%s] can still authenticate", username)
+		}
+		return nil
+	}
+}
+
 func checkUserCanAuthenticate(username string, password string) func(*terraform.State) error {
 	return func(s *terraform.State) error {
 		client, err := clients.NewAcceptanceTestingClient()
@@ -225,6 +317,36 @@ resource "elasticstack_elasticsearch_security_user" "test" {
 	`, username, role)
 }
 
+func testAccResourceSecurityUserPasswordVersion(username string, version int) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_user" "test" {
+  username         = "%s"
+  roles            = ["kibana_user"]
+  password         = "qwerty123"
+  password_version = %d
+}
+	`, username, version)
+}
+
+func testAccResourceSecurityUserEnabled(username string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_user" "test" {
+  username = "%s"
+  roles    = ["kibana_user"]
+  password = "qwerty123"
+  enabled  = %t
+}
+	`, username, enabled)
+}
+
 func checkResourceSecurityUserDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
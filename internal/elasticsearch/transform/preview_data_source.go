@@ -0,0 +1,161 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var LatestMinSupportedVersion = version.Must(version.NewVersion("7.12.0"))
+
+func DataSourceTransformPreview() *schema.Resource {
+	previewSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"source_indices": {
+			Description: "The source indices of the transform, supports wildcards.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"source_query": {
+			Description:      "JSON object with the query DSL selecting the source documents, all the documents match by default.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"pivot": {
+			Description:      "JSON object with the `group_by` and `aggregations` of a pivot transform.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ExactlyOneOf:     []string{"pivot", "latest"},
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"latest": {
+			Description:      "JSON object with the `unique_key` and `sort` of a latest transform. Supported from Elasticsearch version **7.12**",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ExactlyOneOf:     []string{"pivot", "latest"},
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"preview": {
+			Description: "The JSON documents the transform would write to its destination index, a sample of up to 100 documents.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"generated_dest_mappings": {
+			Description: "JSON object with the mappings the transform would generate for its destination index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"generated_dest_settings": {
+			Description: "JSON object with the settings the transform would generate for its destination index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(previewSchema)
+
+	return &schema.Resource{
+		Description: "Previews the documents and the destination index mappings of a transform without creating it, e.g. to check the pivot aggregations before deploying the transform. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/preview-transform.html",
+
+		ReadContext: dataSourceTransformPreviewRead,
+
+		Schema: previewSchema,
+	}
+}
+
+func dataSourceTransformPreviewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	transform := models.TransformPreviewRequest{}
+	for _, i := range d.Get("source_indices").([]interface{}) {
+		transform.Source.Index = append(transform.Source.Index, i.(string))
+	}
+	if v, ok := d.GetOk("source_query"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &transform.Source.Query); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("pivot"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &transform.Pivot); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("latest"); ok {
+		serverVersion, diags := client.ServerVersion(ctx)
+		if diags.HasError() {
+			return diags
+		}
+		if serverVersion != nil && serverVersion.LessThan(LatestMinSupportedVersion) {
+			return diag.Errorf("'latest' is supported only for Elasticsearch v%s and above", LatestMinSupportedVersion)
+		}
+		if err := json.Unmarshal([]byte(v.(string)), &transform.Latest); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	response, diags := elasticsearch.PreviewTransform(ctx, client, &transform)
+	if diags.HasError() {
+		return diags
+	}
+
+	preview := make([]interface{}, len(response.Preview))
+	for i, doc := range response.Preview {
+		source, err := json.Marshal(doc)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		preview[i] = string(source)
+	}
+	if err := d.Set("preview", preview); err != nil {
+		return diag.FromErr(err)
+	}
+	mappings, err := json.Marshal(response.GeneratedDestIndex.Mappings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("generated_dest_mappings", string(mappings)); err != nil {
+		return diag.FromErr(err)
+	}
+	settings, err := json.Marshal(response.GeneratedDestIndex.Settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("generated_dest_settings", string(settings)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, err := utils.StringToHash(fmt.Sprintf("%s/%s/%s/%s", strings.Join(transform.Source.Index, ","), d.Get("source_query").(string), d.Get("pivot").(string), d.Get("latest").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(*hash)
+	return diags
+}
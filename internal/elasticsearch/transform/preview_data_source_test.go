@@ -0,0 +1,91 @@
+package transform_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTransformPreview(t *testing.T) {
+	index := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { putTestDocuments(t, index) },
+				Config:    testAccDataSourceTransformPreview(index),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_transform_preview.test", "preview.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_transform_preview.test", "preview.0", `{"customer":"a","total":3}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_transform_preview.test", "preview.1", `{"customer":"b","total":5}`),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_transform_preview.test", "generated_dest_mappings"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceTransformPreview(index string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_transform_preview" "test" {
+  source_indices = ["%s"]
+  pivot = jsonencode({
+    group_by = {
+      customer = { terms = { field = "customer" } }
+    }
+    aggregations = {
+      total = { sum = { field = "amount" } }
+    }
+  })
+}
+	`, index)
+}
+
+// putTestDocuments creates the source index of the transform with its documents, the index is deleted at the end of the test
+func putTestDocuments(t *testing.T, index string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mappings := `{"mappings": {"properties": {"customer": {"type": "keyword"}, "amount": {"type": "long"}}}}`
+	res, err := client.GetESClient().Indices.Create(index, client.GetESClient().Indices.Create.WithBody(strings.NewReader(mappings)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to create the index: %s", res.String())
+	}
+	t.Cleanup(func() {
+		res, err := client.GetESClient().Indices.Delete([]string{index})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		res.Body.Close()
+	})
+
+	var body strings.Builder
+	for i, doc := range []string{`{"customer":"a","amount":1}`, `{"customer":"a","amount":2}`, `{"customer":"b","amount":5}`} {
+		body.WriteString(fmt.Sprintf("{\"index\":{\"_index\":\"%s\",\"_id\":\"%d\"}}\n%s\n", index, i+1, doc))
+	}
+	bulkRes, err := client.GetESClient().Bulk(strings.NewReader(body.String()), client.GetESClient().Bulk.WithRefresh("true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bulkRes.Body.Close()
+	if bulkRes.IsError() {
+		t.Fatalf("unable to index the documents: %s", bulkRes.String())
+	}
+}
@@ -0,0 +1,151 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ackableState is the state of the watch action, which was executed and can be acknowledged
+const ackableState = "ackable"
+
+func ResourceWatchAck() *schema.Resource {
+	watchAckSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"watch_id": {
+			Description: "Identifier of the watch to acknowledge.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"action_ids": {
+			Description: "Identifiers of the watch actions to acknowledge. If omitted, all the actions of the watch are acknowledged.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"ack_states": {
+			Description: "Current acknowledgement state of the watch actions, keyed by the action identifier.",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(watchAckSchema)
+
+	return &schema.Resource{
+		Description: "Acknowledges the actions of an Elasticsearch watch, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/watcher-api-ack-watch.html",
+
+		CreateContext: resourceWatchAckPut,
+		UpdateContext: resourceWatchAckPut,
+		ReadContext:   resourceWatchAckRead,
+		DeleteContext: resourceWatchAckDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// the watch resets the acknowledgement once its condition is no longer met,
+		// so acknowledge the actions again when they became ackable since the last apply
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Id() == "" {
+				return nil
+			}
+			for _, state := range d.Get("ack_states").(map[string]interface{}) {
+				if state.(string) == ackableState {
+					return d.SetNewComputed("ack_states")
+				}
+			}
+			return nil
+		},
+
+		Schema: watchAckSchema,
+	}
+}
+
+func resourceWatchAckPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	watchID := d.Get("watch_id").(string)
+	id, diags := client.ID(ctx, watchID)
+	if diags.HasError() {
+		return diags
+	}
+
+	actionIDs := make([]string, 0)
+	if v, ok := d.GetOk("action_ids"); ok {
+		for _, a := range v.(*schema.Set).List() {
+			actionIDs = append(actionIDs, a.(string))
+		}
+	}
+	if _, diags := elasticsearch.AckWatch(ctx, client, watchID, actionIDs); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceWatchAckRead(ctx, d, meta)
+}
+
+func resourceWatchAckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	watchID := compId.ResourceId
+
+	status, diags := elasticsearch.GetWatchStatus(ctx, client, watchID)
+	if status == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Watch "%s" not found, removing from state`, watchID))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("watch_id", watchID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ack_states", flattenAckStates(status, d.Get("action_ids").(*schema.Set))); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceWatchAckDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, fmt.Sprintf(`Acknowledgement of the watch "%s" cannot be reverted, it's only removed from the state`, d.Get("watch_id").(string)))
+	return nil
+}
+
+func flattenAckStates(status *models.WatchStatus, actionIDs *schema.Set) map[string]interface{} {
+	states := make(map[string]interface{})
+	for actionID, action := range status.Actions {
+		if actionIDs.Len() > 0 && !actionIDs.Contains(actionID) {
+			continue
+		}
+		states[actionID] = action.Ack.State
+	}
+	return states
+}
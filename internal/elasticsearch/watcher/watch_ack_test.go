@@ -0,0 +1,104 @@
+package watcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceWatchAck(t *testing.T) {
+	watchID := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc:  isWatcherUnavailable,
+				PreConfig: func() { putTestWatch(t, watchID) },
+				Config:    testAccResourceWatchAck(watchID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watch_ack.test", "watch_id", watchID),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watch_ack.test", "ack_states.%", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_watch_ack.test", "ack_states.log", "awaits_successful_execution"),
+				),
+			},
+		},
+	})
+}
+
+// isWatcherUnavailable skips the tests when the cluster license doesn't include Watcher
+func isWatcherUnavailable() (bool, error) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return false, err
+	}
+	res, err := client.GetESClient().XPack.Info(client.GetESClient().XPack.Info.WithCategories("features"))
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return false, fmt.Errorf("unable to get the X-Pack info: %s", res.String())
+	}
+	var info struct {
+		Features map[string]struct {
+			Available bool `json:"available"`
+			Enabled   bool `json:"enabled"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return false, err
+	}
+	watcher := info.Features["watcher"]
+	return !watcher.Available || !watcher.Enabled, nil
+}
+
+func putTestWatch(t *testing.T, watchID string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	watch := `{
+  "trigger": { "schedule": { "interval": "1h" } },
+  "input": { "simple": {} },
+  "condition": { "always": {} },
+  "actions": { "log": { "logging": { "text": "test" } } }
+}`
+	res, err := client.GetESClient().Watcher.PutWatch(watchID, client.GetESClient().Watcher.PutWatch.WithBody(strings.NewReader(watch)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("unable to create the watch: %s", res.String())
+	}
+
+	t.Cleanup(func() {
+		res, err := client.GetESClient().Watcher.DeleteWatch(watchID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		res.Body.Close()
+	})
+}
+
+func testAccResourceWatchAck(watchID string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_watch_ack" "test" {
+  watch_id   = "%s"
+  action_ids = ["log"]
+}
+	`, watchID)
+}
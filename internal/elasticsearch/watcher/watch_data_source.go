@@ -0,0 +1,210 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var actionModes = []string{"simulate", "force_simulate", "execute", "force_execute", "skip"}
+
+func DataSourceWatch() *schema.Resource {
+	watchSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"watch_id": {
+			Description:  "Identifier of the stored watch to execute.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"watch_id", "watch"},
+		},
+		"watch": {
+			Description:      "JSON definition of an inline watch to execute, the watch is not stored.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ExactlyOneOf:     []string{"watch_id", "watch"},
+		},
+		"triggered_time": {
+			Description:  "Overrides the time the watch is triggered at, e.g. `2022-01-01T00:00:00Z`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+		"scheduled_time": {
+			Description:  "Overrides the time the watch is scheduled at, e.g. `2022-01-01T00:00:00Z`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+		"alternative_input": {
+			Description:      "JSON object used as the payload of the watch instead of the result of its input.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"ignore_condition": {
+			Description: "If true, the condition of the watch is ignored and the actions are run as if it was met.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"action_mode": {
+			Description:  "The mode all the actions of the watch are run with: `simulate`, `force_simulate`, `execute`, `force_execute` or `skip`. Actions are only simulated by default, the `execute` modes can have side effects on each read of the data source.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "simulate",
+			ValidateFunc: validation.StringInSlice(actionModes, false),
+		},
+		"state": {
+			Description: "The state of the watch execution, e.g. `executed` or `execution_not_needed`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"execution_time": {
+			Description: "The time the watch was executed at.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"condition_met": {
+			Description: "Whether the condition of the watch was met.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"actions": {
+			Description: "The results of the watch actions.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "Identifier of the action.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": {
+						Description: "Type of the action, e.g. `logging`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"status": {
+						Description: "Status of the action, e.g. `simulated`, `success` or `throttled`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"reason": {
+						Description: "The reason the action was not run successfully, if any.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"result": {
+			Description: "JSON object with the full result of the watch execution.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(watchSchema)
+
+	return &schema.Resource{
+		Description: "Executes a stored or inline watch in the debug mode without recording the execution, e.g. to test a watch. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/watcher-api-execute-watch.html",
+		ReadContext: dataSourceWatchRead,
+		Schema:      watchSchema,
+	}
+}
+
+func dataSourceWatchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	execution := models.WatchExecution{
+		IgnoreCondition: d.Get("ignore_condition").(bool),
+		ActionModes:     map[string]string{"_all": d.Get("action_mode").(string)},
+		RecordExecution: false,
+	}
+	if v, ok := d.GetOk("watch"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &execution.Watch); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("alternative_input"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &execution.AlternativeInput); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	triggeredTime, scheduledTime := d.Get("triggered_time").(string), d.Get("scheduled_time").(string)
+	if triggeredTime != "" || scheduledTime != "" {
+		execution.TriggerData = &models.WatchTriggerData{
+			TriggeredTime: triggeredTime,
+			ScheduledTime: scheduledTime,
+		}
+	}
+
+	watchID := d.Get("watch_id").(string)
+	record, diags := elasticsearch.ExecuteWatch(ctx, client, watchID, &execution)
+	if diags.HasError() {
+		return diags
+	}
+	if record == nil {
+		return diag.Errorf(`the execution of the watch "%s" returned no watch record`, watchID)
+	}
+
+	if err := d.Set("state", record.State); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("execution_time", record.Result.ExecutionTime); err != nil {
+		return diag.FromErr(err)
+	}
+	met, _ := record.Result.Condition["met"].(bool)
+	if err := d.Set("condition_met", met); err != nil {
+		return diag.FromErr(err)
+	}
+	actions := make([]interface{}, len(record.Result.Actions))
+	for i, a := range record.Result.Actions {
+		actions[i] = map[string]interface{}{
+			"id":     a.Id,
+			"type":   a.Type,
+			"status": a.Status,
+			"reason": a.Reason,
+		}
+	}
+	if err := d.Set("actions", actions); err != nil {
+		return diag.FromErr(err)
+	}
+	result, err := json.Marshal(record.Result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("result", string(result)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := watchID
+	if name == "" {
+		name = "_inline"
+	}
+	id, diags := client.ID(ctx, fmt.Sprintf("%s/%s", name, record.Result.ExecutionTime))
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+	return diags
+}
@@ -0,0 +1,58 @@
+package watcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceWatch(t *testing.T) {
+	watchID := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc:  isWatcherUnavailable,
+				PreConfig: func() { putTestWatch(t, watchID) },
+				Config:    testAccDataSourceWatch(watchID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.stored", "state", "executed"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.stored", "condition_met", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.stored", "actions.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.stored", "actions.0.id", "log"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.stored", "actions.0.status", "simulated"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.inline", "condition_met", "false"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_watch.inline", "actions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceWatch(watchID string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_watch" "stored" {
+  watch_id       = "%s"
+  triggered_time = "2022-01-01T00:00:00Z"
+}
+
+data "elasticstack_elasticsearch_watch" "inline" {
+  watch = jsonencode({
+    trigger   = { schedule = { interval = "1h" } }
+    input     = { simple = { count = 1 } }
+    condition = { compare = { "ctx.payload.count" = { gt = 5 } } }
+    actions   = { log = { logging = { text = "test" } } }
+  })
+  alternative_input = jsonencode({ count = 2 })
+}
+`, watchID)
+}
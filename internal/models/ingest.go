@@ -6,6 +6,7 @@ type IngestPipeline struct {
 	OnFailure   []map[string]interface{} `json:"on_failure,omitempty"`
 	Processors  []map[string]interface{} `json:"processors"`
 	Metadata    map[string]interface{}   `json:"_meta,omitempty"`
+	Version     *int                     `json:"version,omitempty"`
 }
 
 type CommonProcessor struct {
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 type ClusterInfo struct {
 	Name        string `json:"name"`
@@ -54,6 +58,26 @@ type Role struct {
 	RusAs        []string               `json:"run_as,omitempty"`
 }
 
+func (r *Role) IsReserved() bool {
+	if reserved := r.Metadata["_reserved"]; reserved != nil {
+		isReserved, ok := reserved.(bool)
+		return ok && isReserved
+	}
+	return false
+}
+
+type ApplicationPrivilege struct {
+	Application string                 `json:"-"`
+	Name        string                 `json:"-"`
+	Actions     []string               `json:"actions"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type BuiltinPrivileges struct {
+	Cluster []string `json:"cluster"`
+	Index   []string `json:"index"`
+}
+
 type RoleMapping struct {
 	Name          string                   `json:"-"`
 	Enabled       bool                     `json:"enabled"`
@@ -70,6 +94,11 @@ type ApiKey struct {
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 }
 
+type ApiKeyUpdate struct {
+	RolesDescriptors map[string]Role        `json:"role_descriptors"`
+	Metadata         map[string]interface{} `json:"metadata"`
+}
+
 type ApiKeyResponse struct {
 	ApiKey
 	RolesDescriptors map[string]Role `json:"role_descriptors,omitempty"`
@@ -100,16 +129,18 @@ type Application struct {
 }
 
 type IndexTemplate struct {
-	Name          string                 `json:"-"`
-	Create        bool                   `json:"-"`
-	Timeout       string                 `json:"-"`
-	ComposedOf    []string               `json:"composed_of"`
-	DataStream    *DataStreamSettings    `json:"data_stream,omitempty"`
-	IndexPatterns []string               `json:"index_patterns"`
-	Meta          map[string]interface{} `json:"_meta,omitempty"`
-	Priority      *int                   `json:"priority,omitempty"`
-	Template      *Template              `json:"template,omitempty"`
-	Version       *int                   `json:"version,omitempty"`
+	Name                            string                 `json:"-"`
+	Create                          bool                   `json:"-"`
+	Timeout                         string                 `json:"-"`
+	AllowAutoCreate                 *bool                  `json:"allow_auto_create,omitempty"`
+	ComposedOf                      []string               `json:"composed_of"`
+	IgnoreMissingComponentTemplates []string               `json:"ignore_missing_component_templates,omitempty"`
+	DataStream                      *DataStreamSettings    `json:"data_stream,omitempty"`
+	IndexPatterns                   []string               `json:"index_patterns"`
+	Meta                            map[string]interface{} `json:"_meta,omitempty"`
+	Priority                        *int                   `json:"priority,omitempty"`
+	Template                        *Template              `json:"template,omitempty"`
+	Version                         *int                   `json:"version,omitempty"`
 }
 
 type DataStreamSettings struct {
@@ -149,8 +180,16 @@ type ComponentTemplateResponse struct {
 }
 
 type PolicyDefinition struct {
-	Policy   Policy `json:"policy"`
-	Modified string `json:"modified_date"`
+	Policy   Policy         `json:"policy"`
+	Modified string         `json:"modified_date"`
+	Version  int            `json:"version"`
+	InUseBy  *PolicyInUseBy `json:"in_use_by,omitempty"`
+}
+
+type PolicyInUseBy struct {
+	Indices             []string `json:"indices"`
+	DataStreams         []string `json:"data_streams"`
+	ComposableTemplates []string `json:"composable_templates"`
 }
 
 type Policy struct {
@@ -166,6 +205,20 @@ type Phase struct {
 
 type Action map[string]interface{}
 
+type IlmExplain struct {
+	Index                string                 `json:"index"`
+	Managed              bool                   `json:"managed"`
+	Policy               string                 `json:"policy"`
+	Phase                string                 `json:"phase"`
+	Action               string                 `json:"action"`
+	Step                 string                 `json:"step"`
+	FailedStep           string                 `json:"failed_step"`
+	FailedStepRetryCount int                    `json:"failed_step_retry_count"`
+	IsAutoRetryableError bool                   `json:"is_auto_retryable_error"`
+	Age                  string                 `json:"age"`
+	StepInfo             map[string]interface{} `json:"step_info"`
+}
+
 type SnapshotRepository struct {
 	Name     string                 `json:"-"`
 	Type     string                 `json:"type"`
@@ -173,6 +226,39 @@ type SnapshotRepository struct {
 	Verify   bool                   `json:"verify"`
 }
 
+type Snapshot struct {
+	Indices            []string `json:"indices,omitempty"`
+	IncludeGlobalState *bool    `json:"include_global_state,omitempty"`
+	Partial            *bool    `json:"partial,omitempty"`
+}
+
+type SnapshotInfo struct {
+	Snapshot           string            `json:"snapshot"`
+	Uuid               string            `json:"uuid"`
+	State              string            `json:"state"`
+	Indices            []string          `json:"indices"`
+	IncludeGlobalState bool              `json:"include_global_state"`
+	StartTimeInMillis  int64             `json:"start_time_in_millis"`
+	EndTimeInMillis    int64             `json:"end_time_in_millis"`
+	DurationInMillis   int64             `json:"duration_in_millis"`
+	Shards             SnapshotShards    `json:"shards"`
+	Failures           []SnapshotFailure `json:"failures"`
+}
+
+type SnapshotShards struct {
+	Total      int `json:"total"`
+	Failed     int `json:"failed"`
+	Successful int `json:"successful"`
+}
+
+type SnapshotFailure struct {
+	Index   string `json:"index"`
+	ShardId int    `json:"shard_id"`
+	Reason  string `json:"reason"`
+	NodeId  string `json:"node_id"`
+	Status  string `json:"status"`
+}
+
 type SnapshotPolicy struct {
 	Id         string                `json:"-"`
 	Config     *SnapshotPolicyConfig `json:"config,omitempty"`
@@ -210,6 +296,9 @@ type PutIndexParams struct {
 	MasterTimeout       time.Duration
 	Timeout             time.Duration
 	IncludeTypeName     bool // IncludeTypeName is supported only in v7.x
+	// IsStaticSetting reports whether the setting can only be set on the index creation, it's used to reconcile with an
+	// index created by a concurrent change.
+	IsStaticSetting func(key string) bool
 }
 
 type IndexAlias struct {
@@ -241,6 +330,32 @@ type DataStreamIndex struct {
 	IndexUUID string `json:"index_uuid"`
 }
 
+type ResolvedIndices struct {
+	Indices []ResolvedIndex `json:"indices"`
+}
+
+type IndexSettings struct {
+	Settings map[string]interface{} `json:"settings"`
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
+}
+
+type ResolvedIndex struct {
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases"`
+	Attributes []string `json:"attributes"`
+	DataStream string   `json:"data_stream"`
+}
+
+// HasAttribute reports whether the index has the attribute, e.g. `hidden`, `system` or `closed`.
+func (i ResolvedIndex) HasAttribute(attribute string) bool {
+	for _, a := range i.Attributes {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
+
 type TimestampField struct {
 	Name string `json:"name"`
 }
@@ -262,3 +377,256 @@ type Script struct {
 	Params   map[string]interface{} `json:"params"`
 	Context  string                 `json:"-"`
 }
+
+type WatchStatus struct {
+	State   *WatchState                  `json:"state,omitempty"`
+	Actions map[string]WatchActionStatus `json:"actions"`
+	Version int                          `json:"version"`
+}
+
+type WatchState struct {
+	Active    bool   `json:"active"`
+	Timestamp string `json:"timestamp"`
+}
+
+type WatchActionStatus struct {
+	Ack WatchActionAck `json:"ack"`
+}
+
+type WatchActionAck struct {
+	Timestamp string `json:"timestamp"`
+	State     string `json:"state"`
+}
+
+type WatchExecution struct {
+	TriggerData      *WatchTriggerData      `json:"trigger_data,omitempty"`
+	AlternativeInput map[string]interface{} `json:"alternative_input,omitempty"`
+	IgnoreCondition  bool                   `json:"ignore_condition,omitempty"`
+	ActionModes      map[string]string      `json:"action_modes,omitempty"`
+	RecordExecution  bool                   `json:"record_execution"`
+	Watch            map[string]interface{} `json:"watch,omitempty"`
+}
+
+type WatchTriggerData struct {
+	TriggeredTime string `json:"triggered_time,omitempty"`
+	ScheduledTime string `json:"scheduled_time,omitempty"`
+}
+
+type WatchRecord struct {
+	WatchId string            `json:"watch_id"`
+	State   string            `json:"state"`
+	Result  WatchRecordResult `json:"result"`
+}
+
+type WatchRecordResult struct {
+	ExecutionTime     string                 `json:"execution_time"`
+	ExecutionDuration int64                  `json:"execution_duration"`
+	Input             map[string]interface{} `json:"input"`
+	Condition         map[string]interface{} `json:"condition"`
+	Actions           []WatchActionResult    `json:"actions"`
+}
+
+type WatchActionResult struct {
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type ClusterHealth struct {
+	ClusterName                 string  `json:"cluster_name"`
+	Status                      string  `json:"status"`
+	TimedOut                    bool    `json:"timed_out"`
+	NumberOfNodes               int     `json:"number_of_nodes"`
+	NumberOfDataNodes           int     `json:"number_of_data_nodes"`
+	ActivePrimaryShards         int     `json:"active_primary_shards"`
+	ActiveShards                int     `json:"active_shards"`
+	RelocatingShards            int     `json:"relocating_shards"`
+	InitializingShards          int     `json:"initializing_shards"`
+	UnassignedShards            int     `json:"unassigned_shards"`
+	ActiveShardsPercentAsNumber float64 `json:"active_shards_percent_as_number"`
+}
+
+type ClusterHealthParams struct {
+	Indices             []string
+	WaitForStatus       string
+	WaitForNodes        string
+	WaitForActiveShards string
+	Timeout             time.Duration
+}
+
+type CatNode struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	Ip              string `json:"ip"`
+	NodeRole        string `json:"node.role"`
+	Master          string `json:"master"`
+	Version         string `json:"version"`
+	HeapCurrent     string `json:"heap.current"`
+	HeapMax         string `json:"heap.max"`
+	HeapPercent     string `json:"heap.percent"`
+	DiskUsed        string `json:"disk.used"`
+	DiskTotal       string `json:"disk.total"`
+	DiskUsedPercent string `json:"disk.used_percent"`
+}
+
+type TaskInfo struct {
+	Node               string                 `json:"node"`
+	Id                 int64                  `json:"id"`
+	Type               string                 `json:"type"`
+	Action             string                 `json:"action"`
+	Description        string                 `json:"description,omitempty"`
+	StartTimeInMillis  int64                  `json:"start_time_in_millis"`
+	RunningTimeInNanos int64                  `json:"running_time_in_nanos"`
+	Cancellable        bool                   `json:"cancellable"`
+	Cancelled          bool                   `json:"cancelled,omitempty"`
+	ParentTaskId       string                 `json:"parent_task_id,omitempty"`
+	Status             map[string]interface{} `json:"status,omitempty"`
+}
+
+// TaskId returns the identifier of the task as used by the task management APIs, i.e. `<node>:<id>`.
+func (t TaskInfo) TaskId() string {
+	return fmt.Sprintf("%s:%d", t.Node, t.Id)
+}
+
+type Task struct {
+	Completed bool                   `json:"completed"`
+	Task      TaskInfo               `json:"task"`
+	Response  map[string]interface{} `json:"response,omitempty"`
+	Error     map[string]interface{} `json:"error,omitempty"`
+}
+
+type ByQuery struct {
+	Query  map[string]interface{} `json:"query,omitempty"`
+	Script *ByQueryScript         `json:"script,omitempty"`
+}
+
+type ByQueryScript struct {
+	Source string                 `json:"source"`
+	Lang   string                 `json:"lang,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+type ByQueryParams struct {
+	Conflicts         string
+	Slices            string
+	Refresh           bool
+	WaitForCompletion bool
+}
+
+type ByQueryResponse struct {
+	Task             string                   `json:"task,omitempty"`
+	Total            int                      `json:"total"`
+	Updated          int                      `json:"updated"`
+	Deleted          int                      `json:"deleted"`
+	VersionConflicts int                      `json:"version_conflicts"`
+	Noops            int                      `json:"noops"`
+	Failures         []map[string]interface{} `json:"failures,omitempty"`
+}
+
+type BulkOperation struct {
+	// Action is either `index` or `delete`
+	Action string
+	Id     string
+	Source json.RawMessage
+}
+
+type BulkResponse struct {
+	Errors bool                  `json:"errors"`
+	Items  []map[string]BulkItem `json:"items"`
+}
+
+type BulkItem struct {
+	Index  string                 `json:"_index"`
+	Id     string                 `json:"_id"`
+	Status int                    `json:"status"`
+	Result string                 `json:"result,omitempty"`
+	Error  map[string]interface{} `json:"error,omitempty"`
+}
+
+type MgetResponse struct {
+	Docs []MgetDocument `json:"docs"`
+}
+
+type MgetDocument struct {
+	Id     string          `json:"_id"`
+	Found  bool            `json:"found"`
+	Source json.RawMessage `json:"_source,omitempty"`
+}
+
+type Document struct {
+	Index   string          `json:"_index"`
+	Id      string          `json:"_id"`
+	Version int64           `json:"_version"`
+	Found   bool            `json:"found"`
+	Source  json.RawMessage `json:"_source,omitempty"`
+}
+
+type SqlQuery struct {
+	Query     string `json:"query,omitempty"`
+	FetchSize int    `json:"fetch_size,omitempty"`
+	Cursor    string `json:"cursor,omitempty"`
+}
+
+type SqlQueryResponse struct {
+	Columns []SqlColumn     `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+	Cursor  string          `json:"cursor"`
+}
+
+type SqlColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type SearchRequest struct {
+	Query          map[string]interface{} `json:"query,omitempty"`
+	Size           int                    `json:"size"`
+	TrackTotalHits interface{}            `json:"track_total_hits,omitempty"`
+	Source         *SearchSourceFilter    `json:"_source,omitempty"`
+}
+
+type TransformPreviewRequest struct {
+	Source TransformSource        `json:"source"`
+	Pivot  map[string]interface{} `json:"pivot,omitempty"`
+	Latest map[string]interface{} `json:"latest,omitempty"`
+}
+
+type TransformSource struct {
+	Index []string               `json:"index"`
+	Query map[string]interface{} `json:"query,omitempty"`
+}
+
+type TransformPreviewResponse struct {
+	Preview            []map[string]interface{} `json:"preview"`
+	GeneratedDestIndex struct {
+		Mappings map[string]interface{} `json:"mappings"`
+		Settings map[string]interface{} `json:"settings"`
+	} `json:"generated_dest_index"`
+}
+
+type SearchSourceFilter struct {
+	Includes []string `json:"includes,omitempty"`
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+type SearchResponse struct {
+	Hits SearchHits `json:"hits"`
+}
+
+type SearchHits struct {
+	Total *SearchTotal `json:"total"`
+	Hits  []SearchHit  `json:"hits"`
+}
+
+type SearchTotal struct {
+	Value    int    `json:"value"`
+	Relation string `json:"relation"`
+}
+
+type SearchHit struct {
+	Index  string                 `json:"_index"`
+	Id     string                 `json:"_id"`
+	Score  *float64               `json:"_score"`
+	Source map[string]interface{} `json:"_source"`
+}
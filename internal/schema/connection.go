@@ -1,20 +1,26 @@
 package schema
 
 import (
+	"crypto/tls"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func GetConnectionSchema(keyName string, isProviderConfiguration bool) *schema.Schema {
 	usernamePath := makePathRef(keyName, "username")
 	passwordPath := makePathRef(keyName, "password")
+	apiKeyPath := makePathRef(keyName, "api_key")
 	caFilePath := makePathRef(keyName, "ca_file")
 	caDataPath := makePathRef(keyName, "ca_data")
 	certFilePath := makePathRef(keyName, "cert_file")
 	certDataPath := makePathRef(keyName, "cert_data")
 	keyFilePath := makePathRef(keyName, "key_file")
 	keyDataPath := makePathRef(keyName, "key_data")
+	keystorePath := makePathRef(keyName, "keystore_path")
 
 	usernameRequiredWithValidation := []string{passwordPath}
 	passwordRequiredWithValidation := []string{usernamePath}
@@ -62,6 +68,42 @@ func GetConnectionSchema(keyName string, isProviderConfiguration bool) *schema.S
 					DefaultFunc:   withEnvDefault("ELASTICSEARCH_API_KEY", nil),
 					ConflictsWith: []string{usernamePath, passwordPath},
 				},
+				"oauth2": {
+					Description:   "Authenticates to Elasticsearch with the bearer token acquired with the OAuth2 client credentials grant. The token is renewed before it expires.",
+					Type:          schema.TypeList,
+					Optional:      true,
+					MaxItems:      1,
+					ConflictsWith: []string{usernamePath, passwordPath, apiKeyPath},
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"token_url": {
+								Description:  "URL of the token endpoint of the OAuth2 authorization server.",
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							},
+							"client_id": {
+								Description: "ID of the OAuth2 client.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"client_secret": {
+								Description: "Secret of the OAuth2 client, sent with the HTTP basic authentication to the token endpoint.",
+								Type:        schema.TypeString,
+								Required:    true,
+								Sensitive:   true,
+							},
+							"scopes": {
+								Description: "Scopes to request for the token.",
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						},
+					},
+				},
 				"endpoints": {
 					Description: "A comma-separated list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
 					Type:        schema.TypeList,
@@ -77,6 +119,66 @@ func GetConnectionSchema(keyName string, isProviderConfiguration bool) *schema.S
 					Optional:    true,
 					DefaultFunc: withEnvDefault("ELASTICSEARCH_INSECURE", false),
 				},
+				"compression": {
+					Description: "Enable gzip compression of the request bodies sent to Elasticsearch. Gzip compressed responses are always accepted and transparently decoded.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"minimize_responses": {
+					Description: "Fetch only the fields of the responses used by the provider, e.g. leaving out the indices using an ILM policy, to reduce the size of the large responses, e.g. when a proxy limits it.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"master_timeout": {
+					Description:  "Period to wait for the master node, applied to the cluster state operations, e.g. on templates, cluster settings and aliases. Defaults to the Elasticsearch default (`30s`).",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateDuration,
+				},
+				"timeout": {
+					Description:  "Period to wait for the response of the cluster state operations, e.g. on templates, cluster settings and aliases. Defaults to the Elasticsearch default (`30s`).",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateDuration,
+				},
+				"rate_limit": {
+					Description:  "Maximum number of requests per second sent to Elasticsearch, shared by all the resources using the connection. Unlimited by default. Terraform runs up to 10 operations concurrently (see the `-parallelism` flag), the requests exceeding the limit wait for their turn.",
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					ValidateFunc: validation.FloatAtLeast(0),
+				},
+				"max_concurrent_requests": {
+					Description:  "Maximum number of requests sent to Elasticsearch at the same time, shared by all the resources using the connection. Unlimited by default. Lower values than the Terraform `-parallelism` (10 by default) make the operations wait for each other.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"tls_min_version": {
+					Description:  "Minimum TLS version accepted for the connection: `1.0`, `1.1`, `1.2` or `1.3`. Defaults to the Go default (`1.2`).",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(TLSVersionNames(), false),
+				},
+				"tls_max_version": {
+					Description:  "Maximum TLS version accepted for the connection: `1.0`, `1.1`, `1.2` or `1.3`. Defaults to the Go default (`1.3`).",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(TLSVersionNames(), false),
+				},
+				"cipher_suites": {
+					Description: "Cipher suites accepted for the TLS 1.0-1.2 connections, e.g. `TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`. The TLS 1.3 cipher suites are not configurable. Defaults to the Go defaults.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateCipherSuite,
+					},
+				},
+				"tls_server_name": {
+					Description: "Server name used to verify the TLS certificate of Elasticsearch, instead of the host of the endpoints. Useful when connecting through a host or an IP address not listed in the certificate, e.g. to the ECK HTTP service inside of Kubernetes.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
 				"ca_file": {
 					Description:   "Path to a custom Certificate Authority certificate",
 					Type:          schema.TypeString,
@@ -94,21 +196,21 @@ func GetConnectionSchema(keyName string, isProviderConfiguration bool) *schema.S
 					Type:          schema.TypeString,
 					Optional:      true,
 					RequiredWith:  []string{keyFilePath},
-					ConflictsWith: []string{certDataPath, keyDataPath},
+					ConflictsWith: []string{certDataPath, keyDataPath, keystorePath},
 				},
 				"key_file": {
 					Description:   "Path to a file containing the PEM encoded private key for client auth",
 					Type:          schema.TypeString,
 					Optional:      true,
 					RequiredWith:  []string{certFilePath},
-					ConflictsWith: []string{certDataPath, keyDataPath},
+					ConflictsWith: []string{certDataPath, keyDataPath, keystorePath},
 				},
 				"cert_data": {
 					Description:   "PEM encoded certificate for client auth",
 					Type:          schema.TypeString,
 					Optional:      true,
 					RequiredWith:  []string{keyDataPath},
-					ConflictsWith: []string{certFilePath, keyFilePath},
+					ConflictsWith: []string{certFilePath, keyFilePath, keystorePath},
 				},
 				"key_data": {
 					Description:   "PEM encoded private key for client auth",
@@ -116,7 +218,20 @@ func GetConnectionSchema(keyName string, isProviderConfiguration bool) *schema.S
 					Optional:      true,
 					Sensitive:     true,
 					RequiredWith:  []string{certDataPath},
-					ConflictsWith: []string{certFilePath, keyFilePath},
+					ConflictsWith: []string{certFilePath, keyFilePath, keystorePath},
+				},
+				"keystore_path": {
+					Description:   "Path to a PKCS#12 keystore (`.p12` or `.pfx`) containing the certificate and the private key for client auth, as an alternative to the PEM encoded ones. Only the keystores using the legacy encryption algorithms are supported, e.g. created with `openssl pkcs12 -export -legacy`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{certFilePath, keyFilePath, certDataPath, keyDataPath},
+				},
+				"keystore_password": {
+					Description:  "Password of the PKCS#12 keystore.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					RequiredWith: []string{keystorePath},
 				},
 			},
 		},
@@ -126,3 +241,45 @@ func GetConnectionSchema(keyName string, isProviderConfiguration bool) *schema.S
 func makePathRef(keyName string, keyValue string) string {
 	return fmt.Sprintf("%s.0.%s", keyName, keyValue)
 }
+
+// TLSVersions maps the TLS versions supported by the connection to their crypto/tls value.
+var TLSVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSVersionNames returns the sorted names of the supported TLS versions.
+func TLSVersionNames() []string {
+	names := make([]string, 0, len(TLSVersions))
+	for name := range TLSVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateCipherSuite(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		if suite.Name == v {
+			return nil, nil
+		}
+	}
+	return nil, []error{fmt.Errorf("%q contains an unknown cipher suite: %s", k, v)}
+}
+
+func validateDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, []error{fmt.Errorf("%q contains an invalid duration: %s", k, err)}
+	}
+	return nil, nil
+}
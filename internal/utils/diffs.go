@@ -13,6 +13,19 @@ func DiffJsonSuppress(k, old, new string, d *schema.ResourceData) bool {
 	return result
 }
 
+// DiffTimeValueSuppress suppresses the diff of the Elasticsearch time values of the same duration, e.g. `30d` and `720h`.
+func DiffTimeValueSuppress(k, old, new string, d *schema.ResourceData) bool {
+	o, err := ParseTimeValue(old)
+	if err != nil {
+		return false
+	}
+	n, err := ParseTimeValue(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
 func DiffIndexSettingSuppress(k, old, new string, d *schema.ResourceData) bool {
 	var o, n map[string]interface{}
 	if err := json.Unmarshal([]byte(old), &o); err != nil {
@@ -35,3 +48,30 @@ func NormalizeIndexSettings(m map[string]interface{}) map[string]interface{} {
 	}
 	return out
 }
+
+// DiffManagedMetadataSuppress suppresses the diff of the JSON metadata of the same value, and the removal of the
+// metadata when it's not configured and marks the object as managed by another system, e.g. Fleet.
+func DiffManagedMetadataSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if new == "" {
+		return isManagedMetadata(old)
+	}
+	return DiffJsonSuppress(k, old, new, d)
+}
+
+// DiffManagedVersionSuppress suppresses the removal of the version when it's not configured and the metadata of the
+// object marks it as managed by another system, e.g. Fleet.
+func DiffManagedVersionSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" && new != "0" {
+		return false
+	}
+	metadata, _ := d.GetChange("metadata")
+	return isManagedMetadata(metadata.(string))
+}
+
+func isManagedMetadata(v string) bool {
+	metadata := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(v), &metadata); err != nil {
+		return false
+	}
+	return isManaged(metadata)
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AddIgnoreManagedSchema adds the `ignore_managed` flag to the resources, which objects can be also managed by other systems, e.g. Fleet.
+func AddIgnoreManagedSchema(providedSchema map[string]*schema.Schema) {
+	providedSchema["ignore_managed"] = &schema.Schema{
+		Description: "Refuse to create or update the object if it is marked as managed by another system, e.g. by Fleet (`_meta.managed` is `true`). Prevents Terraform and the other system from overwriting each other's changes. The object marked as managed after its creation is not refreshed, with a warning, and can still be destroyed.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// CheckManaged returns an error if `ignore_managed` is set and the object is marked as managed in its metadata,
+// unless it's the resource itself which configures the object as managed.
+func CheckManaged(d *schema.ResourceData, kind, name string, metadata map[string]interface{}) diag.Diagnostics {
+	if !isManagedByOther(d, metadata) {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The %s is managed by another system", kind),
+			Detail:   fmt.Sprintf(`The %s "%s" is marked as managed (_meta.managed is true), e.g. by Fleet. Terraform refuses to manage it because "ignore_managed" is set. Use a different name, or unset "ignore_managed" to manage the object anyway.`, kind, name),
+		},
+	}
+}
+
+// WarnManaged returns a warning if `ignore_managed` is set and the object has been marked as managed since it was
+// created, in which case the state is kept as it is instead of being refreshed, so that the resource can be destroyed.
+func WarnManaged(d *schema.ResourceData, kind, name string, metadata map[string]interface{}) diag.Diagnostics {
+	if !isManagedByOther(d, metadata) {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The %s is managed by another system", kind),
+			Detail:   fmt.Sprintf(`The %s "%s" is marked as managed (_meta.managed is true), e.g. by Fleet. Its state is not refreshed and its changes fail because "ignore_managed" is set. Unset "ignore_managed" to manage the object anyway.`, kind, name),
+		},
+	}
+}
+
+func isManagedByOther(d *schema.ResourceData, metadata map[string]interface{}) bool {
+	if !d.Get("ignore_managed").(bool) || !isManaged(metadata) {
+		return false
+	}
+	return !isManagedMetadata(configuredMetadata(d))
+}
+
+// configuredMetadata returns the metadata configured for the resource. The planned metadata can not be used, as it
+// keeps the metadata of the managed object when not configured, the state being used when the config is not known
+// (i.e. on refresh).
+func configuredMetadata(d *schema.ResourceData) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return d.Get("metadata").(string)
+	}
+	if v := config.GetAttr("metadata"); v.IsKnown() && !v.IsNull() {
+		return v.AsString()
+	}
+	return ""
+}
+
+func isManaged(metadata map[string]interface{}) bool {
+	managed, ok := metadata["managed"].(bool)
+	return ok && managed
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AddTaskSchema adds the `wait_for_completion` flag and the `task_id` output to the resources running long operations,
+// which can be handed off to a task and awaited with the `elasticstack_elasticsearch_task_wait` resource.
+func AddTaskSchema(providedSchema map[string]*schema.Schema) {
+	providedSchema["wait_for_completion"] = &schema.Schema{
+		Description: "Wait for the operation to complete. If `false`, the operation runs in a task, which can be awaited with the `elasticstack_elasticsearch_task_wait` resource using `task_id`.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		ForceNew:    true,
+	}
+	providedSchema["task_id"] = &schema.Schema{
+		Description: "Identifier of the task running the operation, always set when `wait_for_completion` is `false`.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
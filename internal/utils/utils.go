@@ -1,11 +1,13 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha1"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
@@ -25,16 +27,51 @@ func CheckError(res *esapi.Response, errMsg string) diag.Diagnostics {
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		detail := fmt.Sprintf("Failed with: %s", body)
+		if res.StatusCode == http.StatusGone {
+			detail = fmt.Sprintf("The API is not available, e.g. in the Elasticsearch Serverless projects. Failed with: %s", body)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  errMsg,
-			Detail:   fmt.Sprintf("Failed with: %s", body),
+			Detail:   detail,
 		})
 		return diags
 	}
 	return diags
 }
 
+// DiagsAsError converts the error diagnostics into an error, e.g. to be returned from the functions which cannot return diagnostics.
+func DiagsAsError(diags diag.Diagnostics) error {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if d.Detail != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+			continue
+		}
+		errs = append(errs, d.Summary)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// MarshalJSON encodes the value as JSON without escaping the HTML characters, so the Mustache templates and the scripts,
+// e.g. `{{#toJson}}` or `&&`, are kept verbatim in the state and in the requests.
+func MarshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
 // Compares the JSON in two byte slices
 func JSONBytesEqual(a, b []byte) (bool, error) {
 	var j, j2 interface{}
@@ -129,6 +166,23 @@ func AddConnectionSchema(providedSchema map[string]*schema.Schema) {
 	providedSchema[connectionKeyName] = providerSchema.GetConnectionSchema(connectionKeyName, false)
 }
 
+// AddTimeoutsSchema adds the `master_timeout` and `timeout` attributes overriding the ones of the connection for the
+// requests of the resource.
+func AddTimeoutsSchema(providedSchema map[string]*schema.Schema) {
+	providedSchema["master_timeout"] = &schema.Schema{
+		Description:  "Period to wait for the master node, overriding the `master_timeout` of the connection for the requests of the resource. Defaults to the Elasticsearch default (`30s`).",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: StringIsDuration,
+	}
+	providedSchema["timeout"] = &schema.Schema{
+		Description:  "Period to wait for the response, overriding the `timeout` of the connection for the requests of the resource. Defaults to the Elasticsearch default (`30s`).",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: StringIsDuration,
+	}
+}
+
 func StringToHash(s string) (*string, error) {
 	h := sha1.New()
 	_, err := h.Write([]byte(s))
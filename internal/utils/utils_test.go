@@ -84,3 +84,55 @@ func TestDiffIndexTemplateSuppress(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in  interface{}
+		out string
+	}{
+		{
+			map[string]interface{}{"source": `{"terms":{"team":{{#toJson}}_user.metadata.teams{{/toJson}}}}`},
+			`{"source":"{\"terms\":{\"team\":{{#toJson}}_user.metadata.teams{{/toJson}}}}"}`,
+		},
+		{
+			map[string]interface{}{"script": "doc['a'].value > 1 && doc['b'].value < 2"},
+			`{"script":"doc['a'].value > 1 && doc['b'].value < 2"}`,
+		},
+		{
+			[]interface{}{map[string]interface{}{"template": "{{_user.username}}"}},
+			`[{"template":"{{_user.username}}"}]`,
+		},
+	}
+
+	for _, tc := range tests {
+		res, err := utils.MarshalJSON(tc.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(res) != tc.out {
+			t.Errorf("expected %s, got %s", tc.out, res)
+		}
+	}
+}
+
+func TestDiffManagedMetadataSuppress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		old, new string
+		equal    bool
+	}{
+		{`{"a":1,"b":2}`, `{"b":2,"a":1}`, true},
+		{`{"managed":true,"managed_by":"fleet"}`, "", true},
+		{`{"managed":false}`, "", false},
+		{`{"owner":"terraform"}`, "", false},
+		{`{"managed":true}`, `{"owner":"terraform"}`, false},
+	}
+	for _, tt := range tests {
+		if got := utils.DiffManagedMetadataSuppress("", tt.old, tt.new, nil); got != tt.equal {
+			t.Errorf("DiffManagedMetadataSuppress(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.equal)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,3 +21,49 @@ func StringIsDuration(i interface{}, k string) (warnings []string, errors []erro
 
 	return nil, nil
 }
+
+// StringIsTimeValue is a SchemaValidateFunc which tests to make sure the supplied string is a valid Elasticsearch time value, e.g. `30d`.
+func StringIsTimeValue(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := ParseTimeValue(v); err != nil {
+		return nil, []error{fmt.Errorf("%q contains an invalid time value: %s", k, err)}
+	}
+
+	return nil, nil
+}
+
+var timeValueRe = regexp.MustCompile(`^(-?\d+)(d|h|m|s|ms|micros|nanos)$`)
+
+var timeUnits = map[string]time.Duration{
+	"d":      24 * time.Hour,
+	"h":      time.Hour,
+	"m":      time.Minute,
+	"s":      time.Second,
+	"ms":     time.Millisecond,
+	"micros": time.Microsecond,
+	"nanos":  time.Nanosecond,
+}
+
+// ParseTimeValue parses the Elasticsearch time units, e.g. `30d` or `12h`. Same as Elasticsearch, `0` and `-1` are accepted without a unit.
+func ParseTimeValue(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	switch v {
+	case "0":
+		return 0, nil
+	case "-1":
+		return -time.Millisecond, nil
+	}
+	m := timeValueRe.FindStringSubmatch(v)
+	if m == nil {
+		return 0, fmt.Errorf(`"%s" is not a valid time value, the supported units are d, h, m, s, ms, micros and nanos, e.g. "30d" or "12h"`, v)
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * timeUnits[m[2]], nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestStringIsDuration(t *testing.T) {
@@ -46,3 +47,74 @@ func TestStringIsDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTimeValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "12h", want: 12 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "45s", want: 45 * time.Second},
+		{value: "100ms", want: 100 * time.Millisecond},
+		{value: "10micros", want: 10 * time.Microsecond},
+		{value: "10nanos", want: 10 * time.Nanosecond},
+		{value: " 1d ", want: 24 * time.Hour},
+		{value: "0", want: 0},
+		{value: "-1", want: -time.Millisecond},
+		{value: "30", wantErr: true},
+		{value: "30w", wantErr: true},
+		{value: "1.5h", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeValue(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimeValue(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeValue(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestStringIsTimeValue(t *testing.T) {
+	t.Parallel()
+
+	if _, errs := StringIsTimeValue("30d", "min_age"); len(errs) > 0 {
+		t.Errorf("StringIsTimeValue() unexpected errors = %v", errs)
+	}
+	_, errs := StringIsTimeValue("30days", "min_age")
+	wantErrors := []error{errors.New(`"min_age" contains an invalid time value: "30days" is not a valid time value, the supported units are d, h, m, s, ms, micros and nanos, e.g. "30d" or "12h"`)}
+	if !reflect.DeepEqual(errs, wantErrors) {
+		t.Errorf("StringIsTimeValue() gotErrors = %v, want %v", errs, wantErrors)
+	}
+}
+
+func TestDiffTimeValueSuppress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		old, new string
+		equal    bool
+	}{
+		{"30d", "720h", true},
+		{"1h", "60m", true},
+		{"1s", "1000ms", true},
+		{"0ms", "0", true},
+		{"0ms", "0s", true},
+		{"30d", "31d", false},
+		{"", "30d", false},
+		{"30x", "30x", false},
+	}
+	for _, tt := range tests {
+		if got := DiffTimeValueSuppress("", tt.old, tt.new, nil); got != tt.equal {
+			t.Errorf("DiffTimeValueSuppress(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.equal)
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package provider
 
 import (
+	"strings"
+
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/cluster"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/document"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/ingest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/logstash"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/search"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/transform"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/watcher"
 	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const esKeyName = "elasticsearch"
@@ -24,8 +31,54 @@ func New(version string) *schema.Provider {
 
 		Schema: map[string]*schema.Schema{
 			esKeyName: providerSchema.GetConnectionSchema(esKeyName, true),
+			"verify_connection": {
+				Description: "Check the connection to Elasticsearch when the provider is configured, to report whether the network, TLS or authentication failed instead of failing on the first operation. Disable it to plan without reaching the cluster, e.g. with `-refresh=false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"resource_name_prefix": {
+				Description: "Prefix prepended to the names of the indices, data streams, index and component templates, ingest pipelines and index lifecycle policies created by the resources, e.g. the namespace of a team sharing the cluster. The `name` of the resources stays unprefixed. Only the own name of the resources is prefixed, the references to other objects, e.g. `composed_of`, `index_patterns` or `default_pipeline`, are sent as they are, so that the built-in and shared objects can still be referenced, and must use the prefixed names of the prefixed objects. The existing objects keep their name when the prefix changes, and are replaced on the next apply. Only the objects named with the prefix can be imported.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+			},
+			"ignore_version_check": {
+				Description: "Skip the detection of the Elasticsearch version and the checks of the features depending on it, e.g. against custom distributions reporting an unexpected version. The unsupported features then fail with the error of the Elasticsearch API. Use at your own risk.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"validate_pipeline_references": {
+				Description:  "Check that the ingest pipelines set as `index.default_pipeline` or `index.final_pipeline` exist, at apply time for the indices and the index and component templates: `off` by default, `warn` to report a warning on apply, or `error` to fail the apply, the index and component templates being also checked at plan time in this mode. The pipelines only known after apply are skipped at plan time, as well as the pipelines managed by an `elasticstack_elasticsearch_ingest_pipeline` the template depends on, e.g. by referencing its `name`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "off",
+				ValidateFunc: validation.StringInSlice([]string{"off", "warn", "error"}, false),
+			},
+			"reconcile_on_conflict": {
+				Description: "Reconcile the objects conflicting with a concurrent change instead of failing, e.g. when overlapping runs apply the same configuration to a shared cluster: the conflicting create or update of the security roles and users and of the index and component templates is retried, and an index created by the concurrent run is updated with the configured dynamic settings, mappings and aliases, failing when its static settings or the existing fields of its mappings differ, and a data stream created by the concurrent run is adopted. This is distinct from the retries of the failed HTTP requests.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"variant": {
+				Description:  "The distribution of the cluster, `elasticsearch` or `opensearch`. The `opensearch` variant is a best effort for the APIs compatible with Elasticsearch, e.g. the indices, the index and component templates and the ingest pipelines, the version-dependent checks are skipped and the resources and data sources specific to Elasticsearch, e.g. the security or the index lifecycle management ones, fail with an error.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      clients.VariantElasticsearch,
+				ValidateFunc: validation.StringInSlice([]string{clients.VariantElasticsearch, clients.VariantOpenSearch}, false),
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"elasticstack_elasticsearch_aliases":                            index.DataSourceAliases(),
+			"elasticstack_elasticsearch_cluster_health":                     clients.NotSupportedOnServerless("elasticstack_elasticsearch_cluster_health", cluster.DataSourceClusterHealth()),
+			"elasticstack_elasticsearch_component_template":                 index.DataSourceComponentTemplate(),
+			"elasticstack_elasticsearch_data_stream":                        index.DataSourceDataStream(),
+			"elasticstack_elasticsearch_document":                           document.DataSourceDocument(),
+			"elasticstack_elasticsearch_ilm_explain":                        clients.NotSupportedOnServerless("elasticstack_elasticsearch_ilm_explain", index.DataSourceIlmExplain()),
+			"elasticstack_elasticsearch_ilm_policy":                         clients.NotSupportedOnServerless("elasticstack_elasticsearch_ilm_policy", index.DataSourceIlmPolicy()),
+			"elasticstack_elasticsearch_indices":                            index.DataSourceIndices(),
 			"elasticstack_elasticsearch_ingest_processor_append":            ingest.DataSourceProcessorAppend(),
 			"elasticstack_elasticsearch_ingest_processor_bytes":             ingest.DataSourceProcessorBytes(),
 			"elasticstack_elasticsearch_ingest_processor_circle":            ingest.DataSourceProcessorCircle(),
@@ -64,32 +117,94 @@ func New(version string) *schema.Provider {
 			"elasticstack_elasticsearch_ingest_processor_urldecode":         ingest.DataSourceProcessorUrldecode(),
 			"elasticstack_elasticsearch_ingest_processor_uri_parts":         ingest.DataSourceProcessorUriParts(),
 			"elasticstack_elasticsearch_ingest_processor_user_agent":        ingest.DataSourceProcessorUserAgent(),
+			"elasticstack_elasticsearch_nodes":                              clients.NotSupportedOnServerless("elasticstack_elasticsearch_nodes", cluster.DataSourceNodes()),
+			"elasticstack_elasticsearch_script":                             cluster.DataSourceScript(),
+			"elasticstack_elasticsearch_search":                             search.DataSourceSearch(),
+			"elasticstack_elasticsearch_security_privileges":                security.DataSourcePrivileges(),
 			"elasticstack_elasticsearch_security_role":                      security.DataSourceRole(),
 			"elasticstack_elasticsearch_security_role_mapping":              security.DataSourceRoleMapping(),
-			"elasticstack_elasticsearch_security_user":                      security.DataSourceUser(),
-			"elasticstack_elasticsearch_snapshot_repository":                cluster.DataSourceSnapshotRespository(),
+			"elasticstack_elasticsearch_security_role_mappings":             security.DataSourceRoleMappings(),
+			"elasticstack_elasticsearch_security_roles":                     security.DataSourceRoles(),
+			"elasticstack_elasticsearch_security_user":                      clients.NotSupportedOnServerless("elasticstack_elasticsearch_security_user", security.DataSourceUser()),
+			"elasticstack_elasticsearch_snapshot_repositories":              clients.NotSupportedOnServerless("elasticstack_elasticsearch_snapshot_repositories", cluster.DataSourceSnapshotRepositories()),
+			"elasticstack_elasticsearch_snapshot_repository":                clients.NotSupportedOnServerless("elasticstack_elasticsearch_snapshot_repository", cluster.DataSourceSnapshotRespository()),
+			"elasticstack_elasticsearch_sql_query":                          search.DataSourceSqlQuery(),
+			"elasticstack_elasticsearch_tasks":                              clients.NotSupportedOnServerless("elasticstack_elasticsearch_tasks", cluster.DataSourceTasks()),
+			"elasticstack_elasticsearch_transform_preview":                  transform.DataSourceTransformPreview(),
+			"elasticstack_elasticsearch_watch":                              clients.NotSupportedOnServerless("elasticstack_elasticsearch_watch", watcher.DataSourceWatch()),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"elasticstack_elasticsearch_cluster_settings":      cluster.ResourceSettings(),
-			"elasticstack_elasticsearch_component_template":    index.ResourceComponentTemplate(),
-			"elasticstack_elasticsearch_data_stream":           index.ResourceDataStream(),
-			"elasticstack_elasticsearch_index":                 index.ResourceIndex(),
-			"elasticstack_elasticsearch_index_lifecycle":       index.ResourceIlm(),
-			"elasticstack_elasticsearch_index_template":        index.ResourceTemplate(),
-			"elasticstack_elasticsearch_ingest_pipeline":       ingest.ResourceIngestPipeline(),
-			"elasticstack_elasticsearch_logstash_pipeline":     logstash.ResourceLogstashPipeline(),
-			"elasticstack_elasticsearch_security_api_key":      security.ResourceApiKey(),
-			"elasticstack_elasticsearch_security_role":         security.ResourceRole(),
-			"elasticstack_elasticsearch_security_role_mapping": security.ResourceRoleMapping(),
-			"elasticstack_elasticsearch_security_user":         security.ResourceUser(),
-			"elasticstack_elasticsearch_security_system_user":  security.ResourceSystemUser(),
-			"elasticstack_elasticsearch_snapshot_lifecycle":    cluster.ResourceSlm(),
-			"elasticstack_elasticsearch_snapshot_repository":   cluster.ResourceSnapshotRepository(),
-			"elasticstack_elasticsearch_script":                cluster.ResourceScript(),
+			"elasticstack_elasticsearch_bulk_documents":                 document.ResourceBulkDocuments(),
+			"elasticstack_elasticsearch_cluster_settings":               clients.NotSupportedOnServerless("elasticstack_elasticsearch_cluster_settings", cluster.ResourceSettings()),
+			"elasticstack_elasticsearch_component_template":             index.ResourceComponentTemplate(),
+			"elasticstack_elasticsearch_data_stream":                    index.ResourceDataStream(),
+			"elasticstack_elasticsearch_delete_by_query":                document.ResourceDeleteByQuery(),
+			"elasticstack_elasticsearch_enrich_policy_execute":          ingest.ResourceEnrichPolicyExecute(),
+			"elasticstack_elasticsearch_index":                          index.ResourceIndex(),
+			"elasticstack_elasticsearch_index_lifecycle":                clients.NotSupportedOnServerless("elasticstack_elasticsearch_index_lifecycle", index.ResourceIlm()),
+			"elasticstack_elasticsearch_index_mapping":                  index.ResourceMapping(),
+			"elasticstack_elasticsearch_index_template":                 index.ResourceTemplate(),
+			"elasticstack_elasticsearch_ingest_pipeline":                ingest.ResourceIngestPipeline(),
+			"elasticstack_elasticsearch_logstash_pipeline":              clients.NotSupportedOnServerless("elasticstack_elasticsearch_logstash_pipeline", logstash.ResourceLogstashPipeline()),
+			"elasticstack_elasticsearch_security_api_key":               security.ResourceApiKey(),
+			"elasticstack_elasticsearch_security_application_privilege": security.ResourceApplicationPrivilege(),
+			"elasticstack_elasticsearch_security_role":                  security.ResourceRole(),
+			"elasticstack_elasticsearch_security_role_mapping":          security.ResourceRoleMapping(),
+			"elasticstack_elasticsearch_security_user":                  clients.NotSupportedOnServerless("elasticstack_elasticsearch_security_user", security.ResourceUser()),
+			"elasticstack_elasticsearch_security_system_user":           clients.NotSupportedOnServerless("elasticstack_elasticsearch_security_system_user", security.ResourceSystemUser()),
+			"elasticstack_elasticsearch_snapshot":                       clients.NotSupportedOnServerless("elasticstack_elasticsearch_snapshot", cluster.ResourceSnapshot()),
+			"elasticstack_elasticsearch_snapshot_lifecycle":             clients.NotSupportedOnServerless("elasticstack_elasticsearch_snapshot_lifecycle", cluster.ResourceSlm()),
+			"elasticstack_elasticsearch_snapshot_repository":            clients.NotSupportedOnServerless("elasticstack_elasticsearch_snapshot_repository", cluster.ResourceSnapshotRepository()),
+			"elasticstack_elasticsearch_script":                         cluster.ResourceScript(),
+			"elasticstack_elasticsearch_task_wait":                      clients.NotSupportedOnServerless("elasticstack_elasticsearch_task_wait", cluster.ResourceTaskWait()),
+			"elasticstack_elasticsearch_update_by_query":                document.ResourceUpdateByQuery(),
+			"elasticstack_elasticsearch_wait_for_cluster":               cluster.ResourceWaitForCluster(),
+			"elasticstack_elasticsearch_watch_ack":                      clients.NotSupportedOnServerless("elasticstack_elasticsearch_watch_ack", watcher.ResourceWatchAck()),
 		},
 	}
 
+	for name, r := range p.DataSourcesMap {
+		if !openSearchCompatible(name) {
+			p.DataSourcesMap[name] = clients.NotSupportedOnOpenSearch(name, r)
+		}
+	}
+	for name, r := range p.ResourcesMap {
+		if !openSearchCompatible(name) {
+			p.ResourcesMap[name] = clients.NotSupportedOnOpenSearch(name, r)
+		}
+	}
+
 	p.ConfigureContextFunc = clients.NewApiClientFunc(version)
 
 	return p
 }
+
+// openSearchCompatibleNames are the resources and data sources using the APIs shared by Elasticsearch and OpenSearch,
+// the others fail with the `opensearch` variant of the provider.
+var openSearchCompatibleNames = map[string]bool{
+	"elasticstack_elasticsearch_aliases":               true,
+	"elasticstack_elasticsearch_bulk_documents":        true,
+	"elasticstack_elasticsearch_cluster_health":        true,
+	"elasticstack_elasticsearch_cluster_settings":      true,
+	"elasticstack_elasticsearch_component_template":    true,
+	"elasticstack_elasticsearch_data_stream":           true,
+	"elasticstack_elasticsearch_delete_by_query":       true,
+	"elasticstack_elasticsearch_document":              true,
+	"elasticstack_elasticsearch_index":                 true,
+	"elasticstack_elasticsearch_index_mapping":         true,
+	"elasticstack_elasticsearch_index_template":        true,
+	"elasticstack_elasticsearch_indices":               true,
+	"elasticstack_elasticsearch_ingest_pipeline":       true,
+	"elasticstack_elasticsearch_script":                true,
+	"elasticstack_elasticsearch_search":                true,
+	"elasticstack_elasticsearch_snapshot":              true,
+	"elasticstack_elasticsearch_snapshot_repositories": true,
+	"elasticstack_elasticsearch_snapshot_repository":   true,
+	"elasticstack_elasticsearch_update_by_query":       true,
+	"elasticstack_elasticsearch_wait_for_cluster":      true,
+}
+
+func openSearchCompatible(name string) bool {
+	// the processor data sources only build the processor JSON, without calling the cluster
+	return openSearchCompatibleNames[name] || strings.HasPrefix(name, "elasticstack_elasticsearch_ingest_processor_")
+}
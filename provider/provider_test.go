@@ -1,16 +1,23 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/elastic/terraform-provider-elasticstack/provider"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestProvider(t *testing.T) {
@@ -70,3 +77,218 @@ data "elasticstack_elasticsearch_security_user" "test" {
 }
 `, apiKeyName, os.Getenv("ELASTICSEARCH_ENDPOINTS"))
 }
+
+func TestResourceNamePrefix(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceNamePrefix(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "name", name),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_index.test", "id", func(id string) error {
+						if !strings.HasSuffix(id, "/team-"+name) {
+							return fmt.Errorf("expected the ID of the prefixed index, got %s", id)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.name", "team-"+name),
+				),
+			},
+			{
+				// the objects named without the prefix are not imported
+				ResourceName: "elasticstack_elasticsearch_index.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return strings.Replace(s.RootModule().Resources["elasticstack_elasticsearch_index.test"].Primary.ID, "/team-", "/", 1), nil
+				},
+				ExpectError: regexp.MustCompile(`does not start with the resource_name_prefix`),
+			},
+			{
+				// the references to other objects are sent as they are
+				Config: testResourceNamePrefixReferences(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "composed_of.0", "team-"+name),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "index_patterns.0", name+"-*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "default_pipeline", "team-"+name),
+					checkIndexTemplatePatterns("team-"+name, name+"-*"),
+				),
+			},
+		},
+	})
+}
+
+func testResourceNamePrefixReferences(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+  resource_name_prefix = "team-"
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "ingested"
+        value = true
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_component_template" "test" {
+  name = "%[1]s"
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        field = { type = "keyword" }
+      }
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name           = "%[1]s"
+  index_patterns = ["%[1]s-*"]
+  composed_of    = ["team-${elasticstack_elasticsearch_component_template.test.name}"]
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name             = "%[1]s"
+  default_pipeline = "team-${elasticstack_elasticsearch_ingest_pipeline.test.name}"
+}
+
+data "elasticstack_elasticsearch_indices" "test" {
+  target = "team-%[1]s"
+
+  depends_on = [elasticstack_elasticsearch_index.test]
+}
+`, name)
+}
+
+// checkIndexTemplatePatterns checks the index patterns of the index template in the cluster.
+func checkIndexTemplatePatterns(name string, patterns ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		tpl, diags := elasticsearch.GetIndexTemplate(context.Background(), client, name)
+		if diags.HasError() {
+			return fmt.Errorf("failed to get the index template: %v", diags)
+		}
+		if tpl == nil {
+			return fmt.Errorf(`index template "%s" not found`, name)
+		}
+		if !reflect.DeepEqual(tpl.IndexTemplate.IndexPatterns, patterns) {
+			return fmt.Errorf("expected the index patterns %v, got %v", patterns, tpl.IndexTemplate.IndexPatterns)
+		}
+		return nil
+	}
+}
+
+func testResourceNamePrefix(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+  resource_name_prefix = "team-"
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name = "%[1]s"
+}
+
+data "elasticstack_elasticsearch_indices" "test" {
+  target = "team-%[1]s"
+
+  depends_on = [elasticstack_elasticsearch_index.test]
+}
+`, name)
+}
+
+func TestValidatePipelineReferences(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testValidatePipelineReferences(name, "error"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the ingest pipeline "missing-pipeline" referenced by "default_pipeline" does not exist`),
+			},
+			{
+				// only reported as a warning on apply when the references are to be warned about
+				Config:             testValidatePipelineReferences(name, "warn"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testValidatePipelineReferences(name, "warn"),
+				Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "name", name),
+			},
+			{
+				// the pipeline created in the same plan is not reported as missing
+				Config:             testValidatePlannedPipelineReferences(name),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testValidatePipelineReferences(name, mode string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+  validate_pipeline_references = "%[2]s"
+}
+
+resource "elasticstack_elasticsearch_component_template" "test" {
+  name = "%[1]s"
+
+  template {
+    settings = jsonencode({
+      index = {
+        default_pipeline = "missing-pipeline"
+      }
+    })
+  }
+}
+	`, name, mode)
+}
+
+func testValidatePlannedPipelineReferences(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+  validate_pipeline_references = "error"
+}
+
+resource "elasticstack_elasticsearch_ingest_pipeline" "test" {
+  name = "%[1]s"
+
+  processors = [
+    jsonencode({
+      set = {
+        field = "ingested"
+        value = true
+      }
+    })
+  ]
+}
+
+resource "elasticstack_elasticsearch_component_template" "test" {
+  name = "%[1]s"
+
+  template {
+    default_pipeline = elasticstack_elasticsearch_ingest_pipeline.test.name
+  }
+}
+	`, name)
+}